@@ -0,0 +1,94 @@
+package enterprise
+
+import (
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// ActivationErrorDetailsTypeURL is the type URL of the ActivationErrorDetails
+// attached to activation errors' grpc status
+const ActivationErrorDetailsTypeURL = "type.googleapis.com/enterprise.ActivationErrorDetails"
+
+// MaintenanceErrorDetailsTypeURL is the type URL of the
+// MaintenanceErrorDetails attached to maintenance errors' grpc status
+const MaintenanceErrorDetailsTypeURL = "type.googleapis.com/enterprise.MaintenanceErrorDetails"
+
+// Metrics is a snapshot of an enterprise server's state and counters, as
+// returned by its MetricsSnapshot method. The server's Prometheus metrics are
+// built from the same snapshot.
+type Metrics struct {
+	// Initialized is whether the server's cache has been primed from etcd.
+	// Until it has, State is NONE whether or not the cluster has a token
+	Initialized bool
+
+	// State is the cluster's enterprise state, and Expires is when its token
+	// expires (the zero time if there is no token)
+	State   State
+	Expires time.Time
+
+	// DaysRemaining is the number of whole days until the token expires. It's
+	// negative once the token has expired, and 0 if there is no token
+	DaysRemaining int64
+
+	// WatchConnected is whether the server's watch on the token is connected
+	WatchConnected bool
+	// WatchStalled is whether the server's last watch health check (see
+	// Options.WatchHealthCheckInterval) found that etcd had stopped
+	// delivering events, although the watch may still seem connected
+	WatchStalled bool
+
+	// LastUpdated is when etcd was last known to be reachable, i.e. when the
+	// server's cached state was last known to be up to date
+	LastUpdated time.Time
+
+	// Subscribers is the server's current number of state subscribers (e.g.
+	// WatchState callers). SlowConsumers and SignatureFailures count, across
+	// the process, the subscribers dropped for falling behind and the
+	// activation codes rejected for having an invalid signature
+	Subscribers       int64
+	SlowConsumers     uint64
+	SignatureFailures uint64
+}
+
+// GetActivationErrorDetails returns the ActivationErrorDetails attached to
+// 'err', an error returned by Activate (or a similar RPC), or nil if 'err'
+// has none (e.g. because it wasn't caused by an invalid activation code)
+func GetActivationErrorDetails(err error) *ActivationErrorDetails {
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, detail := range s.Proto().GetDetails() {
+		if detail.TypeUrl != ActivationErrorDetailsTypeURL {
+			continue
+		}
+		details := &ActivationErrorDetails{}
+		if err := details.Unmarshal(detail.Value); err != nil {
+			return nil
+		}
+		return details
+	}
+	return nil
+}
+
+// GetMaintenanceErrorDetails returns the MaintenanceErrorDetails attached to
+// 'err', or nil if 'err' has none (i.e. it wasn't returned because the server
+// is in maintenance mode)
+func GetMaintenanceErrorDetails(err error) *MaintenanceErrorDetails {
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, detail := range s.Proto().GetDetails() {
+		if detail.TypeUrl != MaintenanceErrorDetailsTypeURL {
+			continue
+		}
+		details := &MaintenanceErrorDetails{}
+		if err := details.Unmarshal(detail.Value); err != nil {
+			return nil
+		}
+		return details
+	}
+	return nil
+}
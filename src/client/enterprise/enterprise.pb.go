@@ -9,10 +9,61 @@ It is generated from these files:
 
 It has these top-level messages:
 	EnterpriseRecord
+	ActivationHistoryRecord
 	ActivateRequest
 	ActivateResponse
+	ActivationErrorDetails
+	MaintenanceErrorDetails
+	ActivateFromURLRequest
+	ActivateDetachedRequest
+	ActivatePartialRequest
+	ActivatePartialResponse
+	StageActivationRequest
+	StageActivationResponse
+	CommitActivationRequest
 	GetStateRequest
 	GetStateResponse
+	EffectiveState
+	WatchStateRequest
+	WatchStateResponse
+	DeactivateRequest
+	DeactivateResponse
+	RefreshStateRequest
+	RefreshStateResponse
+	GetActivationInfoRequest
+	GetActivationInfoResponse
+	RebuildRequest
+	RebuildResponse
+	GetQuotaRequest
+	GetQuotaResponse
+	CheckFeaturesRequest
+	FeatureEntitlement
+	CheckFeaturesResponse
+	TokenClaims
+	PreviewCodeRequest
+	PreviewCodeResponse
+	ValidateCodeAsOfRequest
+	ValidateCodeAsOfResponse
+	ExportHistoryRequest
+	ExportHistoryResponse
+	SetTrustedKeysRequest
+	SetTrustedKeysResponse
+	TrustedKey
+	ListTrustedKeysRequest
+	ListTrustedKeysResponse
+	ServerTimeRequest
+	ServerTimeResponse
+	GetConfigRequest
+	GetConfigResponse
+	SetEmergencyOverrideRequest
+	SetEmergencyOverrideResponse
+	InjectStateRequest
+	InjectStateResponse
+	SelfTestRequest
+	SelfTestResponse
+	DebugDumpRequest
+	EtcdEndpointHealth
+	DebugDumpResponse
 */
 package enterprise
 
@@ -20,6 +71,9 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/gogo/protobuf/types"
+import google_protobuf1 "github.com/gogo/protobuf/types"
+import google_protobuf2 "github.com/gogo/protobuf/types"
+import _ "github.com/gogo/protobuf/gogoproto"
 
 import (
 	context "golang.org/x/net/context"
@@ -39,6 +93,79 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// ActivationErrorReason identifies why an activation code was rejected
+type ActivationErrorReason int32
+
+const (
+	ActivationErrorReason_UNKNOWN_REASON ActivationErrorReason = 0
+	// MALFORMED_CODE means that the code isn't a well-formed activation code
+	ActivationErrorReason_MALFORMED_CODE ActivationErrorReason = 1
+	// INVALID_SIGNATURE means that the code isn't signed by a trusted key
+	ActivationErrorReason_INVALID_SIGNATURE ActivationErrorReason = 2
+	// INVALID_EXPIRY means that the code's expiry couldn't be parsed
+	ActivationErrorReason_INVALID_EXPIRY ActivationErrorReason = 3
+	// CODE_EXPIRED means that the code's expiry has passed
+	ActivationErrorReason_CODE_EXPIRED ActivationErrorReason = 4
+	// SERIAL_NOT_INCREASING means that the server requires monotonic
+	// activation, and the code's serial isn't greater than the current token's
+	ActivationErrorReason_SERIAL_NOT_INCREASING ActivationErrorReason = 5
+	// WRONG_ENVIRONMENT means that the code was issued for a different
+	// environment than the server's
+	ActivationErrorReason_WRONG_ENVIRONMENT ActivationErrorReason = 6
+	// LEASE_MISMATCH means that the caller requested a lease, and the code's
+	// expiry is too far from the end of that lease
+	ActivationErrorReason_LEASE_MISMATCH ActivationErrorReason = 7
+	// SCHEMA_TOO_OLD means that the code's token uses an older schema than the
+	// server's minimum supported schema version
+	ActivationErrorReason_SCHEMA_TOO_OLD ActivationErrorReason = 8
+	// EXPIRES_TOO_SOON means that the code expires sooner than the server's
+	// minimum remaining validity, so activating it would leave the cluster
+	// EXPIRED almost immediately. The request may set force to activate it anyway
+	ActivationErrorReason_EXPIRES_TOO_SOON ActivationErrorReason = 9
+	// WRONG_PRODUCT means that the code was issued for a different product than
+	// the server's, or (if the server requires one) doesn't name a product
+	ActivationErrorReason_WRONG_PRODUCT ActivationErrorReason = 10
+	// UNSUPPORTED_VERSION means that the code may only be activated on a range
+	// of Pachyderm versions that doesn't include the server's
+	ActivationErrorReason_UNSUPPORTED_VERSION ActivationErrorReason = 11
+)
+
+var ActivationErrorReason_name = map[int32]string{
+	0:  "UNKNOWN_REASON",
+	1:  "MALFORMED_CODE",
+	2:  "INVALID_SIGNATURE",
+	3:  "INVALID_EXPIRY",
+	4:  "CODE_EXPIRED",
+	5:  "SERIAL_NOT_INCREASING",
+	6:  "WRONG_ENVIRONMENT",
+	7:  "LEASE_MISMATCH",
+	8:  "SCHEMA_TOO_OLD",
+	9:  "EXPIRES_TOO_SOON",
+	10: "WRONG_PRODUCT",
+	11: "UNSUPPORTED_VERSION",
+}
+var ActivationErrorReason_value = map[string]int32{
+	"UNKNOWN_REASON":        0,
+	"MALFORMED_CODE":        1,
+	"INVALID_SIGNATURE":     2,
+	"INVALID_EXPIRY":        3,
+	"CODE_EXPIRED":          4,
+	"SERIAL_NOT_INCREASING": 5,
+	"WRONG_ENVIRONMENT":     6,
+	"LEASE_MISMATCH":        7,
+	"SCHEMA_TOO_OLD":        8,
+	"EXPIRES_TOO_SOON":      9,
+	"WRONG_PRODUCT":         10,
+	"UNSUPPORTED_VERSION":   11,
+}
+
+func (x ActivationErrorReason) String() string {
+	return proto.EnumName(ActivationErrorReason_name, int32(x))
+}
+func (ActivationErrorReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{0}
+}
+
 type State int32
 
 const (
@@ -61,13 +188,102 @@ var State_value = map[string]int32{
 func (x State) String() string {
 	return proto.EnumName(State_name, int32(x))
 }
-func (State) EnumDescriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{0} }
+func (State) EnumDescriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{1} }
+
+// TrustedKeySource describes where a trusted key came from
+type TrustedKeySource int32
+
+const (
+	TrustedKeySource_UNKNOWN_KEY_SOURCE TrustedKeySource = 0
+	// EMBEDDED is the key embedded in pachd
+	TrustedKeySource_EMBEDDED TrustedKeySource = 1
+	// ENV and FILE are keys that pachd was configured with, via its
+	// environment or a file
+	TrustedKeySource_ENV  TrustedKeySource = 2
+	TrustedKeySource_FILE TrustedKeySource = 3
+	// JWKS keys are fetched from a JWKS URL
+	TrustedKeySource_JWKS TrustedKeySource = 4
+	// SET_BY_RPC keys were set with the SetTrustedKeys RPC
+	TrustedKeySource_SET_BY_RPC TrustedKeySource = 5
+)
+
+var TrustedKeySource_name = map[int32]string{
+	0: "UNKNOWN_KEY_SOURCE",
+	1: "EMBEDDED",
+	2: "ENV",
+	3: "FILE",
+	4: "JWKS",
+	5: "SET_BY_RPC",
+}
+var TrustedKeySource_value = map[string]int32{
+	"UNKNOWN_KEY_SOURCE": 0,
+	"EMBEDDED":           1,
+	"ENV":                2,
+	"FILE":               3,
+	"JWKS":               4,
+	"SET_BY_RPC":         5,
+}
+
+func (x TrustedKeySource) String() string {
+	return proto.EnumName(TrustedKeySource_name, int32(x))
+}
+func (TrustedKeySource) EnumDescriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{2} }
 
 // EnterpriseRecord is the record we store of a Pachyderm enterprise token
 // that has been provided to a Pachyderm cluster
 type EnterpriseRecord struct {
-	ActivationCode string                     `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
-	Expires        *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+	ActivationCode string                      `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	Expires        *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+	// max_nodes is the number of nodes that the token permits the cluster to
+	// have, or 0 if the token doesn't limit the size of the cluster
+	MaxNodes int64 `protobuf:"varint,3,opt,name=max_nodes,json=maxNodes,proto3" json:"max_nodes,omitempty"`
+	// written is the time at which this record was written to etcd
+	Written *google_protobuf1.Timestamp `protobuf:"bytes,4,opt,name=written" json:"written,omitempty"`
+	// features are the enterprise features that the token enables
+	Features []string `protobuf:"bytes,5,rep,name=features" json:"features,omitempty"`
+	// max_pipelines is the number of pipelines that the token permits the
+	// cluster to run concurrently, or 0 if the token doesn't limit it
+	MaxPipelines int32 `protobuf:"varint,6,opt,name=max_pipelines,json=maxPipelines,proto3" json:"max_pipelines,omitempty"`
+	// max_storage_bytes is the amount of data that the token permits the
+	// cluster to store, or 0 if the token doesn't limit it
+	MaxStorageBytes int64 `protobuf:"varint,7,opt,name=max_storage_bytes,json=maxStorageBytes,proto3" json:"max_storage_bytes,omitempty"`
+	// serial is the serial number of the token. Issuers give each new token a
+	// larger serial than the last
+	Serial int64 `protobuf:"varint,8,opt,name=serial,proto3" json:"serial,omitempty"`
+	// environment is the environment (e.g. "prod" or "staging") that the token
+	// was issued for, or "" if it may be used in any environment
+	Environment string `protobuf:"bytes,9,opt,name=environment,proto3" json:"environment,omitempty"`
+	// issued_at is the time at which the token was issued, if the token
+	// records it
+	IssuedAt *google_protobuf1.Timestamp `protobuf:"bytes,10,opt,name=issued_at,json=issuedAt" json:"issued_at,omitempty"`
+	// activated_at is the time at which the token was activated on this cluster
+	ActivatedAt *google_protobuf1.Timestamp `protobuf:"bytes,11,opt,name=activated_at,json=activatedAt" json:"activated_at,omitempty"`
+	// key_id is the fingerprint of the trusted public key that the activation
+	// code's signature was verified with
+	KeyID string `protobuf:"bytes,12,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// schema_version is the version of the schema of the token's claims (at
+	// least 1)
+	SchemaVersion int64 `protobuf:"varint,13,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// sla is the support SLA that the token names, or "" if it doesn't name one
+	SLA string `protobuf:"bytes,14,opt,name=sla,proto3" json:"sla,omitempty"`
+	// lease_expires, if set, is when the etcd lease that the record is attached
+	// to (requested with ActivateRequest.lease_ttl) should end: the token's
+	// expiry plus the cluster's grace period
+	LeaseExpires *google_protobuf1.Timestamp `protobuf:"bytes,15,opt,name=lease_expires,json=leaseExpires" json:"lease_expires,omitempty"`
+	// product is the product that the token was issued for, or "" if the token
+	// doesn't name one
+	Product string `protobuf:"bytes,16,opt,name=product,proto3" json:"product,omitempty"`
+	// deprecated_features maps features that are being deprecated to a notice
+	// for users (e.g. what replaces the feature). It's informational only
+	DeprecatedFeatures map[string]string `protobuf:"bytes,17,rep,name=deprecated_features,json=deprecatedFeatures" json:"deprecated_features,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// feature_expiries maps features that the token enables for less time than
+	// the token itself to the time at which they expire
+	FeatureExpiries map[string]*google_protobuf1.Timestamp `protobuf:"bytes,18,rep,name=feature_expiries,json=featureExpiries" json:"feature_expiries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// min_version and max_version are the range of Pachyderm versions that the
+	// token may be activated on, inclusive. Either may be "", in which case the
+	// range is open at that end
+	MinVersion string `protobuf:"bytes,19,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	MaxVersion string `protobuf:"bytes,20,opt,name=max_version,json=maxVersion,proto3" json:"max_version,omitempty"`
 }
 
 func (m *EnterpriseRecord) Reset()                    { *m = EnterpriseRecord{} }
@@ -82,23 +298,205 @@ func (m *EnterpriseRecord) GetActivationCode() string {
 	return ""
 }
 
-func (m *EnterpriseRecord) GetExpires() *google_protobuf.Timestamp {
+func (m *EnterpriseRecord) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetMaxNodes() int64 {
+	if m != nil {
+		return m.MaxNodes
+	}
+	return 0
+}
+
+func (m *EnterpriseRecord) GetWritten() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Written
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetMaxPipelines() int32 {
+	if m != nil {
+		return m.MaxPipelines
+	}
+	return 0
+}
+
+func (m *EnterpriseRecord) GetMaxStorageBytes() int64 {
+	if m != nil {
+		return m.MaxStorageBytes
+	}
+	return 0
+}
+
+func (m *EnterpriseRecord) GetSerial() int64 {
+	if m != nil {
+		return m.Serial
+	}
+	return 0
+}
+
+func (m *EnterpriseRecord) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *EnterpriseRecord) GetIssuedAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.IssuedAt
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetActivatedAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.ActivatedAt
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *EnterpriseRecord) GetSchemaVersion() int64 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+func (m *EnterpriseRecord) GetSLA() string {
+	if m != nil {
+		return m.SLA
+	}
+	return ""
+}
+
+func (m *EnterpriseRecord) GetLeaseExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LeaseExpires
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetProduct() string {
+	if m != nil {
+		return m.Product
+	}
+	return ""
+}
+
+func (m *EnterpriseRecord) GetDeprecatedFeatures() map[string]string {
+	if m != nil {
+		return m.DeprecatedFeatures
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetFeatureExpiries() map[string]*google_protobuf1.Timestamp {
+	if m != nil {
+		return m.FeatureExpiries
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetMinVersion() string {
+	if m != nil {
+		return m.MinVersion
+	}
+	return ""
+}
+
+func (m *EnterpriseRecord) GetMaxVersion() string {
+	if m != nil {
+		return m.MaxVersion
+	}
+	return ""
+}
+
+// ActivationHistoryRecord records a single activation of a Pachyderm
+// enterprise token. It doesn't contain the activation code itself
+type ActivationHistoryRecord struct {
+	Activated *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=activated" json:"activated,omitempty"`
+	Expires   *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+	// activation_code_fingerprint identifies the activation code without
+	// revealing it
+	ActivationCodeFingerprint string   `protobuf:"bytes,3,opt,name=activation_code_fingerprint,json=activationCodeFingerprint,proto3" json:"activation_code_fingerprint,omitempty"`
+	Features                  []string `protobuf:"bytes,4,rep,name=features" json:"features,omitempty"`
+}
+
+func (m *ActivationHistoryRecord) Reset()         { *m = ActivationHistoryRecord{} }
+func (m *ActivationHistoryRecord) String() string { return proto.CompactTextString(m) }
+func (*ActivationHistoryRecord) ProtoMessage()    {}
+func (*ActivationHistoryRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{1}
+}
+
+func (m *ActivationHistoryRecord) GetActivated() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Activated
+	}
+	return nil
+}
+
+func (m *ActivationHistoryRecord) GetExpires() *google_protobuf1.Timestamp {
 	if m != nil {
 		return m.Expires
 	}
 	return nil
 }
 
+func (m *ActivationHistoryRecord) GetActivationCodeFingerprint() string {
+	if m != nil {
+		return m.ActivationCodeFingerprint
+	}
+	return ""
+}
+
+func (m *ActivationHistoryRecord) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 type ActivateRequest struct {
 	// activation_code is a Pachyderm enterprise activation code. New users can
 	// obtain trial activation codes
 	ActivationCode string `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	// force activates the code even if the server requires monotonic
+	// activation and the code's serial isn't greater than the current token's,
+	// or if the code expires sooner than the server's minimum remaining validity
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// lease_ttl, if set, is how long the caller expects the code's lease to
+	// last. The code is rejected if its expiry doesn't agree (see
+	// LEASE_MISMATCH). Otherwise, the token is stored with an etcd lease that
+	// ends once its grace period after expiry has passed, so that etcd deletes
+	// it then
+	LeaseTTL *google_protobuf.Duration `protobuf:"bytes,3,opt,name=lease_ttl,json=leaseTtl" json:"lease_ttl,omitempty"`
 }
 
 func (m *ActivateRequest) Reset()                    { *m = ActivateRequest{} }
 func (m *ActivateRequest) String() string            { return proto.CompactTextString(m) }
 func (*ActivateRequest) ProtoMessage()               {}
-func (*ActivateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{1} }
+func (*ActivateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{2} }
 
 func (m *ActivateRequest) GetActivationCode() string {
 	if m != nil {
@@ -107,357 +505,6173 @@ func (m *ActivateRequest) GetActivationCode() string {
 	return ""
 }
 
+func (m *ActivateRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *ActivateRequest) GetLeaseTTL() *google_protobuf.Duration {
+	if m != nil {
+		return m.LeaseTTL
+	}
+	return nil
+}
+
+// ActivateResponse describes the cluster's enterprise state as of the
+// activation, so that callers needn't follow Activate with GetState (which may
+// be served before the new token has been observed)
 type ActivateResponse struct {
+	State   State                       `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	Expires *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+	// key_id is the fingerprint of the trusted public key that the activation
+	// code's signature was verified with
+	KeyID string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
 }
 
 func (m *ActivateResponse) Reset()                    { *m = ActivateResponse{} }
 func (m *ActivateResponse) String() string            { return proto.CompactTextString(m) }
 func (*ActivateResponse) ProtoMessage()               {}
-func (*ActivateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{2} }
+func (*ActivateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{3} }
 
-type GetStateRequest struct {
+func (m *ActivateResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
 }
 
-func (m *GetStateRequest) Reset()                    { *m = GetStateRequest{} }
-func (m *GetStateRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetStateRequest) ProtoMessage()               {}
-func (*GetStateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{3} }
+func (m *ActivateResponse) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
 
-type GetStateResponse struct {
-	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+func (m *ActivateResponse) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
 }
 
-func (m *GetStateResponse) Reset()                    { *m = GetStateResponse{} }
-func (m *GetStateResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetStateResponse) ProtoMessage()               {}
-func (*GetStateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{4} }
+// ActivationErrorDetails is attached to the grpc status of errors returned
+// when an activation code is rejected, so that clients needn't parse the
+// error message
+type ActivationErrorDetails struct {
+	Reason ActivationErrorReason `protobuf:"varint,1,opt,name=reason,proto3,enum=enterprise.ActivationErrorReason" json:"reason,omitempty"`
+	// expires is the code's expiry, if reason is CODE_EXPIRED
+	Expires *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+}
 
-func (m *GetStateResponse) GetState() State {
+func (m *ActivationErrorDetails) Reset()         { *m = ActivationErrorDetails{} }
+func (m *ActivationErrorDetails) String() string { return proto.CompactTextString(m) }
+func (*ActivationErrorDetails) ProtoMessage()    {}
+func (*ActivationErrorDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{4}
+}
+
+func (m *ActivationErrorDetails) GetReason() ActivationErrorReason {
 	if m != nil {
-		return m.State
+		return m.Reason
 	}
-	return State_NONE
+	return ActivationErrorReason_UNKNOWN_REASON
 }
 
-func init() {
-	proto.RegisterType((*EnterpriseRecord)(nil), "enterprise.EnterpriseRecord")
-	proto.RegisterType((*ActivateRequest)(nil), "enterprise.ActivateRequest")
-	proto.RegisterType((*ActivateResponse)(nil), "enterprise.ActivateResponse")
-	proto.RegisterType((*GetStateRequest)(nil), "enterprise.GetStateRequest")
-	proto.RegisterType((*GetStateResponse)(nil), "enterprise.GetStateResponse")
-	proto.RegisterEnum("enterprise.State", State_name, State_value)
+func (m *ActivationErrorDetails) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+// MaintenanceErrorDetails is attached to the grpc status of the Unavailable
+// errors returned by RPCs that change the cluster's token while the server is
+// in maintenance mode
+type MaintenanceErrorDetails struct {
+	// retry_after is how long the caller should wait before retrying
+	RetryAfter *google_protobuf.Duration `protobuf:"bytes,1,opt,name=retry_after,json=retryAfter" json:"retry_after,omitempty"`
+}
 
-// Client API for API service
+func (m *MaintenanceErrorDetails) Reset()         { *m = MaintenanceErrorDetails{} }
+func (m *MaintenanceErrorDetails) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceErrorDetails) ProtoMessage()    {}
+func (*MaintenanceErrorDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{5}
+}
 
-type APIClient interface {
-	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
-	// features, such as the Pachyderm Dashboard and Auth system
-	Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
-	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
+func (m *MaintenanceErrorDetails) GetRetryAfter() *google_protobuf.Duration {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return nil
 }
 
-type aPIClient struct {
-	cc *grpc.ClientConn
+type ActivateFromURLRequest struct {
+	// url is an HTTPS URL from which a Pachyderm enterprise activation code
+	// can be downloaded (e.g. a short-lived signed URL)
+	URL string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// force is the same as ActivateRequest.force
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 }
 
-func NewAPIClient(cc *grpc.ClientConn) APIClient {
-	return &aPIClient{cc}
+func (m *ActivateFromURLRequest) Reset()         { *m = ActivateFromURLRequest{} }
+func (m *ActivateFromURLRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivateFromURLRequest) ProtoMessage()    {}
+func (*ActivateFromURLRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{6}
 }
 
-func (c *aPIClient) Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
-	out := new(ActivateResponse)
-	err := grpc.Invoke(ctx, "/enterprise.API/Activate", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ActivateFromURLRequest) GetURL() string {
+	if m != nil {
+		return m.URL
 	}
-	return out, nil
+	return ""
 }
 
-func (c *aPIClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
-	out := new(GetStateResponse)
-	err := grpc.Invoke(ctx, "/enterprise.API/GetState", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ActivateFromURLRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
 	}
-	return out, nil
+	return false
 }
 
-// Server API for API service
-
-type APIServer interface {
-	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
-	// features, such as the Pachyderm Dashboard and Auth system
-	Activate(context.Context, *ActivateRequest) (*ActivateResponse, error)
-	GetState(context.Context, *GetStateRequest) (*GetStateResponse, error)
+// ActivateDetachedRequest carries an activation code's token and signature
+// separately, for license delivery systems that ship them as two files
+type ActivateDetachedRequest struct {
+	// token is the signed token (a JSON document), exactly as it was signed
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// signature is the base64-encoded signature of token
+	Signature string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// force is the same as ActivateRequest.force
+	Force bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
 }
 
-func RegisterAPIServer(s *grpc.Server, srv APIServer) {
-	s.RegisterService(&_API_serviceDesc, srv)
+func (m *ActivateDetachedRequest) Reset()         { *m = ActivateDetachedRequest{} }
+func (m *ActivateDetachedRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivateDetachedRequest) ProtoMessage()    {}
+func (*ActivateDetachedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{7}
 }
 
-func _API_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ActivateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ActivateDetachedRequest) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	if interceptor == nil {
-		return srv.(APIServer).Activate(ctx, in)
+	return ""
+}
+
+func (m *ActivateDetachedRequest) GetSignature() string {
+	if m != nil {
+		return m.Signature
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/enterprise.API/Activate",
+	return ""
+}
+
+func (m *ActivateDetachedRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).Activate(ctx, req.(*ActivateRequest))
+	return false
+}
+
+// ActivatePartialRequest carries one part of an activation code that has been
+// split into several parts
+type ActivatePartialRequest struct {
+	// upload_id identifies the activation code that this is a part of. All
+	// parts of the same code must have the same upload_id, and be sent to the
+	// same server
+	UploadID string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// index is the position of this part in the activation code, from 0 to
+	// total - 1. Parts may be sent in any order
+	Index int64  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Total int64  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Part  []byte `protobuf:"bytes,4,opt,name=part,proto3" json:"part,omitempty"`
+}
+
+func (m *ActivatePartialRequest) Reset()         { *m = ActivatePartialRequest{} }
+func (m *ActivatePartialRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivatePartialRequest) ProtoMessage()    {}
+func (*ActivatePartialRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{8}
+}
+
+func (m *ActivatePartialRequest) GetUploadID() string {
+	if m != nil {
+		return m.UploadID
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _API_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetStateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ActivatePartialRequest) GetIndex() int64 {
+	if m != nil {
+		return m.Index
 	}
-	if interceptor == nil {
-		return srv.(APIServer).GetState(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/enterprise.API/GetState",
+	return 0
+}
+
+func (m *ActivatePartialRequest) GetTotal() int64 {
+	if m != nil {
+		return m.Total
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetState(ctx, req.(*GetStateRequest))
+	return 0
+}
+
+func (m *ActivatePartialRequest) GetPart() []byte {
+	if m != nil {
+		return m.Part
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _API_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "enterprise.API",
-	HandlerType: (*APIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Activate",
-			Handler:    _API_Activate_Handler,
-		},
-		{
-			MethodName: "GetState",
-			Handler:    _API_GetState_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "client/enterprise/enterprise.proto",
+type ActivatePartialResponse struct {
+	// received is the number of distinct parts of the code received so far
+	Received int64 `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	// activated is true once all parts have been received, and the assembled
+	// code has been validated and activated
+	Activated bool `protobuf:"varint,2,opt,name=activated,proto3" json:"activated,omitempty"`
 }
 
-func (m *EnterpriseRecord) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ActivatePartialResponse) Reset()         { *m = ActivatePartialResponse{} }
+func (m *ActivatePartialResponse) String() string { return proto.CompactTextString(m) }
+func (*ActivatePartialResponse) ProtoMessage()    {}
+func (*ActivatePartialResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{9}
 }
 
-func (m *EnterpriseRecord) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ActivationCode) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
-		i += copy(dAtA[i:], m.ActivationCode)
+func (m *ActivatePartialResponse) GetReceived() int64 {
+	if m != nil {
+		return m.Received
 	}
-	if m.Expires != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
-		n1, err := m.Expires.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+	return 0
+}
+
+func (m *ActivatePartialResponse) GetActivated() bool {
+	if m != nil {
+		return m.Activated
 	}
-	return i, nil
+	return false
 }
 
-func (m *ActivateRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+// StageActivationRequest carries an activation code to validate and hold,
+// without activating it yet
+type StageActivationRequest struct {
+	ActivationCode string `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+}
+
+func (m *StageActivationRequest) Reset()         { *m = StageActivationRequest{} }
+func (m *StageActivationRequest) String() string { return proto.CompactTextString(m) }
+func (*StageActivationRequest) ProtoMessage()    {}
+func (*StageActivationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{10}
+}
+
+func (m *StageActivationRequest) GetActivationCode() string {
+	if m != nil {
+		return m.ActivationCode
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ActivateRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ActivationCode) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
-		i += copy(dAtA[i:], m.ActivationCode)
+type StageActivationResponse struct {
+	// stage_id identifies the staged code in a CommitActivationRequest
+	StageID string `protobuf:"bytes,1,opt,name=stage_id,json=stageId,proto3" json:"stage_id,omitempty"`
+	// expires is when the staged code is discarded, if it hasn't been committed
+	Expires *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+}
+
+func (m *StageActivationResponse) Reset()         { *m = StageActivationResponse{} }
+func (m *StageActivationResponse) String() string { return proto.CompactTextString(m) }
+func (*StageActivationResponse) ProtoMessage()    {}
+func (*StageActivationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{11}
+}
+
+func (m *StageActivationResponse) GetStageID() string {
+	if m != nil {
+		return m.StageID
 	}
-	return i, nil
+	return ""
 }
 
-func (m *ActivateResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *StageActivationResponse) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ActivateResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type CommitActivationRequest struct {
+	StageID string `protobuf:"bytes,1,opt,name=stage_id,json=stageId,proto3" json:"stage_id,omitempty"`
 }
 
-func (m *GetStateRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CommitActivationRequest) Reset()         { *m = CommitActivationRequest{} }
+func (m *CommitActivationRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitActivationRequest) ProtoMessage()    {}
+func (*CommitActivationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{12}
+}
+
+func (m *CommitActivationRequest) GetStageID() string {
+	if m != nil {
+		return m.StageID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *GetStateRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type GetStateRequest struct {
+	// include_effective_state, if set, makes state reflect the cluster's token
+	// alone, ignoring any grace period or emergency override, and makes the
+	// response include effective_state. Otherwise state is the effective state,
+	// as it always has been
+	IncludeEffectiveState bool `protobuf:"varint,1,opt,name=include_effective_state,json=includeEffectiveState,proto3" json:"include_effective_state,omitempty"`
 }
 
-func (m *GetStateResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *GetStateRequest) Reset()                    { *m = GetStateRequest{} }
+func (m *GetStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStateRequest) ProtoMessage()               {}
+func (*GetStateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{13} }
+
+func (m *GetStateRequest) GetIncludeEffectiveState() bool {
+	if m != nil {
+		return m.IncludeEffectiveState
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *GetStateResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.State != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+type GetStateResponse struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	// warnings describes any issues with the cluster's enterprise token that
+	// the user should address (e.g. that it expires soon). Unlike state, there
+	// may be several of these at once
+	Warnings []string `protobuf:"bytes,2,rep,name=warnings" json:"warnings,omitempty"`
+	// next_check_after is a hint for clients that poll GetState, indicating how
+	// long they may wait before calling GetState again. It shrinks as the
+	// cluster's token approaches a change in state (e.g. expiring)
+	NextCheckAfter *google_protobuf.Duration `protobuf:"bytes,3,opt,name=next_check_after,json=nextCheckAfter" json:"next_check_after,omitempty"`
+	// stale is true if the server has been unable to reach etcd for a while,
+	// in which case state may be out of date
+	Stale bool `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	// last_updated is set when stale is true, and is the last time at which
+	// the server's state was known to match etcd
+	LastUpdated *google_protobuf1.Timestamp `protobuf:"bytes,5,opt,name=last_updated,json=lastUpdated" json:"last_updated,omitempty"`
+	// effective_state is set if the request set include_effective_state
+	EffectiveState *EffectiveState `protobuf:"bytes,6,opt,name=effective_state,json=effectiveState" json:"effective_state,omitempty"`
+	// state_entered_at is when the cluster entered its current state (the
+	// state reported when include_effective_state is unset), e.g. for "active
+	// since" displays. If the server hasn't observed the transition (e.g.
+	// because pachd restarted since), it's estimated conservatively: for an
+	// ACTIVE cluster, it's when the current token was activated
+	StateEnteredAt *google_protobuf1.Timestamp `protobuf:"bytes,7,opt,name=state_entered_at,json=stateEnteredAt" json:"state_entered_at,omitempty"`
+}
+
+func (m *GetStateResponse) Reset()                    { *m = GetStateResponse{} }
+func (m *GetStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStateResponse) ProtoMessage()               {}
+func (*GetStateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{14} }
+
+func (m *GetStateResponse) GetState() State {
+	if m != nil {
+		return m.State
 	}
-	return i, nil
+	return State_NONE
 }
 
-func encodeFixed64Enterprise(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
+func (m *GetStateResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
 }
-func encodeFixed32Enterprise(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
+
+func (m *GetStateResponse) GetNextCheckAfter() *google_protobuf.Duration {
+	if m != nil {
+		return m.NextCheckAfter
+	}
+	return nil
 }
-func encodeVarintEnterprise(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+
+func (m *GetStateResponse) GetStale() bool {
+	if m != nil {
+		return m.Stale
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return false
 }
-func (m *EnterpriseRecord) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ActivationCode)
-	if l > 0 {
-		n += 1 + l + sovEnterprise(uint64(l))
+
+func (m *GetStateResponse) GetLastUpdated() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LastUpdated
 	}
-	if m.Expires != nil {
-		l = m.Expires.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
+	return nil
+}
+
+func (m *GetStateResponse) GetEffectiveState() *EffectiveState {
+	if m != nil {
+		return m.EffectiveState
 	}
-	return n
+	return nil
 }
 
-func (m *ActivateRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ActivationCode)
-	if l > 0 {
-		n += 1 + l + sovEnterprise(uint64(l))
+func (m *GetStateResponse) GetStateEnteredAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StateEnteredAt
 	}
-	return n
+	return nil
 }
 
-func (m *ActivateResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+// EffectiveState describes what a cluster's token actually entitles it to
+// right now, once the grace period and any emergency override are taken into
+// account
+type EffectiveState struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	// features are the enterprise features that are enabled: the token's
+	// features if state is ACTIVE, and none otherwise
+	Features []string `protobuf:"bytes,2,rep,name=features" json:"features,omitempty"`
+	// in_grace_period is true if the token has expired, but its grace period
+	// hasn't ended
+	InGracePeriod bool `protobuf:"varint,3,opt,name=in_grace_period,json=inGracePeriod,proto3" json:"in_grace_period,omitempty"`
+	// emergency_override is true if an emergency override is in effect
+	EmergencyOverride bool `protobuf:"varint,4,opt,name=emergency_override,json=emergencyOverride,proto3" json:"emergency_override,omitempty"`
 }
 
-func (m *GetStateRequest) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *EffectiveState) Reset()                    { *m = EffectiveState{} }
+func (m *EffectiveState) String() string            { return proto.CompactTextString(m) }
+func (*EffectiveState) ProtoMessage()               {}
+func (*EffectiveState) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{15} }
+
+func (m *EffectiveState) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
 }
 
-func (m *GetStateResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.State != 0 {
-		n += 1 + sovEnterprise(uint64(m.State))
+func (m *EffectiveState) GetFeatures() []string {
+	if m != nil {
+		return m.Features
 	}
-	return n
+	return nil
 }
 
-func sovEnterprise(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *EffectiveState) GetInGracePeriod() bool {
+	if m != nil {
+		return m.InGracePeriod
 	}
-	return n
+	return false
 }
-func sozEnterprise(x uint64) (n int) {
-	return sovEnterprise(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *EffectiveState) GetEmergencyOverride() bool {
+	if m != nil {
+		return m.EmergencyOverride
+	}
+	return false
+}
+
+type WatchStateRequest struct {
+	// state_mask, if set, limits the state changes that are sent to those into
+	// the given states. The cluster's current state is always sent first,
+	// unless send_initial is false
+	StateMask []State `protobuf:"varint,1,rep,packed,name=state_mask,json=stateMask,enum=enterprise.State" json:"state_mask,omitempty"`
+	// send_initial, if false, causes only subsequent state changes to be sent,
+	// rather than the cluster's current state followed by its changes. It's
+	// true if unset
+	SendInitial *google_protobuf2.BoolValue `protobuf:"bytes,2,opt,name=send_initial,json=sendInitial" json:"send_initial,omitempty"`
+}
+
+func (m *WatchStateRequest) Reset()                    { *m = WatchStateRequest{} }
+func (m *WatchStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchStateRequest) ProtoMessage()               {}
+func (*WatchStateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{16} }
+
+func (m *WatchStateRequest) GetStateMask() []State {
+	if m != nil {
+		return m.StateMask
+	}
+	return nil
+}
+
+func (m *WatchStateRequest) GetSendInitial() *google_protobuf2.BoolValue {
+	if m != nil {
+		return m.SendInitial
+	}
+	return nil
+}
+
+type WatchStateResponse struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	// added_features and removed_features are the enterprise features that the
+	// cluster's token enables, and no longer enables, since the previous
+	// response (e.g. because the token was renewed at a different tier). A
+	// response is sent when they're non-empty, even if the state is unchanged.
+	// They're always empty in the first response
+	AddedFeatures   []string `protobuf:"bytes,2,rep,name=added_features,json=addedFeatures" json:"added_features,omitempty"`
+	RemovedFeatures []string `protobuf:"bytes,3,rep,name=removed_features,json=removedFeatures" json:"removed_features,omitempty"`
+	// expiring_features are the features whose own expiries (see
+	// EnterpriseRecord.feature_expiries) are within the server's expiry warning
+	// window, sorted. A response is sent when they change, even if the state is
+	// unchanged (e.g. while the cluster's token remains ACTIVE)
+	ExpiringFeatures []string `protobuf:"bytes,4,rep,name=expiring_features,json=expiringFeatures" json:"expiring_features,omitempty"`
+}
+
+func (m *WatchStateResponse) Reset()                    { *m = WatchStateResponse{} }
+func (m *WatchStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*WatchStateResponse) ProtoMessage()               {}
+func (*WatchStateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{17} }
+
+func (m *WatchStateResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+func (m *WatchStateResponse) GetAddedFeatures() []string {
+	if m != nil {
+		return m.AddedFeatures
+	}
+	return nil
+}
+
+func (m *WatchStateResponse) GetRemovedFeatures() []string {
+	if m != nil {
+		return m.RemovedFeatures
+	}
+	return nil
+}
+
+func (m *WatchStateResponse) GetExpiringFeatures() []string {
+	if m != nil {
+		return m.ExpiringFeatures
+	}
+	return nil
+}
+
+type DeactivateRequest struct {
+}
+
+func (m *DeactivateRequest) Reset()                    { *m = DeactivateRequest{} }
+func (m *DeactivateRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeactivateRequest) ProtoMessage()               {}
+func (*DeactivateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{18} }
+
+type DeactivateResponse struct {
+	// already_inactive is true if the cluster had no enterprise token to remove
+	AlreadyInactive bool `protobuf:"varint,1,opt,name=already_inactive,json=alreadyInactive,proto3" json:"already_inactive,omitempty"`
+}
+
+func (m *DeactivateResponse) Reset()                    { *m = DeactivateResponse{} }
+func (m *DeactivateResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeactivateResponse) ProtoMessage()               {}
+func (*DeactivateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{19} }
+
+func (m *DeactivateResponse) GetAlreadyInactive() bool {
+	if m != nil {
+		return m.AlreadyInactive
+	}
+	return false
+}
+
+type RefreshStateRequest struct {
+}
+
+func (m *RefreshStateRequest) Reset()                    { *m = RefreshStateRequest{} }
+func (m *RefreshStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*RefreshStateRequest) ProtoMessage()               {}
+func (*RefreshStateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{20} }
+
+type RefreshStateResponse struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+}
+
+func (m *RefreshStateResponse) Reset()                    { *m = RefreshStateResponse{} }
+func (m *RefreshStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*RefreshStateResponse) ProtoMessage()               {}
+func (*RefreshStateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{21} }
+
+func (m *RefreshStateResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+type GetActivationInfoRequest struct {
+}
+
+func (m *GetActivationInfoRequest) Reset()         { *m = GetActivationInfoRequest{} }
+func (m *GetActivationInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetActivationInfoRequest) ProtoMessage()    {}
+func (*GetActivationInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{22}
+}
+
+type GetActivationInfoResponse struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	// expires and activated_at are unset if the cluster has no token (or, for
+	// activated_at, if it's unknown)
+	Expires     *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+	ActivatedAt *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=activated_at,json=activatedAt" json:"activated_at,omitempty"`
+	// key_id is the fingerprint of the trusted public key that the current
+	// token's activation code was verified with, if known
+	KeyID string `protobuf:"bytes,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// sla is the support SLA (e.g. a maintenance window) that the current token
+	// entitles the cluster to, or "" if it doesn't name one. It's metadata for
+	// display only; pachd doesn't enforce it
+	SLA string `protobuf:"bytes,5,opt,name=sla,proto3" json:"sla,omitempty"`
+	// deprecated_features maps features that the current token marks as
+	// deprecated to the notice that it gives for each, for display only
+	DeprecatedFeatures map[string]string `protobuf:"bytes,6,rep,name=deprecated_features,json=deprecatedFeatures" json:"deprecated_features,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// record_hash is the hex-encoded SHA-256 hash of the EnterpriseRecord
+	// exactly as it's stored in etcd, or "" if there is no token, so that
+	// clients can detect unexpected changes to it. Unlike the rest of the
+	// response, it's read from etcd on every call
+	RecordHash string `protobuf:"bytes,7,opt,name=record_hash,json=recordHash,proto3" json:"record_hash,omitempty"`
+}
+
+func (m *GetActivationInfoResponse) Reset()         { *m = GetActivationInfoResponse{} }
+func (m *GetActivationInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetActivationInfoResponse) ProtoMessage()    {}
+func (*GetActivationInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{23}
+}
+
+func (m *GetActivationInfoResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+func (m *GetActivationInfoResponse) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *GetActivationInfoResponse) GetActivatedAt() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.ActivatedAt
+	}
+	return nil
+}
+
+func (m *GetActivationInfoResponse) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *GetActivationInfoResponse) GetSLA() string {
+	if m != nil {
+		return m.SLA
+	}
+	return ""
+}
+
+func (m *GetActivationInfoResponse) GetDeprecatedFeatures() map[string]string {
+	if m != nil {
+		return m.DeprecatedFeatures
+	}
+	return nil
+}
+
+func (m *GetActivationInfoResponse) GetRecordHash() string {
+	if m != nil {
+		return m.RecordHash
+	}
+	return ""
+}
+
+type RebuildRequest struct {
+}
+
+func (m *RebuildRequest) Reset()                    { *m = RebuildRequest{} }
+func (m *RebuildRequest) String() string            { return proto.CompactTextString(m) }
+func (*RebuildRequest) ProtoMessage()               {}
+func (*RebuildRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{24} }
+
+type RebuildResponse struct {
+	// state is the enterprise state described by the rebuilt cache
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+}
+
+func (m *RebuildResponse) Reset()                    { *m = RebuildResponse{} }
+func (m *RebuildResponse) String() string            { return proto.CompactTextString(m) }
+func (*RebuildResponse) ProtoMessage()               {}
+func (*RebuildResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{25} }
+
+func (m *RebuildResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+type GetQuotaRequest struct {
+}
+
+func (m *GetQuotaRequest) Reset()                    { *m = GetQuotaRequest{} }
+func (m *GetQuotaRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetQuotaRequest) ProtoMessage()               {}
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{26} }
+
+// GetQuotaResponse contains the numeric limits in the cluster's enterprise
+// token. A limit of 0 means that the token doesn't impose that limit. If state
+// is NONE, the cluster has no token, and all limits are 0.
+type GetQuotaResponse struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	// max_nodes is the token's seat limit: the number of nodes that the
+	// cluster may have
+	MaxNodes        int64 `protobuf:"varint,2,opt,name=max_nodes,json=maxNodes,proto3" json:"max_nodes,omitempty"`
+	MaxPipelines    int32 `protobuf:"varint,3,opt,name=max_pipelines,json=maxPipelines,proto3" json:"max_pipelines,omitempty"`
+	MaxStorageBytes int64 `protobuf:"varint,4,opt,name=max_storage_bytes,json=maxStorageBytes,proto3" json:"max_storage_bytes,omitempty"`
+}
+
+func (m *GetQuotaResponse) Reset()                    { *m = GetQuotaResponse{} }
+func (m *GetQuotaResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetQuotaResponse) ProtoMessage()               {}
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{27} }
+
+func (m *GetQuotaResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+func (m *GetQuotaResponse) GetMaxNodes() int64 {
+	if m != nil {
+		return m.MaxNodes
+	}
+	return 0
+}
+
+func (m *GetQuotaResponse) GetMaxPipelines() int32 {
+	if m != nil {
+		return m.MaxPipelines
+	}
+	return 0
+}
+
+func (m *GetQuotaResponse) GetMaxStorageBytes() int64 {
+	if m != nil {
+		return m.MaxStorageBytes
+	}
+	return 0
+}
+
+// CheckFeaturesRequest asks whether the cluster's enterprise token enables
+// each of 'names'
+type CheckFeaturesRequest struct {
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (m *CheckFeaturesRequest) Reset()                    { *m = CheckFeaturesRequest{} }
+func (m *CheckFeaturesRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckFeaturesRequest) ProtoMessage()               {}
+func (*CheckFeaturesRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{28} }
+
+func (m *CheckFeaturesRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+// FeatureEntitlement reports whether the cluster's enterprise token enables a
+// feature
+type FeatureEntitlement struct {
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entitled bool   `protobuf:"varint,2,opt,name=entitled,proto3" json:"entitled,omitempty"`
+	// deprecation is the token's deprecation notice for the feature, or "" if
+	// the token doesn't mark it as deprecated. It doesn't affect entitled
+	Deprecation string `protobuf:"bytes,3,opt,name=deprecation,proto3" json:"deprecation,omitempty"`
+}
+
+func (m *FeatureEntitlement) Reset()                    { *m = FeatureEntitlement{} }
+func (m *FeatureEntitlement) String() string            { return proto.CompactTextString(m) }
+func (*FeatureEntitlement) ProtoMessage()               {}
+func (*FeatureEntitlement) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{29} }
+
+func (m *FeatureEntitlement) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FeatureEntitlement) GetEntitled() bool {
+	if m != nil {
+		return m.Entitled
+	}
+	return false
+}
+
+func (m *FeatureEntitlement) GetDeprecation() string {
+	if m != nil {
+		return m.Deprecation
+	}
+	return ""
+}
+
+// CheckFeaturesResponse contains a FeatureEntitlement for each feature named
+// in the request, in the same order. Features are only entitled while the
+// cluster's state is ACTIVE
+type CheckFeaturesResponse struct {
+	Features []*FeatureEntitlement `protobuf:"bytes,1,rep,name=features" json:"features,omitempty"`
+	// all_entitled is true if every feature in the request is entitled
+	AllEntitled bool `protobuf:"varint,2,opt,name=all_entitled,json=allEntitled,proto3" json:"all_entitled,omitempty"`
+}
+
+func (m *CheckFeaturesResponse) Reset()         { *m = CheckFeaturesResponse{} }
+func (m *CheckFeaturesResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckFeaturesResponse) ProtoMessage()    {}
+func (*CheckFeaturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{30}
+}
+
+func (m *CheckFeaturesResponse) GetFeatures() []*FeatureEntitlement {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *CheckFeaturesResponse) GetAllEntitled() bool {
+	if m != nil {
+		return m.AllEntitled
+	}
+	return false
+}
+
+// TokenClaims are the entitlements that an enterprise token grants
+type TokenClaims struct {
+	Expires         *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=expires" json:"expires,omitempty"`
+	Features        []string                    `protobuf:"bytes,2,rep,name=features" json:"features,omitempty"`
+	MaxNodes        int64                       `protobuf:"varint,3,opt,name=max_nodes,json=maxNodes,proto3" json:"max_nodes,omitempty"`
+	MaxPipelines    int32                       `protobuf:"varint,4,opt,name=max_pipelines,json=maxPipelines,proto3" json:"max_pipelines,omitempty"`
+	MaxStorageBytes int64                       `protobuf:"varint,5,opt,name=max_storage_bytes,json=maxStorageBytes,proto3" json:"max_storage_bytes,omitempty"`
+	Serial          int64                       `protobuf:"varint,6,opt,name=serial,proto3" json:"serial,omitempty"`
+	Environment     string                      `protobuf:"bytes,7,opt,name=environment,proto3" json:"environment,omitempty"`
+}
+
+func (m *TokenClaims) Reset()                    { *m = TokenClaims{} }
+func (m *TokenClaims) String() string            { return proto.CompactTextString(m) }
+func (*TokenClaims) ProtoMessage()               {}
+func (*TokenClaims) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{31} }
+
+func (m *TokenClaims) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *TokenClaims) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *TokenClaims) GetMaxNodes() int64 {
+	if m != nil {
+		return m.MaxNodes
+	}
+	return 0
+}
+
+func (m *TokenClaims) GetMaxPipelines() int32 {
+	if m != nil {
+		return m.MaxPipelines
+	}
+	return 0
+}
+
+func (m *TokenClaims) GetMaxStorageBytes() int64 {
+	if m != nil {
+		return m.MaxStorageBytes
+	}
+	return 0
+}
+
+func (m *TokenClaims) GetSerial() int64 {
+	if m != nil {
+		return m.Serial
+	}
+	return 0
+}
+
+func (m *TokenClaims) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+type PreviewCodeRequest struct {
+	// code is the activation code to preview
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (m *PreviewCodeRequest) Reset()                    { *m = PreviewCodeRequest{} }
+func (m *PreviewCodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*PreviewCodeRequest) ProtoMessage()               {}
+func (*PreviewCodeRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{32} }
+
+func (m *PreviewCodeRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+// PreviewCodeResponse compares the claims of an activation code with those of
+// the cluster's current token
+type PreviewCodeResponse struct {
+	Proposed *TokenClaims `protobuf:"bytes,1,opt,name=proposed" json:"proposed,omitempty"`
+	// current is unset if the cluster has no token
+	Current *TokenClaims `protobuf:"bytes,2,opt,name=current" json:"current,omitempty"`
+	// added_features are enabled by the proposed code but not the current
+	// token, and removed_features are the reverse
+	AddedFeatures   []string `protobuf:"bytes,3,rep,name=added_features,json=addedFeatures" json:"added_features,omitempty"`
+	RemovedFeatures []string `protobuf:"bytes,4,rep,name=removed_features,json=removedFeatures" json:"removed_features,omitempty"`
+}
+
+func (m *PreviewCodeResponse) Reset()                    { *m = PreviewCodeResponse{} }
+func (m *PreviewCodeResponse) String() string            { return proto.CompactTextString(m) }
+func (*PreviewCodeResponse) ProtoMessage()               {}
+func (*PreviewCodeResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{33} }
+
+func (m *PreviewCodeResponse) GetProposed() *TokenClaims {
+	if m != nil {
+		return m.Proposed
+	}
+	return nil
+}
+
+func (m *PreviewCodeResponse) GetCurrent() *TokenClaims {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+func (m *PreviewCodeResponse) GetAddedFeatures() []string {
+	if m != nil {
+		return m.AddedFeatures
+	}
+	return nil
+}
+
+func (m *PreviewCodeResponse) GetRemovedFeatures() []string {
+	if m != nil {
+		return m.RemovedFeatures
+	}
+	return nil
+}
+
+type ValidateCodeAsOfRequest struct {
+	// code is the activation code to validate
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// as_of is the time that the code's expiry is checked against, instead of
+	// the current time
+	AsOf *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf" json:"as_of,omitempty"`
+}
+
+func (m *ValidateCodeAsOfRequest) Reset()         { *m = ValidateCodeAsOfRequest{} }
+func (m *ValidateCodeAsOfRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateCodeAsOfRequest) ProtoMessage()    {}
+func (*ValidateCodeAsOfRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{34}
+}
+
+func (m *ValidateCodeAsOfRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *ValidateCodeAsOfRequest) GetAsOf() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.AsOf
+	}
+	return nil
+}
+
+// ValidateCodeAsOfResponse reports whether an activation code was (or will
+// be) valid at a given time
+type ValidateCodeAsOfResponse struct {
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// claims are the code's claims, if it's valid
+	Claims *TokenClaims `protobuf:"bytes,2,opt,name=claims" json:"claims,omitempty"`
+	// reason and message explain why the code isn't valid, if it isn't
+	Reason  ActivationErrorReason `protobuf:"varint,3,opt,name=reason,proto3,enum=enterprise.ActivationErrorReason" json:"reason,omitempty"`
+	Message string                `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *ValidateCodeAsOfResponse) Reset()         { *m = ValidateCodeAsOfResponse{} }
+func (m *ValidateCodeAsOfResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateCodeAsOfResponse) ProtoMessage()    {}
+func (*ValidateCodeAsOfResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{35}
+}
+
+func (m *ValidateCodeAsOfResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ValidateCodeAsOfResponse) GetClaims() *TokenClaims {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+func (m *ValidateCodeAsOfResponse) GetReason() ActivationErrorReason {
+	if m != nil {
+		return m.Reason
+	}
+	return ActivationErrorReason_UNKNOWN_REASON
+}
+
+func (m *ValidateCodeAsOfResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ExportHistoryRequest struct {
+}
+
+func (m *ExportHistoryRequest) Reset()                    { *m = ExportHistoryRequest{} }
+func (m *ExportHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportHistoryRequest) ProtoMessage()               {}
+func (*ExportHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{36} }
+
+// ExportHistoryResponse contains the next page of the cluster's activation
+// history
+type ExportHistoryResponse struct {
+	// records are in chronological order, continuing from the previous page
+	Records []*ActivationHistoryRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ExportHistoryResponse) Reset()         { *m = ExportHistoryResponse{} }
+func (m *ExportHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportHistoryResponse) ProtoMessage()    {}
+func (*ExportHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{37}
+}
+
+func (m *ExportHistoryResponse) GetRecords() []*ActivationHistoryRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// SetTrustedKeysRequest replaces the keys that activation codes may be signed
+// with
+type SetTrustedKeysRequest struct {
+	// public_keys are PEM-encoded RSA public keys. There must be at least one
+	PublicKeys []string `protobuf:"bytes,1,rep,name=public_keys,json=publicKeys" json:"public_keys,omitempty"`
+}
+
+func (m *SetTrustedKeysRequest) Reset()         { *m = SetTrustedKeysRequest{} }
+func (m *SetTrustedKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTrustedKeysRequest) ProtoMessage()    {}
+func (*SetTrustedKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{38}
+}
+
+func (m *SetTrustedKeysRequest) GetPublicKeys() []string {
+	if m != nil {
+		return m.PublicKeys
+	}
+	return nil
+}
+
+type SetTrustedKeysResponse struct {
+}
+
+func (m *SetTrustedKeysResponse) Reset()         { *m = SetTrustedKeysResponse{} }
+func (m *SetTrustedKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTrustedKeysResponse) ProtoMessage()    {}
+func (*SetTrustedKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{39}
+}
+
+// TrustedKey describes a key that activation codes may be signed with,
+// without revealing the key itself
+type TrustedKey struct {
+	// fingerprint identifies the key (see KeyID())
+	Fingerprint string `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// algorithm is the key's algorithm and size, e.g. "RSA-2048"
+	Algorithm string           `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Source    TrustedKeySource `protobuf:"varint,3,opt,name=source,proto3,enum=enterprise.TrustedKeySource" json:"source,omitempty"`
+}
+
+func (m *TrustedKey) Reset()                    { *m = TrustedKey{} }
+func (m *TrustedKey) String() string            { return proto.CompactTextString(m) }
+func (*TrustedKey) ProtoMessage()               {}
+func (*TrustedKey) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{40} }
+
+func (m *TrustedKey) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *TrustedKey) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *TrustedKey) GetSource() TrustedKeySource {
+	if m != nil {
+		return m.Source
+	}
+	return TrustedKeySource_UNKNOWN_KEY_SOURCE
+}
+
+type ListTrustedKeysRequest struct {
+}
+
+func (m *ListTrustedKeysRequest) Reset()         { *m = ListTrustedKeysRequest{} }
+func (m *ListTrustedKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTrustedKeysRequest) ProtoMessage()    {}
+func (*ListTrustedKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{41}
+}
+
+type ListTrustedKeysResponse struct {
+	Keys []*TrustedKey `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (m *ListTrustedKeysResponse) Reset()         { *m = ListTrustedKeysResponse{} }
+func (m *ListTrustedKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTrustedKeysResponse) ProtoMessage()    {}
+func (*ListTrustedKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{42}
+}
+
+func (m *ListTrustedKeysResponse) GetKeys() []*TrustedKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type ServerTimeRequest struct {
+}
+
+func (m *ServerTimeRequest) Reset()                    { *m = ServerTimeRequest{} }
+func (m *ServerTimeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ServerTimeRequest) ProtoMessage()               {}
+func (*ServerTimeRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{43} }
+
+type ServerTimeResponse struct {
+	// time is the time according to the enterprise server's clock, which is
+	// the clock that token expiries are compared against
+	Time *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ServerTimeResponse) Reset()                    { *m = ServerTimeResponse{} }
+func (m *ServerTimeResponse) String() string            { return proto.CompactTextString(m) }
+func (*ServerTimeResponse) ProtoMessage()               {}
+func (*ServerTimeResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{44} }
+
+func (m *ServerTimeResponse) GetTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type GetConfigRequest struct {
+}
+
+func (m *GetConfigRequest) Reset()                    { *m = GetConfigRequest{} }
+func (m *GetConfigRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetConfigRequest) ProtoMessage()               {}
+func (*GetConfigRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{45} }
+
+// GetConfigResponse describes how the enterprise server is configured, once
+// defaults are applied. Secrets (e.g. credentials in URLs) are redacted
+type GetConfigResponse struct {
+	ExpiryWarningWindow *google_protobuf.Duration `protobuf:"bytes,1,opt,name=expiry_warning_window,json=expiryWarningWindow" json:"expiry_warning_window,omitempty"`
+	GracePeriod         *google_protobuf.Duration `protobuf:"bytes,2,opt,name=grace_period,json=gracePeriod" json:"grace_period,omitempty"`
+	// expiry_jitter is the most that the cluster brings its token's expiry
+	// forward by, and expiry_offset is how far it brings it forward
+	ExpiryJitter *google_protobuf.Duration `protobuf:"bytes,3,opt,name=expiry_jitter,json=expiryJitter" json:"expiry_jitter,omitempty"`
+	ExpiryOffset *google_protobuf.Duration `protobuf:"bytes,4,opt,name=expiry_offset,json=expiryOffset" json:"expiry_offset,omitempty"`
+	// max_clock_skew is how far the server's clock may be behind the token's
+	// issuer before GetState warns that the clock may be incorrect
+	MaxClockSkew *google_protobuf.Duration `protobuf:"bytes,5,opt,name=max_clock_skew,json=maxClockSkew" json:"max_clock_skew,omitempty"`
+	MinRemaining *google_protobuf.Duration `protobuf:"bytes,6,opt,name=min_remaining,json=minRemaining" json:"min_remaining,omitempty"`
+	// trusted_key_source is where the keys that activation codes are checked
+	// against came from. backup_keys_configured is true if the server also
+	// has backup signing authority keys
+	TrustedKeySource           TrustedKeySource `protobuf:"varint,7,opt,name=trusted_key_source,json=trustedKeySource,proto3,enum=enterprise.TrustedKeySource" json:"trusted_key_source,omitempty"`
+	BackupKeysConfigured       bool             `protobuf:"varint,8,opt,name=backup_keys_configured,json=backupKeysConfigured,proto3" json:"backup_keys_configured,omitempty"`
+	JWKSURL                    string           `protobuf:"bytes,9,opt,name=jwks_url,json=jwksUrl,proto3" json:"jwks_url,omitempty"`
+	TrialsEnabled              bool             `protobuf:"varint,10,opt,name=trials_enabled,json=trialsEnabled,proto3" json:"trials_enabled,omitempty"`
+	StateInjectionEnabled      bool             `protobuf:"varint,11,opt,name=state_injection_enabled,json=stateInjectionEnabled,proto3" json:"state_injection_enabled,omitempty"`
+	RequireMonotonicActivation bool             `protobuf:"varint,12,opt,name=require_monotonic_activation,json=requireMonotonicActivation,proto3" json:"require_monotonic_activation,omitempty"`
+	Environment                string           `protobuf:"bytes,13,opt,name=environment,proto3" json:"environment,omitempty"`
+	ProductID                  string           `protobuf:"bytes,14,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MinSchemaVersion           int64            `protobuf:"varint,15,opt,name=min_schema_version,json=minSchemaVersion,proto3" json:"min_schema_version,omitempty"`
+	ActivationURLHosts         []string         `protobuf:"bytes,16,rep,name=activation_url_hosts,json=activationUrlHosts" json:"activation_url_hosts,omitempty"`
+	EtcdPrefix                 string           `protobuf:"bytes,17,opt,name=etcd_prefix,json=etcdPrefix,proto3" json:"etcd_prefix,omitempty"`
+	HistoryPrefix              string           `protobuf:"bytes,18,opt,name=history_prefix,json=historyPrefix,proto3" json:"history_prefix,omitempty"`
+	JSONRecords                bool             `protobuf:"varint,19,opt,name=json_records,json=jsonRecords,proto3" json:"json_records,omitempty"`
+	CompressRecordsAbove       int64            `protobuf:"varint,20,opt,name=compress_records_above,json=compressRecordsAbove,proto3" json:"compress_records_above,omitempty"`
+	MaxRecordSize              int64            `protobuf:"varint,21,opt,name=max_record_size,json=maxRecordSize,proto3" json:"max_record_size,omitempty"`
+}
+
+func (m *GetConfigResponse) Reset()                    { *m = GetConfigResponse{} }
+func (m *GetConfigResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetConfigResponse) ProtoMessage()               {}
+func (*GetConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{46} }
+
+func (m *GetConfigResponse) GetExpiryWarningWindow() *google_protobuf.Duration {
+	if m != nil {
+		return m.ExpiryWarningWindow
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetGracePeriod() *google_protobuf.Duration {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetExpiryJitter() *google_protobuf.Duration {
+	if m != nil {
+		return m.ExpiryJitter
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetExpiryOffset() *google_protobuf.Duration {
+	if m != nil {
+		return m.ExpiryOffset
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetMaxClockSkew() *google_protobuf.Duration {
+	if m != nil {
+		return m.MaxClockSkew
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetMinRemaining() *google_protobuf.Duration {
+	if m != nil {
+		return m.MinRemaining
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetTrustedKeySource() TrustedKeySource {
+	if m != nil {
+		return m.TrustedKeySource
+	}
+	return TrustedKeySource_UNKNOWN_KEY_SOURCE
+}
+
+func (m *GetConfigResponse) GetBackupKeysConfigured() bool {
+	if m != nil {
+		return m.BackupKeysConfigured
+	}
+	return false
+}
+
+func (m *GetConfigResponse) GetJWKSURL() string {
+	if m != nil {
+		return m.JWKSURL
+	}
+	return ""
+}
+
+func (m *GetConfigResponse) GetTrialsEnabled() bool {
+	if m != nil {
+		return m.TrialsEnabled
+	}
+	return false
+}
+
+func (m *GetConfigResponse) GetStateInjectionEnabled() bool {
+	if m != nil {
+		return m.StateInjectionEnabled
+	}
+	return false
+}
+
+func (m *GetConfigResponse) GetRequireMonotonicActivation() bool {
+	if m != nil {
+		return m.RequireMonotonicActivation
+	}
+	return false
+}
+
+func (m *GetConfigResponse) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *GetConfigResponse) GetProductID() string {
+	if m != nil {
+		return m.ProductID
+	}
+	return ""
+}
+
+func (m *GetConfigResponse) GetMinSchemaVersion() int64 {
+	if m != nil {
+		return m.MinSchemaVersion
+	}
+	return 0
+}
+
+func (m *GetConfigResponse) GetActivationURLHosts() []string {
+	if m != nil {
+		return m.ActivationURLHosts
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetEtcdPrefix() string {
+	if m != nil {
+		return m.EtcdPrefix
+	}
+	return ""
+}
+
+func (m *GetConfigResponse) GetHistoryPrefix() string {
+	if m != nil {
+		return m.HistoryPrefix
+	}
+	return ""
+}
+
+func (m *GetConfigResponse) GetJSONRecords() bool {
+	if m != nil {
+		return m.JSONRecords
+	}
+	return false
+}
+
+func (m *GetConfigResponse) GetCompressRecordsAbove() int64 {
+	if m != nil {
+		return m.CompressRecordsAbove
+	}
+	return 0
+}
+
+func (m *GetConfigResponse) GetMaxRecordSize() int64 {
+	if m != nil {
+		return m.MaxRecordSize
+	}
+	return 0
+}
+
+type SetEmergencyOverrideRequest struct {
+	// duration is how long the override lasts, up to 72 hours. Zero ends any
+	// override that's in effect
+	Duration *google_protobuf.Duration `protobuf:"bytes,1,opt,name=duration" json:"duration,omitempty"`
+}
+
+func (m *SetEmergencyOverrideRequest) Reset()         { *m = SetEmergencyOverrideRequest{} }
+func (m *SetEmergencyOverrideRequest) String() string { return proto.CompactTextString(m) }
+func (*SetEmergencyOverrideRequest) ProtoMessage()    {}
+func (*SetEmergencyOverrideRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{47}
+}
+
+func (m *SetEmergencyOverrideRequest) GetDuration() *google_protobuf.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+type SetEmergencyOverrideResponse struct {
+	// expires is when the override ends, or unset if it was ended
+	Expires *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=expires" json:"expires,omitempty"`
+}
+
+func (m *SetEmergencyOverrideResponse) Reset()         { *m = SetEmergencyOverrideResponse{} }
+func (m *SetEmergencyOverrideResponse) String() string { return proto.CompactTextString(m) }
+func (*SetEmergencyOverrideResponse) ProtoMessage()    {}
+func (*SetEmergencyOverrideResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorEnterprise, []int{48}
+}
+
+func (m *SetEmergencyOverrideResponse) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+// InjectStateRequest makes the server report 'state' for 'ttl', for chaos
+// and resilience testing
+type InjectStateRequest struct {
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	// expiry, if set, is the token expiry that the server reports while the
+	// injection is in effect. Otherwise the real token's expiry is reported
+	Expiry *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expiry" json:"expiry,omitempty"`
+	// ttl is how long the injection lasts, up to an hour. Zero ends any
+	// injection that's in effect
+	TTL *google_protobuf.Duration `protobuf:"bytes,3,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *InjectStateRequest) Reset()                    { *m = InjectStateRequest{} }
+func (m *InjectStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*InjectStateRequest) ProtoMessage()               {}
+func (*InjectStateRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{49} }
+
+func (m *InjectStateRequest) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+func (m *InjectStateRequest) GetExpiry() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expiry
+	}
+	return nil
+}
+
+func (m *InjectStateRequest) GetTTL() *google_protobuf.Duration {
+	if m != nil {
+		return m.TTL
+	}
+	return nil
+}
+
+type InjectStateResponse struct {
+	// expires is when the injection ends, or unset if it was ended
+	Expires *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=expires" json:"expires,omitempty"`
+}
+
+func (m *InjectStateResponse) Reset()                    { *m = InjectStateResponse{} }
+func (m *InjectStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*InjectStateResponse) ProtoMessage()               {}
+func (*InjectStateResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{50} }
+
+func (m *InjectStateResponse) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+type SelfTestRequest struct {
+}
+
+func (m *SelfTestRequest) Reset()                    { *m = SelfTestRequest{} }
+func (m *SelfTestRequest) String() string            { return proto.CompactTextString(m) }
+func (*SelfTestRequest) ProtoMessage()               {}
+func (*SelfTestRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{51} }
+
+// SelfTestResponse reports whether the self-test's sentinel record could be
+// written, observed by a watch, read back and removed, and how long each
+// step took. Steps after a failure aren't run, and their durations are unset
+type SelfTestResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// error describes the failure, if success is false
+	Error          string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	WriteDuration  *google_protobuf.Duration `protobuf:"bytes,3,opt,name=write_duration,json=writeDuration" json:"write_duration,omitempty"`
+	WatchDuration  *google_protobuf.Duration `protobuf:"bytes,4,opt,name=watch_duration,json=watchDuration" json:"watch_duration,omitempty"`
+	ReadDuration   *google_protobuf.Duration `protobuf:"bytes,5,opt,name=read_duration,json=readDuration" json:"read_duration,omitempty"`
+	DeleteDuration *google_protobuf.Duration `protobuf:"bytes,6,opt,name=delete_duration,json=deleteDuration" json:"delete_duration,omitempty"`
+}
+
+func (m *SelfTestResponse) Reset()                    { *m = SelfTestResponse{} }
+func (m *SelfTestResponse) String() string            { return proto.CompactTextString(m) }
+func (*SelfTestResponse) ProtoMessage()               {}
+func (*SelfTestResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{52} }
+
+func (m *SelfTestResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *SelfTestResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *SelfTestResponse) GetWriteDuration() *google_protobuf.Duration {
+	if m != nil {
+		return m.WriteDuration
+	}
+	return nil
+}
+
+func (m *SelfTestResponse) GetWatchDuration() *google_protobuf.Duration {
+	if m != nil {
+		return m.WatchDuration
+	}
+	return nil
+}
+
+func (m *SelfTestResponse) GetReadDuration() *google_protobuf.Duration {
+	if m != nil {
+		return m.ReadDuration
+	}
+	return nil
+}
+
+func (m *SelfTestResponse) GetDeleteDuration() *google_protobuf.Duration {
+	if m != nil {
+		return m.DeleteDuration
+	}
+	return nil
+}
+
+type DebugDumpRequest struct {
+}
+
+func (m *DebugDumpRequest) Reset()                    { *m = DebugDumpRequest{} }
+func (m *DebugDumpRequest) String() string            { return proto.CompactTextString(m) }
+func (*DebugDumpRequest) ProtoMessage()               {}
+func (*DebugDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{53} }
+
+type EtcdEndpointHealth struct {
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Healthy  bool   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// error is set if the endpoint is not healthy
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *EtcdEndpointHealth) Reset()                    { *m = EtcdEndpointHealth{} }
+func (m *EtcdEndpointHealth) String() string            { return proto.CompactTextString(m) }
+func (*EtcdEndpointHealth) ProtoMessage()               {}
+func (*EtcdEndpointHealth) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{54} }
+
+func (m *EtcdEndpointHealth) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *EtcdEndpointHealth) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *EtcdEndpointHealth) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// DebugDumpResponse contains the enterprise server's internal state, for
+// debugging. It never contains secrets such as the activation code itself
+type DebugDumpResponse struct {
+	State    State                       `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	Expires  *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=expires" json:"expires,omitempty"`
+	Features []string                    `protobuf:"bytes,3,rep,name=features" json:"features,omitempty"`
+	// activation_code_fingerprint identifies the cluster's activation code
+	// without revealing it (it's a prefix of the code's SHA-256 hash)
+	ActivationCodeFingerprint string `protobuf:"bytes,4,opt,name=activation_code_fingerprint,json=activationCodeFingerprint,proto3" json:"activation_code_fingerprint,omitempty"`
+	// watch_connected is true if the server is currently watching etcd for
+	// changes to the enterprise token
+	WatchConnected bool                        `protobuf:"varint,5,opt,name=watch_connected,json=watchConnected,proto3" json:"watch_connected,omitempty"`
+	LastWatchEvent *google_protobuf1.Timestamp `protobuf:"bytes,6,opt,name=last_watch_event,json=lastWatchEvent" json:"last_watch_event,omitempty"`
+	// last_error is the most recent error encountered while watching or
+	// health-checking etcd
+	LastError     string                `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	EtcdEndpoints []*EtcdEndpointHealth `protobuf:"bytes,8,rep,name=etcd_endpoints,json=etcdEndpoints" json:"etcd_endpoints,omitempty"`
+}
+
+func (m *DebugDumpResponse) Reset()                    { *m = DebugDumpResponse{} }
+func (m *DebugDumpResponse) String() string            { return proto.CompactTextString(m) }
+func (*DebugDumpResponse) ProtoMessage()               {}
+func (*DebugDumpResponse) Descriptor() ([]byte, []int) { return fileDescriptorEnterprise, []int{55} }
+
+func (m *DebugDumpResponse) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_NONE
+}
+
+func (m *DebugDumpResponse) GetExpires() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *DebugDumpResponse) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *DebugDumpResponse) GetActivationCodeFingerprint() string {
+	if m != nil {
+		return m.ActivationCodeFingerprint
+	}
+	return ""
+}
+
+func (m *DebugDumpResponse) GetWatchConnected() bool {
+	if m != nil {
+		return m.WatchConnected
+	}
+	return false
+}
+
+func (m *DebugDumpResponse) GetLastWatchEvent() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LastWatchEvent
+	}
+	return nil
+}
+
+func (m *DebugDumpResponse) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *DebugDumpResponse) GetEtcdEndpoints() []*EtcdEndpointHealth {
+	if m != nil {
+		return m.EtcdEndpoints
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EnterpriseRecord)(nil), "enterprise.EnterpriseRecord")
+	proto.RegisterType((*ActivationHistoryRecord)(nil), "enterprise.ActivationHistoryRecord")
+	proto.RegisterType((*ActivateRequest)(nil), "enterprise.ActivateRequest")
+	proto.RegisterType((*ActivateResponse)(nil), "enterprise.ActivateResponse")
+	proto.RegisterType((*ActivationErrorDetails)(nil), "enterprise.ActivationErrorDetails")
+	proto.RegisterType((*MaintenanceErrorDetails)(nil), "enterprise.MaintenanceErrorDetails")
+	proto.RegisterType((*ActivateFromURLRequest)(nil), "enterprise.ActivateFromURLRequest")
+	proto.RegisterType((*ActivateDetachedRequest)(nil), "enterprise.ActivateDetachedRequest")
+	proto.RegisterType((*ActivatePartialRequest)(nil), "enterprise.ActivatePartialRequest")
+	proto.RegisterType((*ActivatePartialResponse)(nil), "enterprise.ActivatePartialResponse")
+	proto.RegisterType((*StageActivationRequest)(nil), "enterprise.StageActivationRequest")
+	proto.RegisterType((*StageActivationResponse)(nil), "enterprise.StageActivationResponse")
+	proto.RegisterType((*CommitActivationRequest)(nil), "enterprise.CommitActivationRequest")
+	proto.RegisterType((*GetStateRequest)(nil), "enterprise.GetStateRequest")
+	proto.RegisterType((*GetStateResponse)(nil), "enterprise.GetStateResponse")
+	proto.RegisterType((*EffectiveState)(nil), "enterprise.EffectiveState")
+	proto.RegisterType((*WatchStateRequest)(nil), "enterprise.WatchStateRequest")
+	proto.RegisterType((*WatchStateResponse)(nil), "enterprise.WatchStateResponse")
+	proto.RegisterType((*DeactivateRequest)(nil), "enterprise.DeactivateRequest")
+	proto.RegisterType((*DeactivateResponse)(nil), "enterprise.DeactivateResponse")
+	proto.RegisterType((*RefreshStateRequest)(nil), "enterprise.RefreshStateRequest")
+	proto.RegisterType((*RefreshStateResponse)(nil), "enterprise.RefreshStateResponse")
+	proto.RegisterType((*GetActivationInfoRequest)(nil), "enterprise.GetActivationInfoRequest")
+	proto.RegisterType((*GetActivationInfoResponse)(nil), "enterprise.GetActivationInfoResponse")
+	proto.RegisterType((*RebuildRequest)(nil), "enterprise.RebuildRequest")
+	proto.RegisterType((*RebuildResponse)(nil), "enterprise.RebuildResponse")
+	proto.RegisterType((*GetQuotaRequest)(nil), "enterprise.GetQuotaRequest")
+	proto.RegisterType((*GetQuotaResponse)(nil), "enterprise.GetQuotaResponse")
+	proto.RegisterType((*CheckFeaturesRequest)(nil), "enterprise.CheckFeaturesRequest")
+	proto.RegisterType((*FeatureEntitlement)(nil), "enterprise.FeatureEntitlement")
+	proto.RegisterType((*CheckFeaturesResponse)(nil), "enterprise.CheckFeaturesResponse")
+	proto.RegisterType((*TokenClaims)(nil), "enterprise.TokenClaims")
+	proto.RegisterType((*PreviewCodeRequest)(nil), "enterprise.PreviewCodeRequest")
+	proto.RegisterType((*PreviewCodeResponse)(nil), "enterprise.PreviewCodeResponse")
+	proto.RegisterType((*ValidateCodeAsOfRequest)(nil), "enterprise.ValidateCodeAsOfRequest")
+	proto.RegisterType((*ValidateCodeAsOfResponse)(nil), "enterprise.ValidateCodeAsOfResponse")
+	proto.RegisterType((*ExportHistoryRequest)(nil), "enterprise.ExportHistoryRequest")
+	proto.RegisterType((*ExportHistoryResponse)(nil), "enterprise.ExportHistoryResponse")
+	proto.RegisterType((*SetTrustedKeysRequest)(nil), "enterprise.SetTrustedKeysRequest")
+	proto.RegisterType((*SetTrustedKeysResponse)(nil), "enterprise.SetTrustedKeysResponse")
+	proto.RegisterType((*TrustedKey)(nil), "enterprise.TrustedKey")
+	proto.RegisterType((*ListTrustedKeysRequest)(nil), "enterprise.ListTrustedKeysRequest")
+	proto.RegisterType((*ListTrustedKeysResponse)(nil), "enterprise.ListTrustedKeysResponse")
+	proto.RegisterType((*ServerTimeRequest)(nil), "enterprise.ServerTimeRequest")
+	proto.RegisterType((*ServerTimeResponse)(nil), "enterprise.ServerTimeResponse")
+	proto.RegisterType((*GetConfigRequest)(nil), "enterprise.GetConfigRequest")
+	proto.RegisterType((*GetConfigResponse)(nil), "enterprise.GetConfigResponse")
+	proto.RegisterType((*SetEmergencyOverrideRequest)(nil), "enterprise.SetEmergencyOverrideRequest")
+	proto.RegisterType((*SetEmergencyOverrideResponse)(nil), "enterprise.SetEmergencyOverrideResponse")
+	proto.RegisterType((*InjectStateRequest)(nil), "enterprise.InjectStateRequest")
+	proto.RegisterType((*InjectStateResponse)(nil), "enterprise.InjectStateResponse")
+	proto.RegisterType((*SelfTestRequest)(nil), "enterprise.SelfTestRequest")
+	proto.RegisterType((*SelfTestResponse)(nil), "enterprise.SelfTestResponse")
+	proto.RegisterType((*DebugDumpRequest)(nil), "enterprise.DebugDumpRequest")
+	proto.RegisterType((*EtcdEndpointHealth)(nil), "enterprise.EtcdEndpointHealth")
+	proto.RegisterType((*DebugDumpResponse)(nil), "enterprise.DebugDumpResponse")
+	proto.RegisterEnum("enterprise.ActivationErrorReason", ActivationErrorReason_name, ActivationErrorReason_value)
+	proto.RegisterEnum("enterprise.State", State_name, State_value)
+	proto.RegisterEnum("enterprise.TrustedKeySource", TrustedKeySource_name, TrustedKeySource_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for API service
+
+type APIClient interface {
+	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
+	// features, such as the Pachyderm Dashboard and Auth system
+	Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
+	// ActivateFromURL is like Activate, but downloads the activation code from
+	// a URL. The URL's host must be in the server's allowlist
+	ActivateFromURL(ctx context.Context, in *ActivateFromURLRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
+	// ActivateDetached is like Activate, but takes the activation code's token
+	// and signature separately, rather than combined into an activation code
+	ActivateDetached(ctx context.Context, in *ActivateDetachedRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
+	// ActivatePartial provides one part of an activation code that has been
+	// split into several parts. Once every part has been provided, the
+	// assembled code is activated as if it had been passed to Activate
+	ActivatePartial(ctx context.Context, in *ActivatePartialRequest, opts ...grpc.CallOption) (*ActivatePartialResponse, error)
+	// StageActivation validates an activation code and holds it in the
+	// server's memory for a limited time, without activating it.
+	// CommitActivation then activates it, as if it had been passed to Activate.
+	// Both calls must be made to the same server
+	StageActivation(ctx context.Context, in *StageActivationRequest, opts ...grpc.CallOption) (*StageActivationResponse, error)
+	CommitActivation(ctx context.Context, in *CommitActivationRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
+	// WatchState returns the cluster's current enterprise state, and then the
+	// new state each time that it changes (including when its token expires).
+	// Callers that don't keep up with changes are disconnected
+	WatchState(ctx context.Context, in *WatchStateRequest, opts ...grpc.CallOption) (API_WatchStateClient, error)
+	// Deactivate removes the cluster's Pachyderm enterprise token, if any,
+	// disabling Pachyderm enterprise features. Only cluster admins may call it
+	Deactivate(ctx context.Context, in *DeactivateRequest, opts ...grpc.CallOption) (*DeactivateResponse, error)
+	// RefreshState re-reads the enterprise token from etcd and updates the
+	// server's cached state. GetState never reads from etcd, so this is the only
+	// way to force the cache to resync outside of the background watch
+	RefreshState(ctx context.Context, in *RefreshStateRequest, opts ...grpc.CallOption) (*RefreshStateResponse, error)
+	// GetActivationInfo describes the cluster's current token, including which
+	// trusted key its activation code was verified with
+	GetActivationInfo(ctx context.Context, in *GetActivationInfoRequest, opts ...grpc.CallOption) (*GetActivationInfoResponse, error)
+	// Rebuild discards the server's cached state and reconstructs it from the
+	// enterprise token and activation history in etcd. It's meant for
+	// recovering from a cache that's known to be wrong. Only cluster admins may
+	// call it
+	Rebuild(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (*RebuildResponse, error)
+	// GetQuota returns the numeric limits (nodes, pipelines, storage) in the
+	// cluster's enterprise token, so that other services can enforce them
+	GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error)
+	// CheckFeatures reports whether the cluster's enterprise token enables each
+	// of a set of features (e.g. all of the entitlements that a larger feature
+	// depends on)
+	CheckFeatures(ctx context.Context, in *CheckFeaturesRequest, opts ...grpc.CallOption) (*CheckFeaturesResponse, error)
+	// PreviewCode validates an activation code and returns what it would
+	// enable, compared to the cluster's current token, without activating it.
+	// Only cluster admins may call it
+	PreviewCode(ctx context.Context, in *PreviewCodeRequest, opts ...grpc.CallOption) (*PreviewCodeResponse, error)
+	// ValidateCodeAsOf validates an activation code as the server would at a
+	// given time, rather than now, for reproducible audits. Signatures are
+	// checked against the server's current trusted keys. Only cluster admins
+	// may call it
+	ValidateCodeAsOf(ctx context.Context, in *ValidateCodeAsOfRequest, opts ...grpc.CallOption) (*ValidateCodeAsOfResponse, error)
+	// ExportHistory streams the cluster's activation history, oldest first, a
+	// page at a time. Only cluster admins may call it
+	ExportHistory(ctx context.Context, in *ExportHistoryRequest, opts ...grpc.CallOption) (API_ExportHistoryClient, error)
+	// SetEmergencyOverride makes the server report an expired token as ACTIVE
+	// for a limited time, e.g. while an outage prevents the token from being
+	// renewed. GetState warns while the override is in effect. The override
+	// isn't persisted, so it must be repeated if pachd restarts. Only cluster
+	// admins may call it
+	SetEmergencyOverride(ctx context.Context, in *SetEmergencyOverrideRequest, opts ...grpc.CallOption) (*SetEmergencyOverrideResponse, error)
+	// InjectState makes the server report a given state, regardless of the
+	// cluster's token, until the injection's TTL passes. It's for chaos and
+	// resilience testing, and fails unless state injection was enabled when
+	// the server was constructed. Only cluster admins may call it
+	InjectState(ctx context.Context, in *InjectStateRequest, opts ...grpc.CallOption) (*InjectStateResponse, error)
+	// SelfTest exercises the server's etcd write, watch and read path end to
+	// end, with a sentinel record that's stored apart from the cluster's token
+	// (which it never touches), for post-deploy smoke tests. Only cluster
+	// admins may call it
+	SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestResponse, error)
+	// DebugDump returns the server's internal state, for support bundles. Only
+	// cluster admins may call it
+	DebugDump(ctx context.Context, in *DebugDumpRequest, opts ...grpc.CallOption) (*DebugDumpResponse, error)
+	// SetTrustedKeys replaces the keys that this server accepts activation codes
+	// signed with, e.g. during a key rotation. It doesn't affect the current
+	// token, and isn't persisted, so it must be repeated if pachd restarts.
+	// Only cluster admins may call it
+	SetTrustedKeys(ctx context.Context, in *SetTrustedKeysRequest, opts ...grpc.CallOption) (*SetTrustedKeysResponse, error)
+	// ListTrustedKeys returns the fingerprints of the keys that this server
+	// accepts activation codes signed with, for auditing. Only cluster admins
+	// may call it
+	ListTrustedKeys(ctx context.Context, in *ListTrustedKeysRequest, opts ...grpc.CallOption) (*ListTrustedKeysResponse, error)
+	// ServerTime returns the current time according to the enterprise server,
+	// so that clients can measure how far their clock is from the server's
+	// (e.g. when a token expires earlier or later than expected)
+	ServerTime(ctx context.Context, in *ServerTimeRequest, opts ...grpc.CallOption) (*ServerTimeResponse, error)
+	// GetConfig returns the server's effective configuration, with secrets
+	// redacted, for support. Only cluster admins may call it
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+}
+
+type aPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIClient(cc *grpc.ClientConn) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
+	out := new(ActivateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/Activate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ActivateFromURL(ctx context.Context, in *ActivateFromURLRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
+	out := new(ActivateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/ActivateFromURL", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ActivateDetached(ctx context.Context, in *ActivateDetachedRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
+	out := new(ActivateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/ActivateDetached", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ActivatePartial(ctx context.Context, in *ActivatePartialRequest, opts ...grpc.CallOption) (*ActivatePartialResponse, error) {
+	out := new(ActivatePartialResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/ActivatePartial", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) StageActivation(ctx context.Context, in *StageActivationRequest, opts ...grpc.CallOption) (*StageActivationResponse, error) {
+	out := new(StageActivationResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/StageActivation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CommitActivation(ctx context.Context, in *CommitActivationRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
+	out := new(ActivateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/CommitActivation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
+	out := new(GetStateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/GetState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) WatchState(ctx context.Context, in *WatchStateRequest, opts ...grpc.CallOption) (API_WatchStateClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[0], c.cc, "/enterprise.API/WatchState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIWatchStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_WatchStateClient interface {
+	Recv() (*WatchStateResponse, error)
+	grpc.ClientStream
+}
+
+type aPIWatchStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIWatchStateClient) Recv() (*WatchStateResponse, error) {
+	m := new(WatchStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) Deactivate(ctx context.Context, in *DeactivateRequest, opts ...grpc.CallOption) (*DeactivateResponse, error) {
+	out := new(DeactivateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/Deactivate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RefreshState(ctx context.Context, in *RefreshStateRequest, opts ...grpc.CallOption) (*RefreshStateResponse, error) {
+	out := new(RefreshStateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/RefreshState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetActivationInfo(ctx context.Context, in *GetActivationInfoRequest, opts ...grpc.CallOption) (*GetActivationInfoResponse, error) {
+	out := new(GetActivationInfoResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/GetActivationInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Rebuild(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (*RebuildResponse, error) {
+	out := new(RebuildResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/Rebuild", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	out := new(GetQuotaResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/GetQuota", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CheckFeatures(ctx context.Context, in *CheckFeaturesRequest, opts ...grpc.CallOption) (*CheckFeaturesResponse, error) {
+	out := new(CheckFeaturesResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/CheckFeatures", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) PreviewCode(ctx context.Context, in *PreviewCodeRequest, opts ...grpc.CallOption) (*PreviewCodeResponse, error) {
+	out := new(PreviewCodeResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/PreviewCode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ValidateCodeAsOf(ctx context.Context, in *ValidateCodeAsOfRequest, opts ...grpc.CallOption) (*ValidateCodeAsOfResponse, error) {
+	out := new(ValidateCodeAsOfResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/ValidateCodeAsOf", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ExportHistory(ctx context.Context, in *ExportHistoryRequest, opts ...grpc.CallOption) (API_ExportHistoryClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[1], c.cc, "/enterprise.API/ExportHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIExportHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_ExportHistoryClient interface {
+	Recv() (*ExportHistoryResponse, error)
+	grpc.ClientStream
+}
+
+type aPIExportHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIExportHistoryClient) Recv() (*ExportHistoryResponse, error) {
+	m := new(ExportHistoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) SetEmergencyOverride(ctx context.Context, in *SetEmergencyOverrideRequest, opts ...grpc.CallOption) (*SetEmergencyOverrideResponse, error) {
+	out := new(SetEmergencyOverrideResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/SetEmergencyOverride", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) InjectState(ctx context.Context, in *InjectStateRequest, opts ...grpc.CallOption) (*InjectStateResponse, error) {
+	out := new(InjectStateResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/InjectState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestResponse, error) {
+	out := new(SelfTestResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/SelfTest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DebugDump(ctx context.Context, in *DebugDumpRequest, opts ...grpc.CallOption) (*DebugDumpResponse, error) {
+	out := new(DebugDumpResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/DebugDump", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SetTrustedKeys(ctx context.Context, in *SetTrustedKeysRequest, opts ...grpc.CallOption) (*SetTrustedKeysResponse, error) {
+	out := new(SetTrustedKeysResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/SetTrustedKeys", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListTrustedKeys(ctx context.Context, in *ListTrustedKeysRequest, opts ...grpc.CallOption) (*ListTrustedKeysResponse, error) {
+	out := new(ListTrustedKeysResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/ListTrustedKeys", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ServerTime(ctx context.Context, in *ServerTimeRequest, opts ...grpc.CallOption) (*ServerTimeResponse, error) {
+	out := new(ServerTimeResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/ServerTime", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	out := new(GetConfigResponse)
+	err := grpc.Invoke(ctx, "/enterprise.API/GetConfig", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for API service
+
+type APIServer interface {
+	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
+	// features, such as the Pachyderm Dashboard and Auth system
+	Activate(context.Context, *ActivateRequest) (*ActivateResponse, error)
+	// ActivateFromURL is like Activate, but downloads the activation code from
+	// a URL. The URL's host must be in the server's allowlist
+	ActivateFromURL(context.Context, *ActivateFromURLRequest) (*ActivateResponse, error)
+	// ActivateDetached is like Activate, but takes the activation code's token
+	// and signature separately, rather than combined into an activation code
+	ActivateDetached(context.Context, *ActivateDetachedRequest) (*ActivateResponse, error)
+	// ActivatePartial provides one part of an activation code that has been
+	// split into several parts. Once every part has been provided, the
+	// assembled code is activated as if it had been passed to Activate
+	ActivatePartial(context.Context, *ActivatePartialRequest) (*ActivatePartialResponse, error)
+	// StageActivation validates an activation code and holds it in the
+	// server's memory for a limited time, without activating it.
+	// CommitActivation then activates it, as if it had been passed to Activate.
+	// Both calls must be made to the same server
+	StageActivation(context.Context, *StageActivationRequest) (*StageActivationResponse, error)
+	CommitActivation(context.Context, *CommitActivationRequest) (*ActivateResponse, error)
+	GetState(context.Context, *GetStateRequest) (*GetStateResponse, error)
+	// WatchState returns the cluster's current enterprise state, and then the
+	// new state each time that it changes (including when its token expires).
+	// Callers that don't keep up with changes are disconnected
+	WatchState(*WatchStateRequest, API_WatchStateServer) error
+	// Deactivate removes the cluster's Pachyderm enterprise token, if any,
+	// disabling Pachyderm enterprise features. Only cluster admins may call it
+	Deactivate(context.Context, *DeactivateRequest) (*DeactivateResponse, error)
+	// RefreshState re-reads the enterprise token from etcd and updates the
+	// server's cached state. GetState never reads from etcd, so this is the only
+	// way to force the cache to resync outside of the background watch
+	RefreshState(context.Context, *RefreshStateRequest) (*RefreshStateResponse, error)
+	// GetActivationInfo describes the cluster's current token, including which
+	// trusted key its activation code was verified with
+	GetActivationInfo(context.Context, *GetActivationInfoRequest) (*GetActivationInfoResponse, error)
+	// Rebuild discards the server's cached state and reconstructs it from the
+	// enterprise token and activation history in etcd. It's meant for
+	// recovering from a cache that's known to be wrong. Only cluster admins may
+	// call it
+	Rebuild(context.Context, *RebuildRequest) (*RebuildResponse, error)
+	// GetQuota returns the numeric limits (nodes, pipelines, storage) in the
+	// cluster's enterprise token, so that other services can enforce them
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
+	// CheckFeatures reports whether the cluster's enterprise token enables each
+	// of a set of features (e.g. all of the entitlements that a larger feature
+	// depends on)
+	CheckFeatures(context.Context, *CheckFeaturesRequest) (*CheckFeaturesResponse, error)
+	// PreviewCode validates an activation code and returns what it would
+	// enable, compared to the cluster's current token, without activating it.
+	// Only cluster admins may call it
+	PreviewCode(context.Context, *PreviewCodeRequest) (*PreviewCodeResponse, error)
+	// ValidateCodeAsOf validates an activation code as the server would at a
+	// given time, rather than now, for reproducible audits. Signatures are
+	// checked against the server's current trusted keys. Only cluster admins
+	// may call it
+	ValidateCodeAsOf(context.Context, *ValidateCodeAsOfRequest) (*ValidateCodeAsOfResponse, error)
+	// ExportHistory streams the cluster's activation history, oldest first, a
+	// page at a time. Only cluster admins may call it
+	ExportHistory(*ExportHistoryRequest, API_ExportHistoryServer) error
+	// SetEmergencyOverride makes the server report an expired token as ACTIVE
+	// for a limited time, e.g. while an outage prevents the token from being
+	// renewed. GetState warns while the override is in effect. The override
+	// isn't persisted, so it must be repeated if pachd restarts. Only cluster
+	// admins may call it
+	SetEmergencyOverride(context.Context, *SetEmergencyOverrideRequest) (*SetEmergencyOverrideResponse, error)
+	// InjectState makes the server report a given state, regardless of the
+	// cluster's token, until the injection's TTL passes. It's for chaos and
+	// resilience testing, and fails unless state injection was enabled when
+	// the server was constructed. Only cluster admins may call it
+	InjectState(context.Context, *InjectStateRequest) (*InjectStateResponse, error)
+	// SelfTest exercises the server's etcd write, watch and read path end to
+	// end, with a sentinel record that's stored apart from the cluster's token
+	// (which it never touches), for post-deploy smoke tests. Only cluster
+	// admins may call it
+	SelfTest(context.Context, *SelfTestRequest) (*SelfTestResponse, error)
+	// DebugDump returns the server's internal state, for support bundles. Only
+	// cluster admins may call it
+	DebugDump(context.Context, *DebugDumpRequest) (*DebugDumpResponse, error)
+	// SetTrustedKeys replaces the keys that this server accepts activation codes
+	// signed with, e.g. during a key rotation. It doesn't affect the current
+	// token, and isn't persisted, so it must be repeated if pachd restarts.
+	// Only cluster admins may call it
+	SetTrustedKeys(context.Context, *SetTrustedKeysRequest) (*SetTrustedKeysResponse, error)
+	// ListTrustedKeys returns the fingerprints of the keys that this server
+	// accepts activation codes signed with, for auditing. Only cluster admins
+	// may call it
+	ListTrustedKeys(context.Context, *ListTrustedKeysRequest) (*ListTrustedKeysResponse, error)
+	// ServerTime returns the current time according to the enterprise server,
+	// so that clients can measure how far their clock is from the server's
+	// (e.g. when a token expires earlier or later than expected)
+	ServerTime(context.Context, *ServerTimeRequest) (*ServerTimeResponse, error)
+	// GetConfig returns the server's effective configuration, with secrets
+	// redacted, for support. Only cluster admins may call it
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+}
+
+func RegisterAPIServer(s *grpc.Server, srv APIServer) {
+	s.RegisterService(&_API_serviceDesc, srv)
+}
+
+func _API_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Activate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/Activate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Activate(ctx, req.(*ActivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ActivateFromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateFromURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ActivateFromURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ActivateFromURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ActivateFromURL(ctx, req.(*ActivateFromURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ActivateDetached_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateDetachedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ActivateDetached(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ActivateDetached",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ActivateDetached(ctx, req.(*ActivateDetachedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ActivatePartial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivatePartialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ActivatePartial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ActivatePartial",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ActivatePartial(ctx, req.(*ActivatePartialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_StageActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StageActivationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).StageActivation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/StageActivation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).StageActivation(ctx, req.(*StageActivationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CommitActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitActivationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CommitActivation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/CommitActivation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CommitActivation(ctx, req.(*CommitActivationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/GetState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_WatchState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).WatchState(m, &aPIWatchStateServer{stream})
+}
+
+type API_WatchStateServer interface {
+	Send(*WatchStateResponse) error
+	grpc.ServerStream
+}
+
+type aPIWatchStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIWatchStateServer) Send(m *WatchStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_Deactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Deactivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/Deactivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Deactivate(ctx, req.(*DeactivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RefreshState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RefreshState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/RefreshState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RefreshState(ctx, req.(*RefreshStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetActivationInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivationInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetActivationInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/GetActivationInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetActivationInfo(ctx, req.(*GetActivationInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Rebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Rebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/Rebuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Rebuild(ctx, req.(*RebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/GetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetQuota(ctx, req.(*GetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CheckFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CheckFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/CheckFeatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CheckFeatures(ctx, req.(*CheckFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_PreviewCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).PreviewCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/PreviewCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).PreviewCode(ctx, req.(*PreviewCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ValidateCodeAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCodeAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ValidateCodeAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ValidateCodeAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ValidateCodeAsOf(ctx, req.(*ValidateCodeAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ExportHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).ExportHistory(m, &aPIExportHistoryServer{stream})
+}
+
+type API_ExportHistoryServer interface {
+	Send(*ExportHistoryResponse) error
+	grpc.ServerStream
+}
+
+type aPIExportHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIExportHistoryServer) Send(m *ExportHistoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_SetEmergencyOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEmergencyOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetEmergencyOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/SetEmergencyOverride",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetEmergencyOverride(ctx, req.(*SetEmergencyOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_InjectState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).InjectState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/InjectState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).InjectState(ctx, req.(*InjectStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/SelfTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SelfTest(ctx, req.(*SelfTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DebugDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugDumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DebugDump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/DebugDump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DebugDump(ctx, req.(*DebugDumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetTrustedKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTrustedKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetTrustedKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/SetTrustedKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetTrustedKeys(ctx, req.(*SetTrustedKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListTrustedKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrustedKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListTrustedKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ListTrustedKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListTrustedKeys(ctx, req.(*ListTrustedKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ServerTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ServerTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ServerTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ServerTime(ctx, req.(*ServerTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _API_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "enterprise.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Activate",
+			Handler:    _API_Activate_Handler,
+		},
+		{
+			MethodName: "ActivateFromURL",
+			Handler:    _API_ActivateFromURL_Handler,
+		},
+		{
+			MethodName: "ActivateDetached",
+			Handler:    _API_ActivateDetached_Handler,
+		},
+		{
+			MethodName: "ActivatePartial",
+			Handler:    _API_ActivatePartial_Handler,
+		},
+		{
+			MethodName: "StageActivation",
+			Handler:    _API_StageActivation_Handler,
+		},
+		{
+			MethodName: "CommitActivation",
+			Handler:    _API_CommitActivation_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _API_GetState_Handler,
+		},
+		{
+			MethodName: "Deactivate",
+			Handler:    _API_Deactivate_Handler,
+		},
+		{
+			MethodName: "RefreshState",
+			Handler:    _API_RefreshState_Handler,
+		},
+		{
+			MethodName: "GetActivationInfo",
+			Handler:    _API_GetActivationInfo_Handler,
+		},
+		{
+			MethodName: "Rebuild",
+			Handler:    _API_Rebuild_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _API_GetQuota_Handler,
+		},
+		{
+			MethodName: "CheckFeatures",
+			Handler:    _API_CheckFeatures_Handler,
+		},
+		{
+			MethodName: "PreviewCode",
+			Handler:    _API_PreviewCode_Handler,
+		},
+		{
+			MethodName: "ValidateCodeAsOf",
+			Handler:    _API_ValidateCodeAsOf_Handler,
+		},
+		{
+			MethodName: "SetEmergencyOverride",
+			Handler:    _API_SetEmergencyOverride_Handler,
+		},
+		{
+			MethodName: "InjectState",
+			Handler:    _API_InjectState_Handler,
+		},
+		{
+			MethodName: "SelfTest",
+			Handler:    _API_SelfTest_Handler,
+		},
+		{
+			MethodName: "DebugDump",
+			Handler:    _API_DebugDump_Handler,
+		},
+		{
+			MethodName: "SetTrustedKeys",
+			Handler:    _API_SetTrustedKeys_Handler,
+		},
+		{
+			MethodName: "ListTrustedKeys",
+			Handler:    _API_ListTrustedKeys_Handler,
+		},
+		{
+			MethodName: "ServerTime",
+			Handler:    _API_ServerTime_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _API_GetConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchState",
+			Handler:       _API_WatchState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportHistory",
+			Handler:       _API_ExportHistory_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "client/enterprise/enterprise.proto",
+}
+
+func (m *EnterpriseRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EnterpriseRecord) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ActivationCode) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i += copy(dAtA[i:], m.ActivationCode)
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n1, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.MaxNodes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxNodes))
+	}
+	if m.Written != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Written.Size()))
+		n2, err := m.Written.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MaxPipelines != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxPipelines))
+	}
+	if m.MaxStorageBytes != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxStorageBytes))
+	}
+	if m.Serial != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Serial))
+	}
+	if len(m.Environment) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Environment)))
+		i += copy(dAtA[i:], m.Environment)
+	}
+	if m.IssuedAt != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.IssuedAt.Size()))
+		n3, err := m.IssuedAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.ActivatedAt != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ActivatedAt.Size()))
+		n4, err := m.ActivatedAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if len(m.KeyID) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.KeyID)))
+		i += copy(dAtA[i:], m.KeyID)
+	}
+	if m.SchemaVersion != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.SchemaVersion))
+	}
+	if len(m.SLA) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.SLA)))
+		i += copy(dAtA[i:], m.SLA)
+	}
+	if m.LeaseExpires != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.LeaseExpires.Size()))
+		n5, err := m.LeaseExpires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.Product) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Product)))
+		i += copy(dAtA[i:], m.Product)
+	}
+	if len(m.DeprecatedFeatures) > 0 {
+		for k, _ := range m.DeprecatedFeatures {
+			dAtA[i] = 0x8a
+			i++
+			dAtA[i] = 0x1
+			i++
+			v := m.DeprecatedFeatures[k]
+			mapSize := 1 + len(k) + sovEnterprise(uint64(len(k))) + 1 + len(v) + sovEnterprise(uint64(len(v)))
+			i = encodeVarintEnterprise(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.FeatureExpiries) > 0 {
+		for k, _ := range m.FeatureExpiries {
+			dAtA[i] = 0x92
+			i++
+			dAtA[i] = 0x1
+			i++
+			v := m.FeatureExpiries[k]
+			msgSize := 0
+			if v != nil {
+				msgSize = v.Size()
+				msgSize += 1 + sovEnterprise(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovEnterprise(uint64(len(k))) + msgSize
+			i = encodeVarintEnterprise(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if v != nil {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintEnterprise(dAtA, i, uint64(v.Size()))
+				n6, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
+				}
+				i += n6
+			}
+		}
+	}
+	if len(m.MinVersion) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.MinVersion)))
+		i += copy(dAtA[i:], m.MinVersion)
+	}
+	if len(m.MaxVersion) > 0 {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.MaxVersion)))
+		i += copy(dAtA[i:], m.MaxVersion)
+	}
+	return i, nil
+}
+
+func (m *ActivationHistoryRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivationHistoryRecord) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Activated != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Activated.Size()))
+		n7, err := m.Activated.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n8, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if len(m.ActivationCodeFingerprint) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCodeFingerprint)))
+		i += copy(dAtA[i:], m.ActivationCodeFingerprint)
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *ActivateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ActivationCode) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i += copy(dAtA[i:], m.ActivationCode)
+	}
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.LeaseTTL != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.LeaseTTL.Size()))
+		n9, err := m.LeaseTTL.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	return i, nil
+}
+
+func (m *ActivateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n10, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if len(m.KeyID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.KeyID)))
+		i += copy(dAtA[i:], m.KeyID)
+	}
+	return i, nil
+}
+
+func (m *ActivationErrorDetails) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivationErrorDetails) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Reason != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Reason))
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n11, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+
+func (m *MaintenanceErrorDetails) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaintenanceErrorDetails) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RetryAfter != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.RetryAfter.Size()))
+		n12, err := m.RetryAfter.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	return i, nil
+}
+
+func (m *ActivateFromURLRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateFromURLRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.URL) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.URL)))
+		i += copy(dAtA[i:], m.URL)
+	}
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ActivateDetachedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateDetachedRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Token) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	if len(m.Signature) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Signature)))
+		i += copy(dAtA[i:], m.Signature)
+	}
+	if m.Force {
+		dAtA[i] = 0x18
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ActivatePartialRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivatePartialRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UploadID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.UploadID)))
+		i += copy(dAtA[i:], m.UploadID)
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Index))
+	}
+	if m.Total != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Total))
+	}
+	if len(m.Part) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Part)))
+		i += copy(dAtA[i:], m.Part)
+	}
+	return i, nil
+}
+
+func (m *ActivatePartialResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivatePartialResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Received != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Received))
+	}
+	if m.Activated {
+		dAtA[i] = 0x10
+		i++
+		if m.Activated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *StageActivationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StageActivationRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ActivationCode) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i += copy(dAtA[i:], m.ActivationCode)
+	}
+	return i, nil
+}
+
+func (m *StageActivationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StageActivationResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.StageID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.StageID)))
+		i += copy(dAtA[i:], m.StageID)
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n13, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+
+func (m *CommitActivationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitActivationRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.StageID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.StageID)))
+		i += copy(dAtA[i:], m.StageID)
+	}
+	return i, nil
+}
+
+func (m *GetStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.IncludeEffectiveState {
+		dAtA[i] = 0x8
+		i++
+		if m.IncludeEffectiveState {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *GetStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if len(m.Warnings) > 0 {
+		for _, s := range m.Warnings {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.NextCheckAfter != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.NextCheckAfter.Size()))
+		n14, err := m.NextCheckAfter.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.Stale {
+		dAtA[i] = 0x20
+		i++
+		if m.Stale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.LastUpdated != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.LastUpdated.Size()))
+		n15, err := m.LastUpdated.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.EffectiveState != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.EffectiveState.Size()))
+		n16, err := m.EffectiveState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.StateEnteredAt != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.StateEnteredAt.Size()))
+		n17, err := m.StateEnteredAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+
+func (m *EffectiveState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EffectiveState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.InGracePeriod {
+		dAtA[i] = 0x18
+		i++
+		if m.InGracePeriod {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.EmergencyOverride {
+		dAtA[i] = 0x20
+		i++
+		if m.EmergencyOverride {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *WatchStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.StateMask) > 0 {
+		dAtA19 := make([]byte, len(m.StateMask)*10)
+		var j18 int
+		for _, num := range m.StateMask {
+			for num >= 1<<7 {
+				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j18++
+			}
+			dAtA19[j18] = uint8(num)
+			j18++
+		}
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(j18))
+		i += copy(dAtA[i:], dAtA19[:j18])
+	}
+	if m.SendInitial != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.SendInitial.Size()))
+		n20, err := m.SendInitial.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	return i, nil
+}
+
+func (m *WatchStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if len(m.AddedFeatures) > 0 {
+		for _, s := range m.AddedFeatures {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.RemovedFeatures) > 0 {
+		for _, s := range m.RemovedFeatures {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.ExpiringFeatures) > 0 {
+		for _, s := range m.ExpiringFeatures {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *DeactivateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeactivateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *DeactivateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeactivateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.AlreadyInactive {
+		dAtA[i] = 0x8
+		i++
+		if m.AlreadyInactive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *RefreshStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RefreshStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *RefreshStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RefreshStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	return i, nil
+}
+
+func (m *GetActivationInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetActivationInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetActivationInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetActivationInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n21, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.ActivatedAt != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ActivatedAt.Size()))
+		n22, err := m.ActivatedAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if len(m.KeyID) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.KeyID)))
+		i += copy(dAtA[i:], m.KeyID)
+	}
+	if len(m.SLA) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.SLA)))
+		i += copy(dAtA[i:], m.SLA)
+	}
+	if len(m.DeprecatedFeatures) > 0 {
+		for k, _ := range m.DeprecatedFeatures {
+			dAtA[i] = 0x32
+			i++
+			v := m.DeprecatedFeatures[k]
+			mapSize := 1 + len(k) + sovEnterprise(uint64(len(k))) + 1 + len(v) + sovEnterprise(uint64(len(v)))
+			i = encodeVarintEnterprise(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.RecordHash) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.RecordHash)))
+		i += copy(dAtA[i:], m.RecordHash)
+	}
+	return i, nil
+}
+
+func (m *RebuildRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RebuildRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *RebuildResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RebuildResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	return i, nil
+}
+
+func (m *GetQuotaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetQuotaRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetQuotaResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetQuotaResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if m.MaxNodes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxNodes))
+	}
+	if m.MaxPipelines != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxPipelines))
+	}
+	if m.MaxStorageBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxStorageBytes))
+	}
+	return i, nil
+}
+
+func (m *CheckFeaturesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckFeaturesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *FeatureEntitlement) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureEntitlement) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Entitled {
+		dAtA[i] = 0x10
+		i++
+		if m.Entitled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Deprecation) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Deprecation)))
+		i += copy(dAtA[i:], m.Deprecation)
+	}
+	return i, nil
+}
+
+func (m *CheckFeaturesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckFeaturesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for _, msg := range m.Features {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.AllEntitled {
+		dAtA[i] = 0x10
+		i++
+		if m.AllEntitled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *TokenClaims) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenClaims) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n23, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MaxNodes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxNodes))
+	}
+	if m.MaxPipelines != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxPipelines))
+	}
+	if m.MaxStorageBytes != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxStorageBytes))
+	}
+	if m.Serial != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Serial))
+	}
+	if len(m.Environment) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Environment)))
+		i += copy(dAtA[i:], m.Environment)
+	}
+	return i, nil
+}
+
+func (m *PreviewCodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PreviewCodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Code) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Code)))
+		i += copy(dAtA[i:], m.Code)
+	}
+	return i, nil
+}
+
+func (m *PreviewCodeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PreviewCodeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Proposed != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Proposed.Size()))
+		n24, err := m.Proposed.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.Current != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Current.Size()))
+		n25, err := m.Current.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	if len(m.AddedFeatures) > 0 {
+		for _, s := range m.AddedFeatures {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.RemovedFeatures) > 0 {
+		for _, s := range m.RemovedFeatures {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *ValidateCodeAsOfRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidateCodeAsOfRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Code) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Code)))
+		i += copy(dAtA[i:], m.Code)
+	}
+	if m.AsOf != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.AsOf.Size()))
+		n26, err := m.AsOf.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	return i, nil
+}
+
+func (m *ValidateCodeAsOfResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidateCodeAsOfResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Valid {
+		dAtA[i] = 0x8
+		i++
+		if m.Valid {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Claims != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Claims.Size()))
+		n27, err := m.Claims.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	if m.Reason != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Reason))
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	return i, nil
+}
+
+func (m *ExportHistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ExportHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, msg := range m.Records {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SetTrustedKeysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetTrustedKeysRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKeys) > 0 {
+		for _, s := range m.PublicKeys {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *SetTrustedKeysResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetTrustedKeysResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *TrustedKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TrustedKey) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Fingerprint) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Fingerprint)))
+		i += copy(dAtA[i:], m.Fingerprint)
+	}
+	if len(m.Algorithm) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Algorithm)))
+		i += copy(dAtA[i:], m.Algorithm)
+	}
+	if m.Source != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Source))
+	}
+	return i, nil
+}
+
+func (m *ListTrustedKeysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTrustedKeysRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListTrustedKeysResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTrustedKeysResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, msg := range m.Keys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ServerTimeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServerTimeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ServerTimeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServerTimeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Time != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Time.Size()))
+		n28, err := m.Time.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	return i, nil
+}
+
+func (m *GetConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ExpiryWarningWindow != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ExpiryWarningWindow.Size()))
+		n29, err := m.ExpiryWarningWindow.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
+	}
+	if m.GracePeriod != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.GracePeriod.Size()))
+		n30, err := m.GracePeriod.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	if m.ExpiryJitter != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ExpiryJitter.Size()))
+		n31, err := m.ExpiryJitter.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n31
+	}
+	if m.ExpiryOffset != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ExpiryOffset.Size()))
+		n32, err := m.ExpiryOffset.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n32
+	}
+	if m.MaxClockSkew != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxClockSkew.Size()))
+		n33, err := m.MaxClockSkew.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n33
+	}
+	if m.MinRemaining != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MinRemaining.Size()))
+		n34, err := m.MinRemaining.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
+	if m.TrustedKeySource != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.TrustedKeySource))
+	}
+	if m.BackupKeysConfigured {
+		dAtA[i] = 0x40
+		i++
+		if m.BackupKeysConfigured {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.JWKSURL) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.JWKSURL)))
+		i += copy(dAtA[i:], m.JWKSURL)
+	}
+	if m.TrialsEnabled {
+		dAtA[i] = 0x50
+		i++
+		if m.TrialsEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.StateInjectionEnabled {
+		dAtA[i] = 0x58
+		i++
+		if m.StateInjectionEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.RequireMonotonicActivation {
+		dAtA[i] = 0x60
+		i++
+		if m.RequireMonotonicActivation {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Environment) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Environment)))
+		i += copy(dAtA[i:], m.Environment)
+	}
+	if len(m.ProductID) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ProductID)))
+		i += copy(dAtA[i:], m.ProductID)
+	}
+	if m.MinSchemaVersion != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MinSchemaVersion))
+	}
+	if len(m.ActivationURLHosts) > 0 {
+		for _, s := range m.ActivationURLHosts {
+			dAtA[i] = 0x82
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.EtcdPrefix) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.EtcdPrefix)))
+		i += copy(dAtA[i:], m.EtcdPrefix)
+	}
+	if len(m.HistoryPrefix) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.HistoryPrefix)))
+		i += copy(dAtA[i:], m.HistoryPrefix)
+	}
+	if m.JSONRecords {
+		dAtA[i] = 0x98
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.JSONRecords {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.CompressRecordsAbove != 0 {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.CompressRecordsAbove))
+	}
+	if m.MaxRecordSize != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.MaxRecordSize))
+	}
+	return i, nil
+}
+
+func (m *SetEmergencyOverrideRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetEmergencyOverrideRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Duration != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Duration.Size()))
+		n35, err := m.Duration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n35
+	}
+	return i, nil
+}
+
+func (m *SetEmergencyOverrideResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetEmergencyOverrideResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n36, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n36
+	}
+	return i, nil
+}
+
+func (m *InjectStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InjectStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if m.Expiry != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expiry.Size()))
+		n37, err := m.Expiry.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n37
+	}
+	if m.TTL != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.TTL.Size()))
+		n38, err := m.TTL.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n38
+	}
+	return i, nil
+}
+
+func (m *InjectStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InjectStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n39, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	return i, nil
+}
+
+func (m *SelfTestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SelfTestRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *SelfTestResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SelfTestResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Success {
+		dAtA[i] = 0x8
+		i++
+		if m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.WriteDuration != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.WriteDuration.Size()))
+		n40, err := m.WriteDuration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	if m.WatchDuration != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.WatchDuration.Size()))
+		n41, err := m.WatchDuration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n41
+	}
+	if m.ReadDuration != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ReadDuration.Size()))
+		n42, err := m.ReadDuration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n42
+	}
+	if m.DeleteDuration != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.DeleteDuration.Size()))
+		n43, err := m.DeleteDuration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
+	}
+	return i, nil
+}
+
+func (m *DebugDumpRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DebugDumpRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *EtcdEndpointHealth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EtcdEndpointHealth) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Endpoint) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Endpoint)))
+		i += copy(dAtA[i:], m.Endpoint)
+	}
+	if m.Healthy {
+		dAtA[i] = 0x10
+		i++
+		if m.Healthy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func (m *DebugDumpResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DebugDumpResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+	}
+	if m.Expires != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Expires.Size()))
+		n44, err := m.Expires.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.ActivationCodeFingerprint) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCodeFingerprint)))
+		i += copy(dAtA[i:], m.ActivationCodeFingerprint)
+	}
+	if m.WatchConnected {
+		dAtA[i] = 0x28
+		i++
+		if m.WatchConnected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.LastWatchEvent != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.LastWatchEvent.Size()))
+		n45, err := m.LastWatchEvent.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
+	}
+	if len(m.LastError) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.LastError)))
+		i += copy(dAtA[i:], m.LastError)
+	}
+	if len(m.EtcdEndpoints) > 0 {
+		for _, msg := range m.EtcdEndpoints {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintEnterprise(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64Enterprise(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Enterprise(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintEnterprise(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *EnterpriseRecord) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.MaxNodes != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxNodes))
+	}
+	if m.Written != nil {
+		l = m.Written.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.MaxPipelines != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxPipelines))
+	}
+	if m.MaxStorageBytes != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxStorageBytes))
+	}
+	if m.Serial != 0 {
+		n += 1 + sovEnterprise(uint64(m.Serial))
+	}
+	l = len(m.Environment)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.IssuedAt != nil {
+		l = m.IssuedAt.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.ActivatedAt != nil {
+		l = m.ActivatedAt.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.KeyID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.SchemaVersion != 0 {
+		n += 1 + sovEnterprise(uint64(m.SchemaVersion))
+	}
+	l = len(m.SLA)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.LeaseExpires != nil {
+		l = m.LeaseExpires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.Product)
+	if l > 0 {
+		n += 2 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.DeprecatedFeatures) > 0 {
+		for k, v := range m.DeprecatedFeatures {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovEnterprise(uint64(len(k))) + 1 + len(v) + sovEnterprise(uint64(len(v)))
+			n += mapEntrySize + 2 + sovEnterprise(uint64(mapEntrySize))
+		}
+	}
+	if len(m.FeatureExpiries) > 0 {
+		for k, v := range m.FeatureExpiries {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovEnterprise(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovEnterprise(uint64(len(k))) + l
+			n += mapEntrySize + 2 + sovEnterprise(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.MinVersion)
+	if l > 0 {
+		n += 2 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.MaxVersion)
+	if l > 0 {
+		n += 2 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivationHistoryRecord) Size() (n int) {
+	var l int
+	_ = l
+	if m.Activated != nil {
+		l = m.Activated.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.ActivationCodeFingerprint)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ActivateRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.LeaseTTL != nil {
+		l = m.LeaseTTL.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivateResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.KeyID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivationErrorDetails) Size() (n int) {
+	var l int
+	_ = l
+	if m.Reason != 0 {
+		n += 1 + sovEnterprise(uint64(m.Reason))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *MaintenanceErrorDetails) Size() (n int) {
+	var l int
+	_ = l
+	if m.RetryAfter != nil {
+		l = m.RetryAfter.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivateFromURLRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	return n
+}
+
+func (m *ActivateDetachedRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	return n
+}
+
+func (m *ActivatePartialRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UploadID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovEnterprise(uint64(m.Index))
+	}
+	if m.Total != 0 {
+		n += 1 + sovEnterprise(uint64(m.Total))
+	}
+	l = len(m.Part)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivatePartialResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Received != 0 {
+		n += 1 + sovEnterprise(uint64(m.Received))
+	}
+	if m.Activated {
+		n += 2
+	}
+	return n
+}
+
+func (m *StageActivationRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *StageActivationResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.StageID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *CommitActivationRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.StageID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *GetStateRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.IncludeEffectiveState {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetStateResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if len(m.Warnings) > 0 {
+		for _, s := range m.Warnings {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.NextCheckAfter != nil {
+		l = m.NextCheckAfter.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Stale {
+		n += 2
+	}
+	if m.LastUpdated != nil {
+		l = m.LastUpdated.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.EffectiveState != nil {
+		l = m.EffectiveState.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.StateEnteredAt != nil {
+		l = m.StateEnteredAt.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *EffectiveState) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.InGracePeriod {
+		n += 2
+	}
+	if m.EmergencyOverride {
+		n += 2
+	}
+	return n
+}
+
+func (m *WatchStateRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.StateMask) > 0 {
+		l = 0
+		for _, e := range m.StateMask {
+			l += sovEnterprise(uint64(e))
+		}
+		n += 1 + sovEnterprise(uint64(l)) + l
+	}
+	if m.SendInitial != nil {
+		l = m.SendInitial.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchStateResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if len(m.AddedFeatures) > 0 {
+		for _, s := range m.AddedFeatures {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if len(m.RemovedFeatures) > 0 {
+		for _, s := range m.RemovedFeatures {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if len(m.ExpiringFeatures) > 0 {
+		for _, s := range m.ExpiringFeatures {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DeactivateRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *DeactivateResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.AlreadyInactive {
+		n += 2
+	}
+	return n
+}
+
+func (m *RefreshStateRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RefreshStateResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	return n
+}
+
+func (m *GetActivationInfoRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetActivationInfoResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.ActivatedAt != nil {
+		l = m.ActivatedAt.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.KeyID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.SLA)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.DeprecatedFeatures) > 0 {
+		for k, v := range m.DeprecatedFeatures {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovEnterprise(uint64(len(k))) + 1 + len(v) + sovEnterprise(uint64(len(v)))
+			n += mapEntrySize + 1 + sovEnterprise(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.RecordHash)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *RebuildRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RebuildResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	return n
+}
+
+func (m *GetQuotaRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetQuotaResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.MaxNodes != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxNodes))
+	}
+	if m.MaxPipelines != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxPipelines))
+	}
+	if m.MaxStorageBytes != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxStorageBytes))
+	}
+	return n
+}
+
+func (m *CheckFeaturesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FeatureEntitlement) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Entitled {
+		n += 2
+	}
+	l = len(m.Deprecation)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *CheckFeaturesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for _, e := range m.Features {
+			l = e.Size()
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.AllEntitled {
+		n += 2
+	}
+	return n
+}
+
+func (m *TokenClaims) Size() (n int) {
+	var l int
+	_ = l
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.MaxNodes != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxNodes))
+	}
+	if m.MaxPipelines != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxPipelines))
+	}
+	if m.MaxStorageBytes != 0 {
+		n += 1 + sovEnterprise(uint64(m.MaxStorageBytes))
+	}
+	if m.Serial != 0 {
+		n += 1 + sovEnterprise(uint64(m.Serial))
+	}
+	l = len(m.Environment)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *PreviewCodeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *PreviewCodeResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Proposed != nil {
+		l = m.Proposed.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Current != nil {
+		l = m.Current.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.AddedFeatures) > 0 {
+		for _, s := range m.AddedFeatures {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if len(m.RemovedFeatures) > 0 {
+		for _, s := range m.RemovedFeatures {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ValidateCodeAsOfRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.AsOf != nil {
+		l = m.AsOf.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ValidateCodeAsOfResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Valid {
+		n += 2
+	}
+	if m.Claims != nil {
+		l = m.Claims.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovEnterprise(uint64(m.Reason))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportHistoryRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ExportHistoryResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetTrustedKeysRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.PublicKeys) > 0 {
+		for _, s := range m.PublicKeys {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetTrustedKeysResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TrustedKey) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Fingerprint)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.Algorithm)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Source != 0 {
+		n += 1 + sovEnterprise(uint64(m.Source))
+	}
+	return n
+}
+
+func (m *ListTrustedKeysRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListTrustedKeysResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, e := range m.Keys {
+			l = e.Size()
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServerTimeRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ServerTimeResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != nil {
+		l = m.Time.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *GetConfigRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetConfigResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.ExpiryWarningWindow != nil {
+		l = m.ExpiryWarningWindow.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.GracePeriod != nil {
+		l = m.GracePeriod.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.ExpiryJitter != nil {
+		l = m.ExpiryJitter.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.ExpiryOffset != nil {
+		l = m.ExpiryOffset.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.MaxClockSkew != nil {
+		l = m.MaxClockSkew.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.MinRemaining != nil {
+		l = m.MinRemaining.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.TrustedKeySource != 0 {
+		n += 1 + sovEnterprise(uint64(m.TrustedKeySource))
+	}
+	if m.BackupKeysConfigured {
+		n += 2
+	}
+	l = len(m.JWKSURL)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.TrialsEnabled {
+		n += 2
+	}
+	if m.StateInjectionEnabled {
+		n += 2
+	}
+	if m.RequireMonotonicActivation {
+		n += 2
+	}
+	l = len(m.Environment)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.ProductID)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.MinSchemaVersion != 0 {
+		n += 1 + sovEnterprise(uint64(m.MinSchemaVersion))
+	}
+	if len(m.ActivationURLHosts) > 0 {
+		for _, s := range m.ActivationURLHosts {
+			l = len(s)
+			n += 2 + l + sovEnterprise(uint64(l))
+		}
+	}
+	l = len(m.EtcdPrefix)
+	if l > 0 {
+		n += 2 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.HistoryPrefix)
+	if l > 0 {
+		n += 2 + l + sovEnterprise(uint64(l))
+	}
+	if m.JSONRecords {
+		n += 3
+	}
+	if m.CompressRecordsAbove != 0 {
+		n += 2 + sovEnterprise(uint64(m.CompressRecordsAbove))
+	}
+	if m.MaxRecordSize != 0 {
+		n += 2 + sovEnterprise(uint64(m.MaxRecordSize))
+	}
+	return n
+}
+
+func (m *SetEmergencyOverrideRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Duration != nil {
+		l = m.Duration.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *SetEmergencyOverrideResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *InjectStateRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.Expiry != nil {
+		l = m.Expiry.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.TTL != nil {
+		l = m.TTL.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *InjectStateResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *SelfTestRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SelfTestResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Success {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.WriteDuration != nil {
+		l = m.WriteDuration.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.WatchDuration != nil {
+		l = m.WatchDuration.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.ReadDuration != nil {
+		l = m.ReadDuration.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.DeleteDuration != nil {
+		l = m.DeleteDuration.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *DebugDumpRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *EtcdEndpointHealth) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Healthy {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	return n
+}
+
+func (m *DebugDumpResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	l = len(m.ActivationCodeFingerprint)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.WatchConnected {
+		n += 2
+	}
+	if m.LastWatchEvent != nil {
+		l = m.LastWatchEvent.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.LastError)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.EtcdEndpoints) > 0 {
+		for _, e := range m.EtcdEndpoints {
+			l = e.Size()
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovEnterprise(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozEnterprise(x uint64) (n int) {
+	return sovEnterprise(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -478,19 +6692,7099 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: EnterpriseRecord: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EnterpriseRecord: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EnterpriseRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EnterpriseRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxNodes", wireType)
+			}
+			m.MaxNodes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxNodes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Written", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Written == nil {
+				m.Written = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Written.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPipelines", wireType)
+			}
+			m.MaxPipelines = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPipelines |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStorageBytes", wireType)
+			}
+			m.MaxStorageBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxStorageBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Serial", wireType)
+			}
+			m.Serial = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Serial |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Environment", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Environment = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IssuedAt == nil {
+				m.IssuedAt = &google_protobuf1.Timestamp{}
+			}
+			if err := m.IssuedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActivatedAt == nil {
+				m.ActivatedAt = &google_protobuf1.Timestamp{}
+			}
+			if err := m.ActivatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaVersion", wireType)
+			}
+			m.SchemaVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaVersion |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SLA", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SLA = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseExpires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseExpires == nil {
+				m.LeaseExpires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.LeaseExpires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Product", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Product = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedFeatures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.DeprecatedFeatures == nil {
+				m.DeprecatedFeatures = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthEnterprise
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.DeprecatedFeatures[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.DeprecatedFeatures[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeatureExpiries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.FeatureExpiries == nil {
+				m.FeatureExpiries = make(map[string]*google_protobuf1.Timestamp)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var mapmsglen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					mapmsglen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if mapmsglen < 0 {
+					return ErrInvalidLengthEnterprise
+				}
+				postmsgIndex := iNdEx + mapmsglen
+				if mapmsglen < 0 {
+					return ErrInvalidLengthEnterprise
+				}
+				if postmsgIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := &google_protobuf1.Timestamp{}
+				if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+					return err
+				}
+				iNdEx = postmsgIndex
+				m.FeatureExpiries[mapkey] = mapvalue
+			} else {
+				var mapvalue *google_protobuf1.Timestamp
+				m.FeatureExpiries[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivationHistoryRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivationHistoryRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivationHistoryRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Activated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Activated == nil {
+				m.Activated = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Activated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodeFingerprint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCodeFingerprint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseTTL", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseTTL == nil {
+				m.LeaseTTL = &google_protobuf.Duration{}
+			}
+			if err := m.LeaseTTL.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivationErrorDetails) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivationErrorDetails: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivationErrorDetails: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= (ActivationErrorReason(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintenanceErrorDetails) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintenanceErrorDetails: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintenanceErrorDetails: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RetryAfter == nil {
+				m.RetryAfter = &google_protobuf.Duration{}
+			}
+			if err := m.RetryAfter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateFromURLRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateFromURLRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateFromURLRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateDetachedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateDetachedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateDetachedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivatePartialRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivatePartialRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivatePartialRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UploadID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Part = append(m.Part[:0], dAtA[iNdEx:postIndex]...)
+			if m.Part == nil {
+				m.Part = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivatePartialResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivatePartialResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivatePartialResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Received", wireType)
+			}
+			m.Received = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Received |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Activated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Activated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StageActivationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StageActivationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StageActivationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StageActivationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StageActivationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StageActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StageID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StageID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitActivationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitActivationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitActivationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StageID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StageID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeEffectiveState", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeEffectiveState = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCheckAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NextCheckAfter == nil {
+				m.NextCheckAfter = &google_protobuf.Duration{}
+			}
+			if err := m.NextCheckAfter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stale = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastUpdated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastUpdated == nil {
+				m.LastUpdated = &google_protobuf1.Timestamp{}
+			}
+			if err := m.LastUpdated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EffectiveState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EffectiveState == nil {
+				m.EffectiveState = &EffectiveState{}
+			}
+			if err := m.EffectiveState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateEnteredAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StateEnteredAt == nil {
+				m.StateEnteredAt = &google_protobuf1.Timestamp{}
+			}
+			if err := m.StateEnteredAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EffectiveState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EffectiveState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EffectiveState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InGracePeriod", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.InGracePeriod = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmergencyOverride", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EmergencyOverride = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v State
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (State(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.StateMask = append(m.StateMask, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEnterprise
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v State
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEnterprise
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (State(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.StateMask = append(m.StateMask, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateMask", wireType)
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendInitial", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SendInitial == nil {
+				m.SendInitial = &google_protobuf2.BoolValue{}
+			}
+			if err := m.SendInitial.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddedFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AddedFeatures = append(m.AddedFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemovedFeatures = append(m.RemovedFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiringFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpiringFeatures = append(m.ExpiringFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeactivateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeactivateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeactivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeactivateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeactivateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeactivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AlreadyInactive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AlreadyInactive = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RefreshStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RefreshStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RefreshStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RefreshStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RefreshStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RefreshStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetActivationInfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetActivationInfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetActivationInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetActivationInfoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetActivationInfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetActivationInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActivatedAt == nil {
+				m.ActivatedAt = &google_protobuf1.Timestamp{}
+			}
+			if err := m.ActivatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SLA", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SLA = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedFeatures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.DeprecatedFeatures == nil {
+				m.DeprecatedFeatures = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEnterprise
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthEnterprise
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.DeprecatedFeatures[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.DeprecatedFeatures[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RebuildRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RebuildRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RebuildRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RebuildResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RebuildResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RebuildResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetQuotaRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetQuotaRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetQuotaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetQuotaResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetQuotaResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetQuotaResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxNodes", wireType)
+			}
+			m.MaxNodes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxNodes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPipelines", wireType)
+			}
+			m.MaxPipelines = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPipelines |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStorageBytes", wireType)
+			}
+			m.MaxStorageBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxStorageBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckFeaturesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckFeaturesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckFeaturesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureEntitlement) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureEntitlement: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureEntitlement: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entitled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Entitled = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deprecation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deprecation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckFeaturesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckFeaturesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckFeaturesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, &FeatureEntitlement{})
+			if err := m.Features[len(m.Features)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllEntitled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllEntitled = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenClaims) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenClaims: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenClaims: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxNodes", wireType)
+			}
+			m.MaxNodes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxNodes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPipelines", wireType)
+			}
+			m.MaxPipelines = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPipelines |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStorageBytes", wireType)
+			}
+			m.MaxStorageBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxStorageBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Serial", wireType)
+			}
+			m.Serial = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Serial |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Environment", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Environment = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PreviewCodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PreviewCodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PreviewCodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PreviewCodeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PreviewCodeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PreviewCodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proposed == nil {
+				m.Proposed = &TokenClaims{}
+			}
+			if err := m.Proposed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Current", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Current == nil {
+				m.Current = &TokenClaims{}
+			}
+			if err := m.Current.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddedFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AddedFeatures = append(m.AddedFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemovedFeatures = append(m.RemovedFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidateCodeAsOfRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidateCodeAsOfRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidateCodeAsOfRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AsOf", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AsOf == nil {
+				m.AsOf = &google_protobuf1.Timestamp{}
+			}
+			if err := m.AsOf.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidateCodeAsOfResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidateCodeAsOfResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidateCodeAsOfResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Valid", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Valid = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Claims", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Claims == nil {
+				m.Claims = &TokenClaims{}
+			}
+			if err := m.Claims.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= (ActivationErrorReason(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportHistoryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportHistoryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, &ActivationHistoryRecord{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetTrustedKeysRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetTrustedKeysRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetTrustedKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKeys = append(m.PublicKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetTrustedKeysResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetTrustedKeysResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetTrustedKeysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TrustedKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TrustedKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TrustedKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fingerprint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fingerprint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Algorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Algorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			m.Source = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Source |= (TrustedKeySource(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListTrustedKeysRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListTrustedKeysRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListTrustedKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListTrustedKeysResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListTrustedKeysResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListTrustedKeysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, &TrustedKey{})
+			if err := m.Keys[len(m.Keys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServerTimeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServerTimeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServerTimeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServerTimeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServerTimeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServerTimeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConfigResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConfigResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiryWarningWindow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpiryWarningWindow == nil {
+				m.ExpiryWarningWindow = &google_protobuf.Duration{}
+			}
+			if err := m.ExpiryWarningWindow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GracePeriod", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GracePeriod == nil {
+				m.GracePeriod = &google_protobuf.Duration{}
+			}
+			if err := m.GracePeriod.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiryJitter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpiryJitter == nil {
+				m.ExpiryJitter = &google_protobuf.Duration{}
+			}
+			if err := m.ExpiryJitter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiryOffset", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpiryOffset == nil {
+				m.ExpiryOffset = &google_protobuf.Duration{}
+			}
+			if err := m.ExpiryOffset.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxClockSkew", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxClockSkew == nil {
+				m.MaxClockSkew = &google_protobuf.Duration{}
+			}
+			if err := m.MaxClockSkew.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinRemaining", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MinRemaining == nil {
+				m.MinRemaining = &google_protobuf.Duration{}
+			}
+			if err := m.MinRemaining.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustedKeySource", wireType)
+			}
+			m.TrustedKeySource = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TrustedKeySource |= (TrustedKeySource(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackupKeysConfigured", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BackupKeysConfigured = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JWKSURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JWKSURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrialsEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TrialsEnabled = bool(v != 0)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateInjectionEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StateInjectionEnabled = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequireMonotonicActivation", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequireMonotonicActivation = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Environment", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Environment = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProductID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProductID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinSchemaVersion", wireType)
+			}
+			m.MinSchemaVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinSchemaVersion |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationURLHosts", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationURLHosts = append(m.ActivationURLHosts, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EtcdPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoryPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HistoryPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JSONRecords", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.JSONRecords = bool(v != 0)
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressRecordsAbove", wireType)
+			}
+			m.CompressRecordsAbove = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompressRecordsAbove |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecordSize", wireType)
+			}
+			m.MaxRecordSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRecordSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetEmergencyOverrideRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetEmergencyOverrideRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetEmergencyOverrideRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Duration == nil {
+				m.Duration = &google_protobuf.Duration{}
+			}
+			if err := m.Duration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetEmergencyOverrideResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetEmergencyOverrideResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetEmergencyOverrideResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InjectStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InjectStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InjectStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiry", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expiry == nil {
+				m.Expiry = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expiry.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TTL == nil {
+				m.TTL = &google_protobuf.Duration{}
+			}
+			if err := m.TTL.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InjectStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InjectStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InjectStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SelfTestRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SelfTestRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SelfTestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SelfTestResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SelfTestResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SelfTestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Success = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.WriteDuration == nil {
+				m.WriteDuration = &google_protobuf.Duration{}
+			}
+			if err := m.WriteDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatchDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.WatchDuration == nil {
+				m.WatchDuration = &google_protobuf.Duration{}
+			}
+			if err := m.WatchDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadDuration == nil {
+				m.ReadDuration = &google_protobuf.Duration{}
+			}
+			if err := m.ReadDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeleteDuration == nil {
+				m.DeleteDuration = &google_protobuf.Duration{}
+			}
+			if err := m.DeleteDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DebugDumpRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DebugDumpRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DebugDumpRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EtcdEndpointHealth) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EtcdEndpointHealth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EtcdEndpointHealth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Healthy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Healthy = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DebugDumpResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DebugDumpResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DebugDumpResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= (State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &google_protobuf1.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodeFingerprint", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -515,11 +13809,31 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			m.ActivationCodeFingerprint = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatchConnected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WatchConnected = bool(v != 0)
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastWatchEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -543,66 +13857,16 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Expires == nil {
-				m.Expires = &google_protobuf.Timestamp{}
+			if m.LastWatchEvent == nil {
+				m.LastWatchEvent = &google_protobuf1.Timestamp{}
 			}
-			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LastWatchEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEnterprise(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthEnterprise
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEnterprise
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActivateRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -627,163 +13891,13 @@ func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			m.LastError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEnterprise(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthEnterprise
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEnterprise
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActivateResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEnterprise(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthEnterprise
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetStateRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEnterprise
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetStateRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEnterprise(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthEnterprise
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEnterprise
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetStateResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdEndpoints", wireType)
 			}
-			m.State = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -793,11 +13907,23 @@ func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= (State(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EtcdEndpoints = append(m.EtcdEndpoints, &EtcdEndpointHealth{})
+			if err := m.EtcdEndpoints[len(m.EtcdEndpoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEnterprise(dAtA[iNdEx:])
@@ -927,26 +14053,230 @@ var (
 func init() { proto.RegisterFile("client/enterprise/enterprise.proto", fileDescriptorEnterprise) }
 
 var fileDescriptorEnterprise = []byte{
-	// 331 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xcf, 0x4e, 0xf2, 0x40,
-	0x14, 0xc5, 0x3b, 0x7c, 0x1f, 0x7f, 0xbc, 0x24, 0x30, 0xcc, 0x8a, 0xa0, 0xa9, 0x64, 0x36, 0x10,
-	0x16, 0x25, 0x41, 0x57, 0xba, 0x42, 0x6c, 0x08, 0x1b, 0x24, 0x95, 0x18, 0x77, 0x06, 0xca, 0x95,
-	0x4c, 0x02, 0x9d, 0xd2, 0x19, 0x8c, 0x6f, 0xa2, 0x8f, 0xe4, 0xd2, 0x47, 0x30, 0xf8, 0x22, 0x86,
-	0x8e, 0xb5, 0x40, 0x5c, 0xb8, 0x6b, 0xcf, 0x3d, 0xf3, 0x3b, 0xf7, 0xcc, 0x00, 0xf7, 0x17, 0x02,
-	0x03, 0xdd, 0xc6, 0x40, 0x63, 0x14, 0x46, 0x42, 0xe1, 0xce, 0xa7, 0x13, 0x46, 0x52, 0x4b, 0x06,
-	0xa9, 0x52, 0x3b, 0x9d, 0x4b, 0x39, 0x5f, 0x60, 0x3b, 0x9e, 0x4c, 0xd7, 0x8f, 0x6d, 0x2d, 0x96,
-	0xa8, 0xf4, 0x64, 0x19, 0x1a, 0x33, 0x5f, 0x01, 0x75, 0x7f, 0xec, 0x1e, 0xfa, 0x32, 0x9a, 0xb1,
-	0x06, 0x94, 0x27, 0xbe, 0x16, 0x4f, 0x13, 0x2d, 0x64, 0xf0, 0xe0, 0xcb, 0x19, 0x56, 0x49, 0x9d,
-	0x34, 0x8f, 0xbc, 0x52, 0x2a, 0xf7, 0xe4, 0x0c, 0xd9, 0x39, 0xe4, 0xf1, 0x39, 0x14, 0x11, 0xaa,
-	0x6a, 0xa6, 0x4e, 0x9a, 0xc5, 0x4e, 0xcd, 0x31, 0x79, 0x4e, 0x92, 0xe7, 0x8c, 0x93, 0x3c, 0x2f,
-	0xb1, 0xf2, 0x0b, 0x28, 0x77, 0x0d, 0x07, 0x3d, 0x5c, 0xad, 0x51, 0xe9, 0x3f, 0x27, 0x72, 0x06,
-	0x34, 0x3d, 0xab, 0x42, 0x19, 0x28, 0xe4, 0x15, 0x28, 0xf7, 0x51, 0xdf, 0xea, 0x94, 0xc7, 0x2f,
-	0x81, 0xa6, 0x92, 0xb1, 0xb1, 0x06, 0x64, 0xd5, 0x56, 0x88, 0xc9, 0xa5, 0x4e, 0xc5, 0xd9, 0xb9,
-	0x38, 0xe3, 0x34, 0xf3, 0x56, 0x0b, 0xb2, 0xf1, 0x3f, 0x2b, 0xc0, 0xff, 0xe1, 0xcd, 0xd0, 0xa5,
-	0x16, 0x03, 0xc8, 0x75, 0x7b, 0xe3, 0xc1, 0x9d, 0x4b, 0x09, 0x2b, 0x42, 0xde, 0xbd, 0x1f, 0x0d,
-	0x3c, 0xf7, 0x9a, 0x66, 0x3a, 0x2f, 0x04, 0xfe, 0x75, 0x47, 0x03, 0xd6, 0x87, 0x42, 0xb2, 0x17,
-	0x3b, 0xde, 0x25, 0x1f, 0x34, 0xad, 0x9d, 0xfc, 0x3e, 0xfc, 0xae, 0x62, 0x6d, 0x41, 0xc9, 0xe6,
-	0xfb, 0xa0, 0x83, 0x8a, 0xfb, 0xa0, 0xc3, 0xb2, 0xdc, 0xba, 0xa2, 0x6f, 0x1b, 0x9b, 0xbc, 0x6f,
-	0x6c, 0xf2, 0xb1, 0xb1, 0xc9, 0xeb, 0xa7, 0x6d, 0x4d, 0x73, 0xf1, 0xa3, 0x9c, 0x7d, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x96, 0xa9, 0xfb, 0xdb, 0x44, 0x02, 0x00, 0x00,
+	// 3599 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcd, 0x73, 0xdb, 0x58,
+	0x72, 0x37, 0x45, 0x52, 0x24, 0x9b, 0x22, 0x09, 0x3d, 0xeb, 0x83, 0x86, 0x35, 0x92, 0x0c, 0xef,
+	0xec, 0x78, 0x9c, 0x89, 0x3d, 0xab, 0x75, 0x92, 0xc9, 0x54, 0x66, 0x66, 0x29, 0x12, 0xb6, 0x69,
+	0x4b, 0xa4, 0x02, 0x52, 0xf2, 0x4e, 0xd5, 0xd6, 0x22, 0x10, 0xf0, 0x48, 0xc1, 0x02, 0x01, 0x2e,
+	0x00, 0xea, 0x63, 0x8f, 0x49, 0xaa, 0x52, 0xb9, 0xe4, 0x92, 0xaa, 0xd4, 0xde, 0x73, 0x48, 0xa5,
+	0x2a, 0xe7, 0x9c, 0xf2, 0x07, 0xa4, 0x72, 0x48, 0x36, 0xff, 0x80, 0x2b, 0xa5, 0x54, 0xfe, 0x8b,
+	0x1c, 0x52, 0xef, 0x03, 0x9f, 0xa4, 0x44, 0xc9, 0xd9, 0x9a, 0x1b, 0x5e, 0xf7, 0xaf, 0x1b, 0xfd,
+	0xfa, 0xbd, 0xd7, 0xaf, 0xbb, 0x1f, 0x48, 0xba, 0x65, 0x62, 0xdb, 0x7f, 0x8e, 0x6d, 0x1f, 0xbb,
+	0x63, 0xd7, 0xf4, 0x70, 0xec, 0xf3, 0xd9, 0xd8, 0x75, 0x7c, 0x07, 0x41, 0x44, 0x11, 0x37, 0x87,
+	0x8e, 0x33, 0xb4, 0xf0, 0x73, 0xca, 0x39, 0x9e, 0x0c, 0x9e, 0x1b, 0x13, 0x57, 0xf3, 0x4d, 0xc7,
+	0x66, 0x58, 0x71, 0x2b, 0xcd, 0xf7, 0xcd, 0x11, 0xf6, 0x7c, 0x6d, 0x34, 0xe6, 0x80, 0x29, 0x05,
+	0xe7, 0xae, 0x36, 0x1e, 0x63, 0xd7, 0xe3, 0xfc, 0x95, 0xa1, 0x33, 0x74, 0xe8, 0xe7, 0x73, 0xf2,
+	0xc5, 0xa8, 0xd2, 0x6f, 0x8b, 0x20, 0xc8, 0xa1, 0x15, 0x0a, 0xd6, 0x1d, 0xd7, 0x40, 0x9f, 0x41,
+	0x4d, 0xd3, 0x7d, 0xf3, 0x8c, 0xfe, 0x5f, 0xd5, 0x1d, 0x03, 0xd7, 0x33, 0xdb, 0x99, 0x27, 0x25,
+	0xa5, 0x1a, 0x91, 0x9b, 0x8e, 0x81, 0xd1, 0x0b, 0x28, 0xe0, 0x8b, 0xb1, 0xe9, 0x62, 0xaf, 0xbe,
+	0xb0, 0x9d, 0x79, 0x52, 0xde, 0x11, 0x9f, 0x31, 0x2b, 0x9e, 0x05, 0x56, 0x3c, 0xeb, 0x07, 0x66,
+	0x2a, 0x01, 0x14, 0x3d, 0x84, 0xd2, 0x48, 0xbb, 0x50, 0x6d, 0xc7, 0xc0, 0x5e, 0x3d, 0xbb, 0x9d,
+	0x79, 0x92, 0x55, 0x8a, 0x23, 0xed, 0xa2, 0x43, 0xc6, 0x44, 0xe5, 0xb9, 0x6b, 0xfa, 0x3e, 0xb6,
+	0xeb, 0xb9, 0xf9, 0x2a, 0x39, 0x14, 0x89, 0x50, 0x1c, 0x60, 0xcd, 0x9f, 0x10, 0x4b, 0xf2, 0xdb,
+	0xd9, 0x27, 0x25, 0x25, 0x1c, 0xa3, 0xc7, 0x50, 0x21, 0xbf, 0x1b, 0x9b, 0x63, 0x6c, 0x99, 0x36,
+	0xf6, 0xea, 0x8b, 0xdb, 0x99, 0x27, 0x79, 0x65, 0x69, 0xa4, 0x5d, 0x1c, 0x04, 0x34, 0xf4, 0x14,
+	0x96, 0x09, 0xc8, 0xf3, 0x1d, 0x57, 0x1b, 0x62, 0xf5, 0xf8, 0xd2, 0xc7, 0x5e, 0xbd, 0x40, 0x6d,
+	0xab, 0x8d, 0xb4, 0x8b, 0x1e, 0xa3, 0xef, 0x12, 0x32, 0x5a, 0x83, 0x45, 0x0f, 0xbb, 0xa6, 0x66,
+	0xd5, 0x8b, 0x14, 0xc0, 0x47, 0x68, 0x1b, 0xca, 0xd8, 0x3e, 0x33, 0x5d, 0xc7, 0x1e, 0x61, 0xdb,
+	0xaf, 0x97, 0xa8, 0xcb, 0xe2, 0x24, 0xf4, 0x47, 0x50, 0x32, 0x3d, 0x6f, 0x82, 0x0d, 0x55, 0xf3,
+	0xeb, 0x30, 0x77, 0x7a, 0x45, 0x06, 0x6e, 0xf8, 0xe8, 0x1b, 0x58, 0xe2, 0xae, 0x67, 0xb2, 0xe5,
+	0xb9, 0xb2, 0xe5, 0x10, 0xdf, 0xf0, 0xd1, 0x36, 0x2c, 0x9e, 0xe2, 0x4b, 0xd5, 0x34, 0xea, 0x4b,
+	0xc4, 0xa8, 0xdd, 0xd2, 0xd5, 0x87, 0xad, 0xfc, 0x5b, 0x7c, 0xd9, 0x6e, 0x29, 0xf9, 0x53, 0x7c,
+	0xd9, 0x36, 0xd0, 0xa7, 0x50, 0xf5, 0xf4, 0x13, 0x3c, 0xd2, 0xd4, 0x33, 0xec, 0x7a, 0xa6, 0x63,
+	0xd7, 0x2b, 0x74, 0x6e, 0x15, 0x46, 0x3d, 0x62, 0x44, 0xf4, 0x00, 0xb2, 0x9e, 0xa5, 0xd5, 0xab,
+	0x54, 0x4b, 0xe1, 0xea, 0xc3, 0x56, 0xb6, 0xb7, 0xd7, 0x50, 0x08, 0x0d, 0x7d, 0x07, 0x15, 0x0b,
+	0x6b, 0x1e, 0x56, 0x83, 0x1d, 0x51, 0x9b, 0x6b, 0xe3, 0x12, 0x15, 0x90, 0xf9, 0xb6, 0xa8, 0x43,
+	0x61, 0xec, 0x3a, 0xc6, 0x44, 0xf7, 0xeb, 0x02, 0x75, 0x5d, 0x30, 0x44, 0x18, 0xee, 0x1b, 0x78,
+	0xec, 0x62, 0x9d, 0x4e, 0x3f, 0x5c, 0xe8, 0xe5, 0xed, 0xec, 0x93, 0xf2, 0xce, 0x8b, 0x67, 0xb1,
+	0x73, 0x95, 0xde, 0xca, 0xcf, 0x5a, 0xa1, 0xdc, 0x4b, 0x2e, 0x26, 0xdb, 0xbe, 0x7b, 0xa9, 0x20,
+	0x63, 0x8a, 0x81, 0x7e, 0x01, 0x02, 0xd7, 0xcd, 0xe6, 0x60, 0x62, 0xaf, 0x8e, 0xe8, 0x3f, 0x7e,
+	0x72, 0xe3, 0x3f, 0xb8, 0x02, 0x99, 0xcb, 0xb0, 0x1f, 0xd4, 0x06, 0x49, 0x2a, 0xda, 0x82, 0xf2,
+	0xc8, 0xb4, 0x43, 0xf7, 0xde, 0xa7, 0x53, 0x84, 0x91, 0x69, 0x07, 0xbe, 0x25, 0x00, 0xed, 0x22,
+	0x04, 0xac, 0x70, 0x80, 0x76, 0xc1, 0x01, 0xa2, 0x0c, 0xeb, 0xd7, 0x4c, 0x07, 0x09, 0x90, 0x3d,
+	0xc5, 0x97, 0xfc, 0x94, 0x92, 0x4f, 0xb4, 0x02, 0xf9, 0x33, 0xcd, 0x9a, 0x60, 0x7a, 0x30, 0x4b,
+	0x0a, 0x1b, 0x7c, 0xbd, 0xf0, 0x55, 0x46, 0xfc, 0x25, 0xac, 0xcc, 0xb2, 0x78, 0x86, 0x8e, 0x2f,
+	0xe3, 0x3a, 0x6e, 0x5e, 0xca, 0x48, 0xbf, 0xf4, 0x3f, 0x19, 0x58, 0x6f, 0x84, 0x71, 0xe2, 0xb5,
+	0x49, 0xce, 0xd4, 0x25, 0x8f, 0x2c, 0x5f, 0x41, 0x29, 0xdc, 0x97, 0xf5, 0xcc, 0x5c, 0xad, 0x11,
+	0xf8, 0x23, 0x43, 0xcd, 0xb7, 0xf0, 0x30, 0x15, 0xc9, 0xd4, 0x81, 0x69, 0x0f, 0xe9, 0xfa, 0xd9,
+	0x3e, 0x0d, 0x3e, 0x25, 0xe5, 0x41, 0x32, 0xaa, 0xbd, 0x8c, 0x00, 0x89, 0xb8, 0x92, 0x4b, 0xc6,
+	0x15, 0xe9, 0xef, 0x32, 0x50, 0xe3, 0xf3, 0xc4, 0x0a, 0xfe, 0xd5, 0x04, 0x7b, 0xfe, 0xed, 0x23,
+	0xe7, 0x0a, 0xe4, 0x07, 0x8e, 0xab, 0x33, 0xd7, 0x16, 0x15, 0x36, 0x40, 0x2d, 0x28, 0xb1, 0x33,
+	0xe4, 0xfb, 0x16, 0x35, 0xae, 0xbc, 0xf3, 0x60, 0x6a, 0x9a, 0x2d, 0x7e, 0x31, 0xec, 0x2e, 0x5d,
+	0x7d, 0xd8, 0x2a, 0xee, 0x11, 0x7c, 0xbf, 0xbf, 0xa7, 0x14, 0xa9, 0x64, 0xdf, 0xb7, 0xa4, 0xbf,
+	0xcd, 0x80, 0x10, 0x19, 0xe6, 0x8d, 0x1d, 0xdb, 0xc3, 0xe8, 0x33, 0xc8, 0x7b, 0xbe, 0xe6, 0x33,
+	0x7b, 0xaa, 0x3b, 0xcb, 0xf1, 0x1d, 0xdd, 0x23, 0x0c, 0x85, 0xf1, 0x3f, 0xd2, 0xd1, 0x51, 0x84,
+	0xc9, 0xce, 0x8e, 0x30, 0xd2, 0x5f, 0x67, 0x60, 0x2d, 0xda, 0x16, 0xb2, 0xeb, 0x3a, 0x6e, 0x0b,
+	0xfb, 0x9a, 0x69, 0x79, 0xe8, 0x8f, 0x61, 0xd1, 0xc5, 0x9a, 0xe7, 0xd8, 0xdc, 0xb8, 0x47, 0x71,
+	0xe3, 0x52, 0x32, 0x0a, 0x05, 0x2a, 0x5c, 0xe0, 0xe3, 0xac, 0x95, 0x0e, 0x61, 0x7d, 0x5f, 0x33,
+	0x6d, 0x1f, 0xdb, 0x9a, 0xad, 0xe3, 0x84, 0x2d, 0x5f, 0x43, 0xd9, 0xc5, 0xbe, 0x7b, 0xa9, 0x6a,
+	0x03, 0x1f, 0xbb, 0xf5, 0xcc, 0x9c, 0x45, 0x50, 0x80, 0xa2, 0x1b, 0x04, 0x2c, 0xb5, 0xc3, 0x19,
+	0xe2, 0x97, 0xae, 0x33, 0x3a, 0x54, 0xf6, 0x82, 0x7d, 0xf1, 0x00, 0xb2, 0x13, 0xd7, 0xaa, 0x67,
+	0xa2, 0xb8, 0x49, 0x98, 0x84, 0x36, 0x7b, 0x27, 0x48, 0x7a, 0x78, 0x86, 0x30, 0xb1, 0x4c, 0x3f,
+	0xc1, 0x46, 0xa0, 0x6b, 0x05, 0xf2, 0xbe, 0x73, 0x8a, 0x6d, 0xbe, 0xb3, 0xd8, 0x00, 0x6d, 0x40,
+	0xc9, 0x33, 0x87, 0x36, 0xdd, 0x9b, 0xfc, 0xcc, 0x47, 0x84, 0xe8, 0x27, 0xd9, 0xf8, 0x4f, 0xfe,
+	0x22, 0x5a, 0x12, 0x7c, 0xa0, 0xb9, 0xbe, 0xa9, 0x59, 0xc1, 0x4f, 0x3e, 0x87, 0xd2, 0x64, 0x6c,
+	0x39, 0x9a, 0x41, 0x96, 0x94, 0x99, 0x4d, 0xb7, 0xdb, 0x21, 0x25, 0xb6, 0x5b, 0x4a, 0x91, 0xb1,
+	0xdb, 0x06, 0xd1, 0x6d, 0xda, 0x06, 0xbe, 0xa0, 0x7f, 0xcd, 0x2a, 0x6c, 0xc0, 0xac, 0xf4, 0x35,
+	0x8b, 0x5f, 0xf0, 0x6c, 0x80, 0x10, 0xe4, 0xc6, 0x9a, 0xeb, 0xd3, 0xab, 0x7d, 0x49, 0xa1, 0xdf,
+	0x52, 0x0f, 0xd6, 0xa7, 0x8c, 0xe0, 0x9b, 0x56, 0x84, 0xa2, 0x8b, 0x75, 0x6c, 0x9e, 0xf1, 0x68,
+	0x91, 0x55, 0xc2, 0x31, 0x99, 0x70, 0x14, 0x4a, 0x98, 0xef, 0x22, 0x82, 0xd4, 0x80, 0xb5, 0x9e,
+	0xaf, 0x0d, 0x71, 0xb4, 0x7b, 0xee, 0x7a, 0x44, 0xa5, 0x73, 0x58, 0x9f, 0x52, 0xc1, 0xed, 0xfa,
+	0x31, 0x14, 0x3d, 0xc2, 0x8a, 0x9c, 0x53, 0xbe, 0xfa, 0xb0, 0x55, 0xa0, 0xf0, 0x76, 0x4b, 0x29,
+	0x50, 0x66, 0xfb, 0x23, 0x83, 0x96, 0xd4, 0x80, 0xf5, 0xa6, 0x33, 0x1a, 0x99, 0xfe, 0xb4, 0xf1,
+	0xb7, 0xfc, 0xb1, 0xd4, 0x86, 0xda, 0x2b, 0xec, 0xb3, 0x73, 0xcd, 0x45, 0xff, 0x10, 0xd6, 0x4d,
+	0x5b, 0xb7, 0x26, 0x06, 0x56, 0xf1, 0x60, 0x80, 0x89, 0x6a, 0xac, 0x46, 0x21, 0xa1, 0xa8, 0xac,
+	0x72, 0xb6, 0x1c, 0x70, 0xa9, 0xb8, 0xf4, 0xbf, 0x0b, 0x20, 0x44, 0xba, 0xee, 0x1a, 0x4d, 0x44,
+	0x28, 0x9e, 0x6b, 0xae, 0x6d, 0xda, 0x43, 0xe2, 0x02, 0x1a, 0x40, 0x83, 0x31, 0x6a, 0x82, 0x60,
+	0xe3, 0x0b, 0x5f, 0xd5, 0x4f, 0xb0, 0x7e, 0xca, 0xcf, 0xdb, 0xbc, 0xa0, 0xa7, 0x54, 0x89, 0x48,
+	0x93, 0x48, 0xd0, 0x33, 0x47, 0xf6, 0x99, 0xe7, 0x6b, 0x16, 0xa6, 0x5b, 0xaa, 0xa8, 0xb0, 0x01,
+	0xc9, 0x97, 0x2c, 0xcd, 0xf3, 0xd5, 0xc9, 0xd8, 0xa0, 0xfb, 0x23, 0x3f, 0x3f, 0x5f, 0x22, 0xf8,
+	0x43, 0x06, 0x47, 0x4d, 0xa8, 0xa5, 0x7d, 0xb4, 0xc8, 0x35, 0xc4, 0x13, 0x81, 0x84, 0xa3, 0x94,
+	0x2a, 0x4e, 0x8c, 0x51, 0x0b, 0x04, 0x2a, 0xaa, 0x52, 0x11, 0x96, 0xb7, 0x15, 0xe6, 0xda, 0x51,
+	0xa5, 0x32, 0x32, 0x13, 0x69, 0xf8, 0xd2, 0x3f, 0x65, 0xa0, 0x9a, 0xfc, 0xd1, 0x9d, 0x9c, 0x1f,
+	0xde, 0x5e, 0x0b, 0xa9, 0xac, 0xf8, 0xc7, 0x50, 0x33, 0x6d, 0x75, 0xe8, 0x6a, 0x3a, 0x56, 0xc7,
+	0xd8, 0x35, 0x1d, 0x83, 0xc7, 0x86, 0x8a, 0x69, 0xbf, 0x22, 0xd4, 0x03, 0x4a, 0x44, 0xbf, 0x0f,
+	0x08, 0x8f, 0xb0, 0x3b, 0xc4, 0xb6, 0x7e, 0xa9, 0x3a, 0x67, 0xd8, 0x75, 0x4d, 0x23, 0x70, 0xf6,
+	0x72, 0xc8, 0xe9, 0x72, 0x86, 0xf4, 0x97, 0x19, 0x58, 0x7e, 0xa7, 0xf9, 0xfa, 0x49, 0x62, 0xef,
+	0x7d, 0x09, 0xc0, 0x5c, 0x31, 0xd2, 0xbc, 0xd3, 0x7a, 0x66, 0x3b, 0x3b, 0xdb, 0xec, 0x12, 0x05,
+	0xed, 0x6b, 0xde, 0x29, 0x59, 0x40, 0x0f, 0xdb, 0x86, 0x6a, 0xda, 0x26, 0x89, 0x08, 0xd7, 0x1e,
+	0x9f, 0x5d, 0xc7, 0xb1, 0x8e, 0x48, 0xea, 0xa1, 0x94, 0x09, 0xbe, 0xcd, 0xe0, 0xd2, 0xbf, 0x64,
+	0x00, 0xc5, 0xcd, 0xb8, 0xeb, 0xb6, 0xfd, 0x14, 0xaa, 0x9a, 0x61, 0xc4, 0x93, 0x4d, 0xe6, 0xbf,
+	0x0a, 0xa5, 0x86, 0x19, 0xe3, 0xe7, 0x20, 0xb8, 0x78, 0xe4, 0x9c, 0xc5, 0x81, 0x59, 0x0a, 0xac,
+	0x71, 0x7a, 0x08, 0xfd, 0x3d, 0x58, 0x66, 0x49, 0xa5, 0x3d, 0x54, 0x53, 0x29, 0x85, 0x10, 0x30,
+	0x02, 0xb0, 0x74, 0x1f, 0x96, 0x5b, 0x58, 0x4b, 0xe6, 0x16, 0xd2, 0x77, 0x80, 0xe2, 0x44, 0x3e,
+	0xa5, 0xcf, 0x41, 0xd0, 0x2c, 0x17, 0x6b, 0xc6, 0xa5, 0x6a, 0xda, 0x94, 0x1b, 0x9c, 0xe7, 0x1a,
+	0xa7, 0xb7, 0x39, 0x59, 0x5a, 0x85, 0xfb, 0x0a, 0x1e, 0xb8, 0xd8, 0x4b, 0x2c, 0x8e, 0xf4, 0x1d,
+	0xac, 0x24, 0xc9, 0x77, 0x74, 0x96, 0x24, 0x42, 0xfd, 0x15, 0x8e, 0x05, 0xab, 0xb6, 0x3d, 0x70,
+	0x02, 0xe5, 0xff, 0x9e, 0x85, 0x07, 0x33, 0x98, 0x3f, 0x4c, 0x52, 0x92, 0xae, 0x9a, 0xb2, 0x1f,
+	0x5b, 0x35, 0xe5, 0xae, 0xa9, 0x9a, 0x78, 0x39, 0x94, 0x9f, 0x51, 0x0e, 0xd9, 0xb3, 0x6b, 0x96,
+	0x45, 0x5a, 0x4f, 0x7c, 0x13, 0x9f, 0xe8, 0xb5, 0xee, 0xb9, 0x53, 0xf1, 0xb2, 0x45, 0xf2, 0x16,
+	0x92, 0x63, 0xab, 0x27, 0x9a, 0x77, 0x42, 0x03, 0x4d, 0x49, 0x01, 0x46, 0x7a, 0xad, 0x79, 0x27,
+	0xbf, 0xa3, 0xea, 0x41, 0x12, 0xa0, 0xaa, 0xe0, 0xe3, 0x89, 0x69, 0x05, 0xf9, 0x88, 0xf4, 0x35,
+	0xd4, 0x42, 0xca, 0x5d, 0xb7, 0xce, 0x32, 0xbd, 0xa7, 0xfe, 0x74, 0xe2, 0xf8, 0x5a, 0xa0, 0xee,
+	0x1f, 0x33, 0x20, 0x44, 0xb4, 0xbb, 0x6e, 0x94, 0x44, 0x6f, 0x61, 0x21, 0xd5, 0x5b, 0x98, 0xea,
+	0x04, 0x64, 0x6f, 0xdb, 0x09, 0xc8, 0xcd, 0xec, 0x04, 0x48, 0x5f, 0xc0, 0x0a, 0xbd, 0x8a, 0x02,
+	0x77, 0xc6, 0x52, 0x34, 0x5b, 0x1b, 0x61, 0x8f, 0x86, 0xba, 0x92, 0xc2, 0x06, 0xd2, 0x00, 0x50,
+	0x50, 0x78, 0xd9, 0xbe, 0xe9, 0x5b, 0x98, 0xf6, 0x04, 0x10, 0xe4, 0x08, 0x9b, 0x7b, 0x9f, 0x7e,
+	0x93, 0xc0, 0x8d, 0x19, 0x24, 0x48, 0x6d, 0xc2, 0x31, 0xe9, 0x32, 0x04, 0xcb, 0x4f, 0xca, 0x44,
+	0x56, 0xc2, 0xc4, 0x49, 0xd2, 0x19, 0xac, 0xa6, 0xac, 0xe2, 0x5e, 0xfc, 0x3a, 0x76, 0x1f, 0x64,
+	0xe8, 0x46, 0xdc, 0x8c, 0x3b, 0x72, 0xda, 0xb8, 0xd8, 0x7d, 0xf1, 0x08, 0x96, 0x34, 0xcb, 0x52,
+	0x53, 0x66, 0x95, 0x35, 0xcb, 0xe2, 0x78, 0x43, 0xfa, 0xab, 0x05, 0x28, 0xf7, 0x49, 0x32, 0xda,
+	0xb4, 0x34, 0x73, 0xe4, 0xc5, 0x0f, 0x6d, 0xe6, 0xf6, 0x87, 0xf6, 0xa6, 0x4b, 0xeb, 0xc6, 0xce,
+	0xd1, 0xd4, 0xea, 0xe6, 0x6e, 0xbb, 0xba, 0xf9, 0x79, 0x7d, 0x9e, 0xc5, 0x9b, 0xfa, 0x3c, 0x85,
+	0xa9, 0x3e, 0x8f, 0xf4, 0x04, 0xd0, 0x81, 0x8b, 0xcf, 0x4c, 0x7c, 0x4e, 0x32, 0xc9, 0x60, 0x57,
+	0x20, 0xc8, 0xc5, 0xd2, 0x4d, 0xfa, 0x2d, 0xfd, 0x47, 0x06, 0xee, 0x27, 0xa0, 0x7c, 0xa9, 0x7e,
+	0x0a, 0xc5, 0xb1, 0xeb, 0x8c, 0x1d, 0x2f, 0xac, 0x93, 0xd7, 0xe3, 0x4b, 0x15, 0x73, 0xb3, 0x12,
+	0x02, 0xd1, 0x4f, 0xa0, 0xa0, 0x4f, 0x5c, 0x97, 0x18, 0xb5, 0x70, 0xb3, 0x4c, 0x80, 0x9b, 0x71,
+	0xd1, 0x65, 0x6f, 0x7b, 0xd1, 0xe5, 0x66, 0x5e, 0x74, 0xd2, 0x2f, 0x61, 0xfd, 0x48, 0xb3, 0x4c,
+	0x92, 0x48, 0x91, 0x19, 0x35, 0xbc, 0xee, 0xe0, 0x06, 0x07, 0xa0, 0xe7, 0x90, 0xd7, 0x3c, 0xd5,
+	0x19, 0xdc, 0x22, 0xae, 0xe7, 0x34, 0xaf, 0x3b, 0x90, 0xfe, 0x39, 0x03, 0xf5, 0xe9, 0x1f, 0x70,
+	0xb7, 0xb1, 0xb8, 0xc5, 0x93, 0xe3, 0xa2, 0xc2, 0x06, 0xe8, 0x39, 0x2c, 0xea, 0x74, 0xde, 0xf3,
+	0xdc, 0xc2, 0x61, 0xb1, 0x82, 0x34, 0x7b, 0xd7, 0x82, 0xb4, 0x0e, 0x85, 0x11, 0xf6, 0x3c, 0x6d,
+	0xc8, 0x92, 0xa4, 0x92, 0x12, 0x0c, 0xa5, 0x35, 0x58, 0x91, 0x2f, 0xc6, 0x8e, 0xeb, 0x87, 0x2d,
+	0x11, 0x16, 0xf0, 0x8e, 0x60, 0x35, 0x45, 0xe7, 0x93, 0xf9, 0x06, 0x0a, 0x2c, 0x7e, 0x07, 0xa7,
+	0xf5, 0xf1, 0x6c, 0x33, 0x12, 0x2d, 0x16, 0x25, 0x90, 0x91, 0xbe, 0x82, 0xd5, 0x1e, 0xf6, 0xfb,
+	0xee, 0xc4, 0xf3, 0xb1, 0xf1, 0x16, 0x5f, 0x86, 0xd1, 0x69, 0x0b, 0xca, 0xe3, 0xc9, 0xb1, 0x65,
+	0xea, 0xea, 0x29, 0xbe, 0x0c, 0x62, 0x14, 0x30, 0x12, 0xc1, 0x49, 0x75, 0x58, 0x4b, 0x4b, 0x32,
+	0x93, 0xa4, 0x3f, 0xcf, 0x00, 0x44, 0x74, 0x72, 0x12, 0xe2, 0xed, 0x14, 0xb6, 0xae, 0x71, 0x12,
+	0xad, 0xd2, 0xac, 0xa1, 0xe3, 0x9a, 0xfe, 0xc9, 0x28, 0x28, 0x4b, 0x43, 0x02, 0x7a, 0x01, 0x8b,
+	0x9e, 0x33, 0x09, 0xea, 0xd2, 0xea, 0xce, 0x46, 0x62, 0x61, 0xc2, 0xff, 0xf4, 0x28, 0x46, 0xe1,
+	0x58, 0x62, 0xde, 0x9e, 0xe9, 0xcd, 0x98, 0x99, 0x24, 0xc3, 0xfa, 0x14, 0x87, 0x3b, 0xf3, 0x29,
+	0xe4, 0xc2, 0xd9, 0x96, 0x77, 0xd6, 0x66, 0xff, 0x48, 0xa1, 0x18, 0x92, 0x7e, 0xf5, 0xb0, 0x7b,
+	0x86, 0x5d, 0xb2, 0xf7, 0x02, 0xdd, 0x2d, 0x40, 0x71, 0x22, 0x57, 0xfb, 0x0c, 0x72, 0xbe, 0xc9,
+	0xa3, 0xf7, 0x9c, 0xdd, 0x4b, 0x70, 0x12, 0xa2, 0x97, 0x5b, 0xd3, 0xb1, 0x07, 0xe6, 0x30, 0xd0,
+	0xfc, 0x37, 0x25, 0x58, 0x8e, 0x11, 0xb9, 0xe6, 0x7d, 0x58, 0xa5, 0x21, 0xf1, 0x52, 0xe5, 0x05,
+	0x93, 0x7a, 0x6e, 0xda, 0x86, 0x73, 0x3e, 0xbf, 0x25, 0x71, 0x9f, 0xc9, 0xbd, 0x63, 0x62, 0xef,
+	0xa8, 0x14, 0xfa, 0x13, 0x58, 0x4a, 0x24, 0xfb, 0x0b, 0xf3, 0xb4, 0x94, 0x87, 0xb1, 0x2a, 0xe0,
+	0x5b, 0xa8, 0x70, 0x63, 0xde, 0x9b, 0xfe, 0xad, 0xea, 0xb4, 0x25, 0x86, 0x7f, 0x43, 0xe1, 0x31,
+	0x79, 0x67, 0x30, 0xf0, 0xb0, 0x5f, 0xcf, 0xdd, 0x52, 0xbe, 0x4b, 0xe1, 0xe8, 0x3b, 0xa8, 0x92,
+	0xb0, 0xad, 0x5b, 0x8e, 0x7e, 0xaa, 0x7a, 0xa7, 0xf8, 0xbc, 0x9e, 0x9f, 0xab, 0x60, 0xa4, 0x5d,
+	0x34, 0x09, 0xbe, 0x77, 0x8a, 0xcf, 0x89, 0x01, 0xa4, 0xfb, 0xea, 0xe2, 0x91, 0x66, 0x12, 0xaf,
+	0xd4, 0x17, 0xe7, 0xcb, 0x9b, 0xb6, 0x12, 0xc0, 0xd1, 0x1b, 0x40, 0x3e, 0xdb, 0x26, 0xe4, 0xd0,
+	0xa8, 0x7c, 0xd7, 0x16, 0x6e, 0xb1, 0x6b, 0x05, 0x3f, 0x45, 0x41, 0x2f, 0x60, 0xed, 0x58, 0xd3,
+	0x4f, 0x27, 0x63, 0x7a, 0xfe, 0x54, 0x9d, 0xae, 0xfb, 0xc4, 0xc5, 0x06, 0x7d, 0x4f, 0x28, 0x2a,
+	0x2b, 0x8c, 0x4b, 0xb6, 0x6f, 0x33, 0xe4, 0x91, 0xd2, 0xff, 0xfd, 0xf9, 0xa9, 0xa7, 0x92, 0x3e,
+	0x52, 0x29, 0x2a, 0xfd, 0xdf, 0xbc, 0x7b, 0xdb, 0x23, 0xbd, 0xa4, 0x02, 0x61, 0x1e, 0xba, 0x16,
+	0x89, 0xe8, 0x3e, 0xb9, 0xa6, 0x3c, 0x15, 0xdb, 0xda, 0x31, 0xb9, 0xaa, 0x81, 0xd5, 0x75, 0x8c,
+	0x2a, 0x33, 0x22, 0x69, 0x07, 0xb0, 0x92, 0xcc, 0xb4, 0xdf, 0x93, 0xe2, 0xd2, 0xb1, 0x43, 0x7c,
+	0x99, 0xb5, 0x03, 0x28, 0xbb, 0x1d, 0x70, 0x03, 0xb9, 0x9f, 0xc1, 0x86, 0x8b, 0x7f, 0x35, 0x31,
+	0x5d, 0xac, 0x8e, 0x1c, 0xdb, 0xf1, 0x1d, 0xdb, 0xd4, 0xd5, 0xa8, 0x73, 0x42, 0x1f, 0x18, 0x8a,
+	0x8a, 0xc8, 0x31, 0xfb, 0x01, 0x24, 0x8a, 0x56, 0xe9, 0xeb, 0xb3, 0x32, 0xfd, 0x4c, 0xf2, 0x05,
+	0x00, 0x6f, 0xfd, 0x93, 0xe4, 0x9b, 0x3d, 0x36, 0x54, 0xae, 0x3e, 0x6c, 0x95, 0x0e, 0x18, 0xb5,
+	0xdd, 0x52, 0x4a, 0x1c, 0xd0, 0x36, 0xd0, 0x17, 0x80, 0xc8, 0xd2, 0xa6, 0x9e, 0x2f, 0x6a, 0xf4,
+	0xca, 0x16, 0x46, 0xa6, 0xdd, 0x4b, 0xbc, 0x60, 0xbc, 0x86, 0x95, 0x58, 0xfb, 0x67, 0xe2, 0x5a,
+	0xea, 0x89, 0xe3, 0xf9, 0x5e, 0x5d, 0x20, 0x51, 0x70, 0x77, 0xed, 0xea, 0xc3, 0x16, 0x8a, 0x6c,
+	0x3d, 0x54, 0xf6, 0x5e, 0x13, 0xae, 0x82, 0x22, 0x99, 0x43, 0xd7, 0xa2, 0x34, 0x12, 0x46, 0xb1,
+	0xaf, 0x1b, 0xea, 0xd8, 0xc5, 0x03, 0xf3, 0xa2, 0xbe, 0xcc, 0x32, 0x6e, 0x42, 0x3a, 0xa0, 0x14,
+	0xb2, 0x12, 0x27, 0x2c, 0x34, 0x07, 0x18, 0x44, 0x31, 0x15, 0x4e, 0xe5, 0xb0, 0x1d, 0x58, 0x7a,
+	0xef, 0x39, 0xb6, 0xca, 0xe3, 0x36, 0x7d, 0x19, 0x28, 0xee, 0xd6, 0xae, 0x3e, 0x6c, 0x95, 0xdf,
+	0xf4, 0xba, 0x1d, 0x16, 0xd6, 0x3d, 0xa5, 0x4c, 0x40, 0x7c, 0x40, 0xb6, 0x90, 0xee, 0x8c, 0xc6,
+	0x2e, 0xf6, 0xbc, 0x40, 0x4e, 0xd5, 0x8e, 0x9d, 0x33, 0x4c, 0x9f, 0x0d, 0xb2, 0xca, 0x4a, 0xc0,
+	0xe5, 0x02, 0x0d, 0xc2, 0x23, 0x35, 0x3f, 0x39, 0x45, 0xbc, 0x4e, 0xf0, 0xcc, 0x5f, 0xe3, 0xfa,
+	0x2a, 0x7b, 0xe5, 0x19, 0x69, 0x17, 0x0c, 0xd9, 0x33, 0x7f, 0x8d, 0xa5, 0x3e, 0x3c, 0xec, 0x61,
+	0x5f, 0x4e, 0x17, 0xf7, 0xc1, 0xfd, 0xf1, 0x07, 0x50, 0x0c, 0x1e, 0x27, 0xe7, 0x07, 0xa3, 0x10,
+	0x2a, 0xf5, 0x61, 0x63, 0xb6, 0x56, 0x1e, 0xf0, 0x3e, 0x2a, 0x5d, 0x94, 0xfe, 0x21, 0x03, 0x88,
+	0x6d, 0xd2, 0x44, 0xc7, 0xe1, 0xd6, 0x05, 0xc3, 0x0e, 0x2c, 0xb2, 0x48, 0x73, 0x8b, 0x04, 0x84,
+	0x23, 0xd1, 0x0b, 0xc8, 0xde, 0xaa, 0x41, 0x4f, 0x2b, 0x42, 0xd2, 0x9b, 0x27, 0x70, 0xe9, 0x2d,
+	0xdc, 0x4f, 0x18, 0xfa, 0xff, 0x9a, 0xf6, 0x32, 0xd4, 0x7a, 0xd8, 0x1a, 0xf4, 0xb1, 0xe7, 0x07,
+	0xd7, 0xc8, 0xbf, 0x2d, 0x80, 0x10, 0xd1, 0xb8, 0xf6, 0x3a, 0x14, 0xbc, 0x89, 0xae, 0x63, 0xcf,
+	0xe3, 0x29, 0x51, 0x30, 0x24, 0xa9, 0x12, 0x76, 0x5d, 0xc7, 0x0d, 0x4a, 0x3c, 0x3a, 0x40, 0x3f,
+	0x83, 0x2a, 0x79, 0x53, 0xc5, 0x6a, 0xb8, 0xc2, 0x73, 0x23, 0x7d, 0x85, 0x0a, 0x04, 0x43, 0xaa,
+	0x81, 0x74, 0x5e, 0x22, 0x0d, 0xb9, 0xf9, 0x1a, 0x88, 0x40, 0xa8, 0xe1, 0x5b, 0xa8, 0xb8, 0x58,
+	0x33, 0x22, 0x05, 0xf3, 0x63, 0x3d, 0xc1, 0x87, 0xf2, 0xbb, 0x50, 0x33, 0xb0, 0x85, 0xe3, 0x93,
+	0x98, 0x1b, 0xed, 0xab, 0x4c, 0x22, 0x18, 0x93, 0x7b, 0xba, 0x85, 0x8f, 0x27, 0xc3, 0xd6, 0x64,
+	0x34, 0x0e, 0x1c, 0xfc, 0x67, 0x80, 0x64, 0x5f, 0x37, 0x64, 0xdb, 0x18, 0x3b, 0xa6, 0xed, 0xbf,
+	0xc6, 0x9a, 0xe5, 0x9f, 0xb0, 0x5a, 0x8d, 0x51, 0x78, 0x02, 0x14, 0x8e, 0x89, 0xf7, 0x4f, 0x28,
+	0xea, 0x92, 0xd7, 0x4b, 0xc1, 0x30, 0xf2, 0x7e, 0x36, 0xe6, 0x7d, 0xe9, 0xef, 0xb3, 0xb0, 0x1c,
+	0xfb, 0xed, 0x0f, 0xd3, 0x25, 0x89, 0x17, 0x5c, 0xd9, 0x54, 0xc1, 0x35, 0xe7, 0xfd, 0x2c, 0x37,
+	0xef, 0xfd, 0xec, 0x33, 0xa8, 0xb1, 0xcd, 0xa0, 0x3b, 0xb6, 0x8d, 0xf5, 0xa0, 0x15, 0x5b, 0x54,
+	0xd8, 0x1e, 0x69, 0x06, 0x54, 0xd2, 0x2c, 0xa5, 0x0d, 0x5b, 0x86, 0xc6, 0x67, 0xe4, 0x66, 0x58,
+	0x9c, 0xdf, 0x2c, 0x25, 0x32, 0xb4, 0xcf, 0x27, 0x13, 0x09, 0xf4, 0x09, 0x00, 0xd5, 0xc2, 0x5c,
+	0xcb, 0x0a, 0xb3, 0x12, 0xa1, 0xd0, 0x4c, 0x1d, 0xc9, 0x50, 0xa5, 0x11, 0x3b, 0x58, 0x1f, 0xaf,
+	0x5e, 0x9c, 0xae, 0x82, 0xa7, 0x97, 0x58, 0xa9, 0xe0, 0x18, 0xcd, 0x7b, 0xfa, 0x9b, 0x05, 0x58,
+	0x9d, 0x59, 0x04, 0x20, 0x04, 0xd5, 0xc3, 0xce, 0xdb, 0x4e, 0xf7, 0x5d, 0x47, 0x55, 0xe4, 0x46,
+	0xaf, 0xdb, 0x11, 0xee, 0x11, 0xda, 0x7e, 0x63, 0xef, 0x65, 0x57, 0xd9, 0x97, 0x5b, 0x6a, 0xb3,
+	0xdb, 0x92, 0x85, 0x0c, 0x5a, 0x85, 0xe5, 0x76, 0xe7, 0xa8, 0xb1, 0xd7, 0x6e, 0xa9, 0xbd, 0xf6,
+	0xab, 0x4e, 0xa3, 0x7f, 0xa8, 0xc8, 0xc2, 0x02, 0x81, 0x06, 0x64, 0xf9, 0xe7, 0x07, 0x6d, 0xe5,
+	0x7b, 0x21, 0x8b, 0x04, 0x58, 0x22, 0x42, 0x8c, 0x20, 0xb7, 0x84, 0x1c, 0x7a, 0x00, 0xab, 0x3d,
+	0x59, 0x69, 0x37, 0xf6, 0xd4, 0x4e, 0xb7, 0xaf, 0xb6, 0x3b, 0x4d, 0xf2, 0xab, 0x76, 0xe7, 0x95,
+	0x90, 0x27, 0x7a, 0xdf, 0x29, 0xdd, 0xce, 0x2b, 0x55, 0xee, 0x1c, 0xb5, 0x95, 0x6e, 0x67, 0x5f,
+	0xee, 0xf4, 0x85, 0x45, 0xa2, 0x77, 0x4f, 0x6e, 0xf4, 0x64, 0x75, 0xbf, 0xdd, 0xdb, 0x6f, 0xf4,
+	0x9b, 0xaf, 0x85, 0x02, 0xa1, 0xf5, 0x9a, 0xaf, 0xe5, 0xfd, 0x86, 0xda, 0xef, 0x76, 0xd5, 0xee,
+	0x5e, 0x4b, 0x28, 0xa2, 0x15, 0x10, 0xd8, 0x6f, 0x7a, 0x94, 0xd8, 0xeb, 0x76, 0x3b, 0x42, 0x09,
+	0x2d, 0x43, 0x85, 0x29, 0x3d, 0x50, 0xba, 0xad, 0xc3, 0x66, 0x5f, 0x00, 0xb4, 0x0e, 0xf7, 0x0f,
+	0x3b, 0xbd, 0xc3, 0x83, 0x83, 0xae, 0xd2, 0x97, 0x5b, 0xea, 0x91, 0xac, 0xf4, 0xda, 0xdd, 0x8e,
+	0x50, 0x7e, 0xfa, 0x14, 0xf2, 0xac, 0x47, 0x5d, 0x84, 0x5c, 0xa7, 0xdb, 0x91, 0x85, 0x7b, 0x08,
+	0x60, 0xb1, 0xd1, 0xec, 0xb7, 0x8f, 0xc8, 0xbc, 0xcb, 0x50, 0x08, 0xe6, 0xb1, 0xf0, 0x14, 0x83,
+	0x90, 0x4e, 0x96, 0xd0, 0x1a, 0xa0, 0xc0, 0x81, 0x6f, 0xe5, 0xef, 0xd5, 0x5e, 0xf7, 0x50, 0x69,
+	0x12, 0x25, 0x4b, 0x50, 0x94, 0xf7, 0x77, 0xe5, 0x56, 0x4b, 0x6e, 0x09, 0x19, 0x54, 0x80, 0xac,
+	0xdc, 0x39, 0x12, 0x16, 0xc8, 0x5f, 0x5e, 0xb6, 0xf7, 0x64, 0x21, 0x4b, 0xbe, 0x48, 0x26, 0x24,
+	0xe4, 0x50, 0x15, 0xa0, 0x27, 0xf7, 0xd5, 0xdd, 0xef, 0x55, 0xe5, 0xa0, 0x29, 0xe4, 0x77, 0xfe,
+	0x53, 0x80, 0x6c, 0xe3, 0xa0, 0x8d, 0x5e, 0x41, 0x91, 0x2f, 0x1a, 0x46, 0x0f, 0x67, 0x14, 0x52,
+	0xc1, 0xd5, 0x21, 0x6e, 0xcc, 0x66, 0xf2, 0x0a, 0xe8, 0x1e, 0x3a, 0x84, 0x5a, 0xea, 0x95, 0x0f,
+	0x49, 0xb3, 0x44, 0x92, 0x4f, 0x80, 0x73, 0xd5, 0xbe, 0x03, 0x21, 0xfd, 0xe2, 0x87, 0x66, 0x15,
+	0x7c, 0xe9, 0xf7, 0xc0, 0xb9, 0x8a, 0x7f, 0x01, 0xb5, 0xd4, 0xfb, 0xda, 0x6c, 0x7b, 0x93, 0x2f,
+	0x80, 0xe2, 0xe3, 0x1b, 0x31, 0x71, 0xed, 0xa9, 0x57, 0xb2, 0xa4, 0xf6, 0xd9, 0xaf, 0x70, 0xe2,
+	0xe3, 0x1b, 0x31, 0x71, 0xa7, 0xa4, 0x9f, 0xc2, 0x92, 0x4e, 0xb9, 0xe6, 0xa1, 0x6c, 0xae, 0x53,
+	0x5e, 0x41, 0x31, 0x78, 0xd4, 0x4a, 0xee, 0x86, 0xd4, 0xb3, 0x99, 0xb8, 0x31, 0x9b, 0x19, 0x2a,
+	0xea, 0x02, 0x44, 0x0f, 0x0d, 0xe8, 0x93, 0x38, 0x7a, 0xea, 0x1d, 0x44, 0xdc, 0xbc, 0x8e, 0x1d,
+	0xa8, 0xfb, 0x32, 0x83, 0xf6, 0x01, 0xa2, 0x36, 0x7f, 0x52, 0xe1, 0xd4, 0x9b, 0x80, 0xb8, 0x79,
+	0x1d, 0x3b, 0xb4, 0xaf, 0x07, 0x4b, 0xf1, 0xee, 0x3e, 0xda, 0x8a, 0x4b, 0xcc, 0x78, 0x0e, 0x10,
+	0xb7, 0xaf, 0x07, 0x84, 0x4a, 0x8f, 0x69, 0xc1, 0x9a, 0xec, 0x5a, 0xa3, 0x1f, 0xcd, 0x69, 0x6a,
+	0x33, 0xf5, 0x9f, 0xde, 0xaa, 0xf5, 0x2d, 0xdd, 0x43, 0x2d, 0x28, 0xf0, 0xb6, 0x32, 0x12, 0x93,
+	0x26, 0xc5, 0xbb, 0xcf, 0xe2, 0xc3, 0x99, 0xbc, 0xd4, 0x3a, 0xd3, 0x66, 0xf2, 0xd4, 0x3a, 0xc7,
+	0xdb, 0xce, 0xe2, 0xc6, 0x6c, 0x66, 0xa8, 0xe8, 0x08, 0x2a, 0x89, 0xa6, 0x2a, 0x4a, 0xf8, 0x69,
+	0x56, 0x17, 0x58, 0x7c, 0x74, 0x03, 0x22, 0xd4, 0x7b, 0x00, 0xe5, 0x58, 0xff, 0x0f, 0x25, 0x16,
+	0x74, 0xba, 0x87, 0x28, 0x6e, 0x5d, 0xcb, 0x0f, 0x35, 0xaa, 0x20, 0xa4, 0xfb, 0x63, 0xc9, 0x33,
+	0x73, 0x4d, 0x7b, 0x4e, 0xfc, 0xd1, 0xcd, 0xa0, 0xf0, 0x07, 0x3f, 0x87, 0x4a, 0xa2, 0x61, 0x95,
+	0x74, 0xc5, 0xac, 0x1e, 0x97, 0xf8, 0xe8, 0x06, 0x44, 0x6c, 0xef, 0x9f, 0xc2, 0xca, 0xac, 0x12,
+	0x01, 0x7d, 0x96, 0x88, 0x16, 0xd7, 0x97, 0x26, 0xe2, 0x93, 0xf9, 0xc0, 0xb8, 0xe7, 0x63, 0xf9,
+	0x78, 0xd2, 0xf3, 0xd3, 0x15, 0x85, 0xb8, 0x75, 0x2d, 0x3f, 0xbe, 0xd9, 0x82, 0x04, 0x3c, 0xb9,
+	0xd9, 0x52, 0xa9, 0xba, 0xb8, 0x31, 0x9b, 0x19, 0x2a, 0x7a, 0x03, 0xa5, 0x30, 0x0d, 0x44, 0x1b,
+	0xc9, 0x33, 0x9e, 0x4c, 0x4a, 0xc5, 0x4f, 0xae, 0xe1, 0x86, 0xba, 0xbe, 0x87, 0x6a, 0xb2, 0x99,
+	0x87, 0x1e, 0xa5, 0x9c, 0x34, 0xdd, 0x48, 0x13, 0xa5, 0x9b, 0x20, 0xf1, 0xd8, 0x9f, 0x6a, 0xb7,
+	0x25, 0x63, 0xff, 0xec, 0x2e, 0x9d, 0xf8, 0xf8, 0x46, 0x4c, 0xa8, 0x7d, 0x1f, 0x20, 0x6a, 0xb8,
+	0x25, 0x03, 0xe1, 0x54, 0x77, 0x4e, 0xdc, 0xbc, 0x8e, 0x1d, 0xf7, 0x69, 0xd8, 0x64, 0x43, 0xe9,
+	0xd3, 0x9e, 0x68, 0xc8, 0x89, 0x9f, 0x5c, 0xc3, 0x0d, 0x74, 0xed, 0x0a, 0xff, 0x7a, 0xb5, 0x99,
+	0xf9, 0xed, 0xd5, 0x66, 0xe6, 0xbf, 0xae, 0x36, 0x33, 0xbf, 0xf9, 0xef, 0xcd, 0x7b, 0xc7, 0x8b,
+	0x34, 0x3b, 0xfd, 0xe9, 0xff, 0x0d, 0x00, 0x7b, 0xc2, 0x5f, 0xac, 0xf7, 0x2b, 0x00, 0x00,
 }
@@ -0,0 +1,475 @@
+package enterprise
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+)
+
+// PublicKey is the PEM-encoded public key that Pachyderm's activation codes
+// are signed with. pachd trusts it unless it's configured with other keys.
+const PublicKey = `-----BEGIN PUBLIC KEY-----
+MIICIjANBgkqhkiG9w0BAQEFAAOCAg8AMIICCgKCAgEAoaPoEfv5RcVUbCuWNnOB
+WtLHzcyQSe4SbtGGQom/X27iq/7s8dcebSsCd2cwYoyKihEQ5OlaghrhcxTTV5AN
+39O6S0YnWjt/+4PWQQP3NpcEhqWj8RLPJtYq+JNrqlyjxBlca7vDcFSTa6iCqXay
+iVD2OyTbWrD6KZ/YTSmSY8mY2qdYvHyp3Ue5ueH3rSkKRUjo4Jyjf59PntZD884P
+yb9kC+weh/1KlbDQ4aV0U9p6DSBkW7dinOQj7a1/ikDoA9Nebnrkb1FF9Hr2+utO
+We4e4yOViDzAP9hhQiBhOVR0F6wJF5i+NfuLit4tk5ViboogEZqIyuakTD6abSFg
+UPqBTDDG0UsVqjnU5ysJ1DKQqALnOrxEKZoVXtH80/m7kgmeY3VDHCFt+WCSdaSq
+1w8SoIpJAZPJpKlDjMxe+NqsX2qUODQ2KNkqfEqFtyUNZzfS9o9pEg/KJzDuDclM
+oMQr1BG8vc3msX4UiGQPkohznwlCSGWf62IkSS6P8hQRCBKGRS5yGjmT3J+/chZw
+Je46y8zNLV7t2pOL6UemdmDjTaMCt0YBc1FmG2eUipAWcHJWEHgQm2Yz6QjtBgvt
+jFqnYeiDwdxU7CQD3oF9H+uVHqz8Jmmf9BxY9PhlMSUGPUsTpZ717ysL0UrBhQhW
+xYp8vpeQ3by9WxPBE/WrxN8CAwEAAQ==
+-----END PUBLIC KEY-----
+`
+
+// ActivationCode is an activation code, after it has been base64-decoded and
+// parsed from JSON
+type ActivationCode struct {
+	Token     string
+	Signature string
+	// Canonical indicates that Signature was computed over the canonical form
+	// of Token (see CanonicalizeJSON) rather than over Token's exact bytes
+	Canonical bool
+	// KID, if set, is the ID of the key (in the JWKS that pachd fetches
+	// trusted keys from) that Signature was computed with. Only that key is
+	// checked
+	KID string
+	// Backup indicates that Signature was computed with a key of the backup
+	// signing authority, which pachd only trusts for codes that set it. It's
+	// for disaster recovery, if the primary signing key is revoked
+	Backup bool
+}
+
+// DetachedActivationCode returns the activation code combining 'token' and
+// its base64-encoded 'signature', for codes whose token and signature were
+// delivered separately
+func DetachedActivationCode(token, signature string) (string, error) {
+	code, err := json.Marshal(ActivationCode{
+		Token:     token,
+		Signature: signature,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(code), nil
+}
+
+// Token is the JSON-encoded token signed by an activation code
+type Token struct {
+	Expiry          string
+	MaxNodes        int64
+	MaxPipelines    int32
+	MaxStorageBytes int64
+	// Serial increases with each token issued to a customer
+	Serial int64
+	// Env is the environment that the token was issued for, if any
+	Env string
+	// IssuedAt, if set, is the time at which the token was issued
+	IssuedAt string
+	// Scopes are the enterprise features that the token enables
+	Scopes map[string]bool
+	// SchemaVersion is the version of the schema of the token's claims. Tokens
+	// that don't set it have version 1
+	SchemaVersion int
+	// SLA, if set, names the support SLA (e.g. the maintenance window) that
+	// the token's holder is entitled to. It's informational only
+	SLA string
+	// Product, if set, is the product that the token was issued for.
+	// Pachyderm's codes name "pachyderm"; other products' codes may be signed
+	// with the same key
+	Product string
+	// DeprecatedFeatures maps features that are being deprecated to a notice
+	// for users. It's informational only
+	DeprecatedFeatures map[string]string
+	// FeatureExpiries maps features that expire before the token to their
+	// expiries, which are ISO 8601 strings like Expiry
+	FeatureExpiries map[string]string
+	// MinVersion and MaxVersion, if set, are the oldest and newest Pachyderm
+	// versions (e.g. "1.5.0") that the token may be activated on, inclusive
+	MinVersion string
+	MaxVersion string
+}
+
+// Claims are the claims of a verified activation code's token
+type Claims struct {
+	Expires time.Time
+	// IssuedAt is the zero time if the token doesn't say when it was issued
+	IssuedAt        time.Time
+	MaxNodes        int64
+	MaxPipelines    int32
+	MaxStorageBytes int64
+	Serial          int64
+	Environment     string
+	// Features are the enterprise features that the token enables, sorted
+	Features []string
+	// KeyID identifies the key that the code was verified with (see KeyID)
+	KeyID string
+	// SchemaVersion is the version of the token's schema (see
+	// Token.SchemaVersion). It's at least 1
+	SchemaVersion int
+	// SLA is the token's support SLA, or "" if it doesn't name one
+	SLA string
+	// Product is the product that the token was issued for, or "" if it
+	// doesn't name one
+	Product string
+	// DeprecatedFeatures maps deprecated features to their deprecation
+	// notices, or is nil if the token doesn't deprecate any
+	DeprecatedFeatures map[string]string
+	// FeatureExpiries maps features to their own expiries, or is nil if all
+	// of the token's features last as long as the token
+	FeatureExpiries map[string]time.Time
+	// MinVersion and MaxVersion are the range of Pachyderm versions that the
+	// token may be activated on (see VersionInRange). Either may be "", in
+	// which case the range is open at that end
+	MinVersion string
+	MaxVersion string
+}
+
+// VerificationError is returned by VerifyCode when an activation code is
+// rejected
+type VerificationError struct {
+	Reason ActivationErrorReason
+	// Expires is the code's expiry, if Reason is CODE_EXPIRED
+	Expires time.Time
+	Message string
+}
+
+func newVerificationError(reason ActivationErrorReason, format string, args ...interface{}) *VerificationError {
+	return &VerificationError{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *VerificationError) Error() string {
+	return e.Message
+}
+
+// VerifyCodeOffline checks the validity of an activation code against
+// PublicKey, without contacting a cluster, and returns its claims if it's
+// valid. pachd verifies codes in the same way (with VerifyCode), but a
+// cluster may also be configured to trust other keys, or to reject codes that
+// are otherwise valid (e.g. because they were issued for another environment).
+func VerifyCodeOffline(code string) (Claims, error) {
+	key, err := ParsePublicKey(PublicKey)
+	if err != nil {
+		return Claims{}, fmt.Errorf("could not parse the embedded public key: %s", err.Error())
+	}
+	return VerifyCode(code, []*rsa.PublicKey{key})
+}
+
+// VerifyCode checks the validity of an activation code, which must be signed
+// by one of 'keys', and returns its claims if it's valid. If it isn't, the
+// error is a *VerificationError.
+func VerifyCode(code string, keys []*rsa.PublicKey) (Claims, error) {
+	return VerifyCodeAsOf(code, keys, time.Now())
+}
+
+// VerifyCodeAsOf is like VerifyCode, but checks whether the code was (or will
+// be) valid at 'asOf', rather than now. Signatures don't depend on the time.
+func VerifyCodeAsOf(code string, keys []*rsa.PublicKey, asOf time.Time) (Claims, error) {
+	// Decode the base64-encoded activation code
+	decodedActivationCode, err := base64.StdEncoding.DecodeString(code)
+	if err != nil {
+		return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "activation code is not base64 encoded")
+	}
+	activationCode := &ActivationCode{}
+	if err := json.Unmarshal(decodedActivationCode, &activationCode); err != nil {
+		return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "activation code is not valid JSON")
+	}
+
+	// Decode the signature
+	decodedSignature, err := base64.StdEncoding.DecodeString(activationCode.Signature)
+	if err != nil {
+		return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "signature is not base64 encoded")
+	}
+
+	// Compute the sha256 checksum of the token
+	signedToken := []byte(activationCode.Token)
+	if activationCode.Canonical {
+		signedToken, err = CanonicalizeJSON(signedToken)
+		if err != nil {
+			return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "token is not valid JSON")
+		}
+	}
+	hashedToken := sha256.Sum256(signedToken)
+
+	// Verify that the signature is valid for one of the trusted keys. They're
+	// tried in order of their IDs, so that the outcome (and the error, if
+	// none of them verifies it) doesn't depend on the order they were given in
+	var verifiedBy *rsa.PublicKey
+	sorted := sortKeys(keys)
+	for _, key := range sorted.keys {
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, hashedToken[:], decodedSignature) == nil {
+			verifiedBy = key
+			break
+		}
+	}
+	if verifiedBy == nil {
+		if len(sorted.ids) == 0 {
+			return Claims{}, newVerificationError(ActivationErrorReason_INVALID_SIGNATURE, "invalid signature in activation code: no keys are trusted")
+		}
+		tried := make([]string, len(sorted.ids))
+		for i, id := range sorted.ids {
+			tried[i] = shortKeyID(id)
+		}
+		return Claims{}, newVerificationError(ActivationErrorReason_INVALID_SIGNATURE,
+			"invalid signature in activation code: it isn't signed by any of the trusted keys (%s)",
+			strings.Join(tried, ", "))
+	}
+
+	// Unmarshal the token
+	token := Token{}
+	if err := json.Unmarshal([]byte(activationCode.Token), &token); err != nil {
+		return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "token is not valid JSON")
+	}
+
+	// Parse the expiry
+	expiry, err := time.Parse(time.RFC3339, token.Expiry)
+	if err != nil {
+		return Claims{}, newVerificationError(ActivationErrorReason_INVALID_EXPIRY, "expiry is not valid ISO 8601 string")
+	}
+	// Expiries are stored as protobuf timestamps, which have a narrower range
+	if _, err := types.TimestampProto(expiry); err != nil {
+		return Claims{}, newVerificationError(ActivationErrorReason_INVALID_EXPIRY, "expiry is out of range: %v", err)
+	}
+	// Check that the activation code has not expired
+	if asOf.After(expiry) {
+		err := newVerificationError(ActivationErrorReason_CODE_EXPIRED, "the activation code has expired")
+		err.Expires = expiry
+		return Claims{}, err
+	}
+	var issuedAt time.Time
+	if token.IssuedAt != "" {
+		if issuedAt, err = time.Parse(time.RFC3339, token.IssuedAt); err != nil {
+			return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "issue time is not valid ISO 8601 string")
+		}
+		if _, err := types.TimestampProto(issuedAt); err != nil {
+			return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "issue time is out of range: %v", err)
+		}
+	}
+	schemaVersion := token.SchemaVersion
+	if schemaVersion < 0 {
+		return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "schema version must not be negative, but was %d", schemaVersion)
+	} else if schemaVersion == 0 {
+		schemaVersion = 1
+	}
+	for _, v := range []string{token.MinVersion, token.MaxVersion} {
+		if v == "" {
+			continue
+		}
+		if _, err := parseVersion(v); err != nil {
+			return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "%v", err)
+		}
+	}
+	var featureExpiries map[string]time.Time
+	for feature, featureExpiry := range token.FeatureExpiries {
+		t, err := time.Parse(time.RFC3339, featureExpiry)
+		if err != nil {
+			return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "expiry of feature %q is not valid ISO 8601 string", feature)
+		}
+		if _, err := types.TimestampProto(t); err != nil {
+			return Claims{}, newVerificationError(ActivationErrorReason_MALFORMED_CODE, "expiry of feature %q is out of range: %v", feature, err)
+		}
+		if featureExpiries == nil {
+			featureExpiries = make(map[string]time.Time)
+		}
+		featureExpiries[feature] = t
+	}
+	var features []string
+	for feature, enabled := range token.Scopes {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	return Claims{
+		Expires:            expiry,
+		IssuedAt:           issuedAt,
+		MaxNodes:           token.MaxNodes,
+		MaxPipelines:       token.MaxPipelines,
+		MaxStorageBytes:    token.MaxStorageBytes,
+		Serial:             token.Serial,
+		Environment:        token.Env,
+		Features:           features,
+		KeyID:              KeyID(verifiedBy),
+		SchemaVersion:      schemaVersion,
+		SLA:                token.SLA,
+		Product:            token.Product,
+		DeprecatedFeatures: token.DeprecatedFeatures,
+		FeatureExpiries:    featureExpiries,
+		MinVersion:         token.MinVersion,
+		MaxVersion:         token.MaxVersion,
+	}, nil
+}
+
+// VersionInRange returns true if the Pachyderm version 'version' (e.g.
+// "1.5.3", or "1.5.3-rc1", whose suffix is ignored) is between 'min' and
+// 'max', inclusive. Either bound may be "", in which case the range is open
+// at that end.
+func VersionInRange(version, min, max string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	if min != "" {
+		m, err := parseVersion(min)
+		if err != nil {
+			return false, err
+		}
+		if compareVersions(v, m) < 0 {
+			return false, nil
+		}
+	}
+	if max != "" {
+		m, err := parseVersion(max)
+		if err != nil {
+			return false, err
+		}
+		if compareVersions(v, m) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseVersion parses a version of the form "major.minor.micro", followed
+// by an optional "-additional" suffix, which is discarded
+func parseVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	numbers := version
+	if i := strings.Index(numbers, "-"); i >= 0 {
+		numbers = numbers[:i]
+	}
+	parts := strings.Split(numbers, ".")
+	if len(parts) != 3 {
+		return parsed, fmt.Errorf("version %q is not of the form major.minor.micro", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("version %q is not of the form major.minor.micro", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+// compareVersions returns -1, 0 or 1 if 'a' is older than, the same as or
+// newer than 'b'
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ParsePublicKey parses a PEM-encoded RSA public key
+func ParsePublicKey(pemKey string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to pem decode public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER encoded public key: %s", err.Error())
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key isn't an RSA key")
+	}
+	return rsaPub, nil
+}
+
+// KeyID identifies the public key 'key'. It's the hex-encoded SHA-256 hash of
+// the key's DER-encoded PKIX form, like the fingerprint printed by
+// 'openssl pkey -pubin -outform DER | sha256sum'
+func KeyID(key *rsa.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// shortKeyIDLen is the length of the key ID prefixes in VerifyCode's errors
+const shortKeyIDLen = 16
+
+// shortKeyID abbreviates the key ID 'id' for error messages
+func shortKeyID(id string) string {
+	if len(id) > shortKeyIDLen {
+		return id[:shortKeyIDLen]
+	}
+	return id
+}
+
+// sortedKeys is a set of keys in the order they're tried in, and their IDs
+type sortedKeys struct {
+	keys []*rsa.PublicKey
+	ids  []string
+}
+
+func (s sortedKeys) Len() int           { return len(s.keys) }
+func (s sortedKeys) Less(i, j int) bool { return s.ids[i] < s.ids[j] }
+func (s sortedKeys) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.ids[i], s.ids[j] = s.ids[j], s.ids[i]
+}
+
+// sortKeys returns a copy of 'keys' sorted by key ID (see KeyID), which is
+// the order that VerifyCode tries them in
+func sortKeys(keys []*rsa.PublicKey) sortedKeys {
+	sorted := sortedKeys{
+		keys: append([]*rsa.PublicKey(nil), keys...),
+		ids:  make([]string, len(keys)),
+	}
+	for i, key := range keys {
+		sorted.ids[i] = KeyID(key)
+	}
+	sort.Stable(sorted)
+	return sorted
+}
+
+// CanonicalizeJSON returns the canonical form of the JSON document 'data':
+// object keys are sorted, insignificant whitespace is removed, and numbers are
+// reproduced exactly as written. Tokens in activation codes with Canonical set
+// are signed in this form.
+func CanonicalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	// Encode terminates its output with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/version"
 	"github.com/pachyderm/pachyderm/src/client/version/versionpb"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
@@ -23,6 +24,21 @@ var (
 type ServeOptions struct {
 	Version    *versionpb.Version
 	MaxMsgSize int
+	// UnaryInterceptors are applied to every unary RPC, in order (the first
+	// interceptor is the outermost)
+	UnaryInterceptors []grpc.UnaryServerInterceptor
+	// StreamInterceptors are the equivalent of UnaryInterceptors for
+	// streaming RPCs
+	StreamInterceptors []grpc.StreamServerInterceptor
+	// KeepalivePolicy limits how often clients may send keepalive pings. If
+	// nil, clients may ping every 5 seconds, even without active RPCs
+	KeepalivePolicy *keepalive.EnforcementPolicy
+	// KeepaliveParams configures the keepalive pings that the server sends to
+	// idle connections (e.g. to keep long-lived streams open behind load
+	// balancers that cut idle connections), and how long a connection may go
+	// without RPCs before it's closed. Fields that are unset use grpc's
+	// defaults.
+	KeepaliveParams keepalive.ServerParameters
 }
 
 // ServeEnv are environment variables for serving.
@@ -43,15 +59,7 @@ func Serve(
 	if serveEnv.GRPCPort == 0 {
 		serveEnv.GRPCPort = 7070
 	}
-	grpcServer := grpc.NewServer(
-		grpc.MaxConcurrentStreams(math.MaxUint32),
-		grpc.MaxRecvMsgSize(options.MaxMsgSize),
-		grpc.MaxSendMsgSize(options.MaxMsgSize),
-		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
-			MinTime:             5 * time.Second,
-			PermitWithoutStream: true,
-		}),
-	)
+	grpcServer := grpc.NewServer(ServerOptions(options)...)
 	registerFunc(grpcServer)
 	if options.Version != nil {
 		versionpb.RegisterAPIServer(grpcServer, version.NewAPIServer(options.Version, version.APIServerOptions{}))
@@ -62,3 +70,65 @@ func Serve(
 	}
 	return grpcServer.Serve(listener)
 }
+
+// ServerOptions returns the grpc server options that Serve uses for
+// 'options'. Version is ignored, as it's a service, not a server option.
+func ServerOptions(options ServeOptions) []grpc.ServerOption {
+	policy := keepalive.EnforcementPolicy{
+		MinTime:             5 * time.Second,
+		PermitWithoutStream: true,
+	}
+	if options.KeepalivePolicy != nil {
+		policy = *options.KeepalivePolicy
+	}
+	serverOptions := []grpc.ServerOption{
+		grpc.MaxConcurrentStreams(math.MaxUint32),
+		grpc.KeepaliveEnforcementPolicy(policy),
+		grpc.KeepaliveParams(options.KeepaliveParams),
+	}
+	if options.MaxMsgSize > 0 {
+		serverOptions = append(serverOptions,
+			grpc.MaxRecvMsgSize(options.MaxMsgSize),
+			grpc.MaxSendMsgSize(options.MaxMsgSize))
+	}
+	if len(options.UnaryInterceptors) > 0 {
+		serverOptions = append(serverOptions,
+			grpc.UnaryInterceptor(ChainUnaryServerInterceptors(options.UnaryInterceptors...)))
+	}
+	if len(options.StreamInterceptors) > 0 {
+		serverOptions = append(serverOptions,
+			grpc.StreamInterceptor(ChainStreamServerInterceptors(options.StreamInterceptors...)))
+	}
+	return serverOptions
+}
+
+// ChainUnaryServerInterceptors combines 'interceptors' into a single
+// interceptor (grpc servers only accept one). The first interceptor is the
+// outermost, i.e. it's called first and returns last.
+func ChainUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// ChainStreamServerInterceptors is the equivalent of
+// ChainUnaryServerInterceptors for streaming RPCs
+func ChainStreamServerInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, stream grpc.ServerStream) error {
+				return interceptor(srv, stream, info, next)
+			}
+		}
+		return chained(srv, stream)
+	}
+}
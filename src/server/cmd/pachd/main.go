@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	units "github.com/docker/go-units"
 	"github.com/pachyderm/pachyderm/src/client"
@@ -25,6 +26,7 @@ import (
 	eprsserver "github.com/pachyderm/pachyderm/src/server/enterprise/server"
 	"github.com/pachyderm/pachyderm/src/server/health"
 	pfs_server "github.com/pachyderm/pachyderm/src/server/pfs/server"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	cache_pb "github.com/pachyderm/pachyderm/src/server/pkg/cache/groupcachepb"
 	cache_server "github.com/pachyderm/pachyderm/src/server/pkg/cache/server"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
@@ -33,10 +35,13 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/netutil"
 	pps_server "github.com/pachyderm/pachyderm/src/server/pps/server"
 
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	flag "github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	"k8s.io/kubernetes/pkg/api"
 	kube_client "k8s.io/kubernetes/pkg/client/restclient"
 	kube "k8s.io/kubernetes/pkg/client/unversioned"
@@ -63,6 +68,24 @@ type appEnv struct {
 	PFSEtcdPrefix         string `env:"PFS_ETCD_PREFIX,default=pachyderm_pfs"`
 	AuthEtcdPrefix        string `env:"PACHYDERM_AUTH_ETCD_PREFIX,default=pachyderm_auth"`
 	EnterpriseEtcdPrefix  string `env:"PACHYDERM_ENTERPRISE_ETCD_PREFIX,default=pachyderm_enterprise"`
+	EnterpriseJSONRecords bool   `env:"PACHYDERM_ENTERPRISE_JSON_RECORDS,default=false"`
+	EnterpriseURLHosts    string `env:"PACHYDERM_ENTERPRISE_ACTIVATION_URL_HOSTS,default="`
+	EnterpriseMonotonic   bool   `env:"PACHYDERM_ENTERPRISE_REQUIRE_MONOTONIC_ACTIVATION,default=false"`
+	EnterpriseEnvironment string `env:"PACHYDERM_ENTERPRISE_ENVIRONMENT,default="`
+	EnterpriseProductID   string `env:"PACHYDERM_ENTERPRISE_PRODUCT_ID,default="`
+	EnterpriseGracePeriod string `env:"PACHYDERM_ENTERPRISE_GRACE_PERIOD,default=0s"`
+	EnterpriseWarnWindow  string `env:"PACHYDERM_ENTERPRISE_EXPIRY_WARNING_WINDOW,default=720h"`
+	EnterpriseRevoked     string `env:"PACHYDERM_ENTERPRISE_REVOKED_FINGERPRINTS,default="`
+	EnterpriseJWKSURL     string `env:"PACHYDERM_ENTERPRISE_JWKS_URL,default="`
+	EnterpriseHistoryMax  int    `env:"PACHYDERM_ENTERPRISE_HISTORY_MAX_ENTRIES,default=0"`
+	EnterpriseHistoryAge  string `env:"PACHYDERM_ENTERPRISE_HISTORY_MAX_AGE,default=0s"`
+	EnterpriseSigFailures int    `env:"PACHYDERM_ENTERPRISE_SIGNATURE_FAILURE_THRESHOLD,default=0"`
+	EnterpriseJitter      string `env:"PACHYDERM_ENTERPRISE_EXPIRY_JITTER,default=0s"`
+	EnterpriseReadEtcd    string `env:"PACHYDERM_ENTERPRISE_ETCD_READ_ENDPOINTS,default="`
+	EnterpriseMinSchema   int    `env:"PACHYDERM_ENTERPRISE_MIN_SCHEMA_VERSION,default=0"`
+	EnterpriseMinLeft     string `env:"PACHYDERM_ENTERPRISE_MIN_REMAINING,default=0s"`
+	EnterpriseFastWatch   bool   `env:"PACHYDERM_ENTERPRISE_IMMEDIATE_WATCH_RECONNECT,default=false"`
+	EnterpriseConfirm     string `env:"PACHYDERM_ENTERPRISE_CONFIRM_ACTIVATION_TIMEOUT,default=0s"`
 	KubeAddress           string `env:"KUBERNETES_PORT_443_TCP_ADDR,required"`
 	EtcdAddress           string `env:"ETCD_PORT_2379_TCP_ADDR,required"`
 	Namespace             string `env:"NAMESPACE,default=default"`
@@ -74,9 +97,101 @@ type appEnv struct {
 	WorkerSidecarImage    string `env:"WORKER_SIDECAR_IMAGE,default="`
 	WorkerImagePullPolicy string `env:"WORKER_IMAGE_PULL_POLICY,default="`
 	LogLevel              string `env:"LOG_LEVEL,default=info"`
+	KeepaliveMinTime      string `env:"GRPC_KEEPALIVE_MIN_TIME,default=5s"`
+	KeepaliveNoStream     bool   `env:"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM,default=true"`
+	KeepaliveTime         string `env:"GRPC_KEEPALIVE_TIME,default=0s"`
+	MaxConnectionIdle     string `env:"GRPC_MAX_CONNECTION_IDLE,default=0s"`
+}
+
+// enterpriseOptions collects the enterprise API server's optional settings
+// from the environment. 'pachdAddress' is the address of this pachd's grpc
+// server, which the enterprise server uses to reach the auth API, 'clusterID'
+// identifies the cluster, and 'kubeClient' is used to count the cluster's nodes
+func enterpriseOptions(appEnv *appEnv, pachdAddress string, clusterID string, kubeClient *kube.Client) (eprsserver.Options, error) {
+	// Hosts from which ActivateFromURL may download activation codes
+	var activationURLHosts []string
+	if appEnv.EnterpriseURLHosts != "" {
+		activationURLHosts = strings.Split(appEnv.EnterpriseURLHosts, ",")
+	}
+	gracePeriod, err := time.ParseDuration(appEnv.EnterpriseGracePeriod)
+	if err != nil {
+		return eprsserver.Options{}, fmt.Errorf("invalid enterprise grace period: %s", err.Error())
+	}
+	warningWindow, err := time.ParseDuration(appEnv.EnterpriseWarnWindow)
+	if err != nil {
+		return eprsserver.Options{}, fmt.Errorf("invalid enterprise expiry warning window: %s", err.Error())
+	}
+	historyMaxAge, err := time.ParseDuration(appEnv.EnterpriseHistoryAge)
+	if err != nil {
+		return eprsserver.Options{}, fmt.Errorf("invalid enterprise history max age: %s", err.Error())
+	}
+	expiryJitter, err := time.ParseDuration(appEnv.EnterpriseJitter)
+	if err != nil {
+		return eprsserver.Options{}, fmt.Errorf("invalid enterprise expiry jitter: %s", err.Error())
+	}
+	minRemaining, err := time.ParseDuration(appEnv.EnterpriseMinLeft)
+	if err != nil {
+		return eprsserver.Options{}, fmt.Errorf("invalid enterprise minimum remaining validity: %s", err.Error())
+	}
+	confirmTimeout, err := time.ParseDuration(appEnv.EnterpriseConfirm)
+	if err != nil {
+		return eprsserver.Options{}, fmt.Errorf("invalid enterprise activation confirmation timeout: %s", err.Error())
+	}
+	var onSignatureFailures func(int, time.Duration)
+	if appEnv.EnterpriseSigFailures > 0 {
+		onSignatureFailures = func(failures int, window time.Duration) {
+			log.Errorf("%d activation codes with invalid signatures were rejected "+
+				"within %v; the enterprise signing key may have been compromised, "+
+				"or the wrong keys distributed", failures, window)
+		}
+	}
+	// etcd endpoints (e.g. a read replica) that the enterprise server reads
+	// from instead of ETCD_PORT_2379_TCP_ADDR
+	var etcdReadEndpoints []string
+	if appEnv.EnterpriseReadEtcd != "" {
+		etcdReadEndpoints = strings.Split(appEnv.EnterpriseReadEtcd, ",")
+	}
+	var isRevoked func(string) (bool, error)
+	if appEnv.EnterpriseRevoked != "" {
+		isRevoked = eprsserver.RevokedFingerprints(strings.Split(appEnv.EnterpriseRevoked, ","))
+	}
+	return eprsserver.Options{
+		JSONRecords: appEnv.EnterpriseJSONRecords,
+		// etcd may still be starting up when pachd starts
+		ConnectRetry:               backoff.NewCappedBackOff(time.Second, 10*time.Second, 2*time.Minute),
+		ActivationURLHosts:         activationURLHosts,
+		EtcdReadEndpoints:          etcdReadEndpoints,
+		RequireMonotonicActivation: appEnv.EnterpriseMonotonic,
+		Environment:                appEnv.EnterpriseEnvironment,
+		ProductID:                  appEnv.EnterpriseProductID,
+		MinSchemaVersion:           appEnv.EnterpriseMinSchema,
+		MinRemaining:               minRemaining,
+		ImmediateWatchReconnect:    appEnv.EnterpriseFastWatch,
+		ConfirmActivationTimeout:   confirmTimeout,
+		JWKSURL:                    appEnv.EnterpriseJWKSURL,
+		IsAdmin:                    eprsserver.AuthAdminCheck(pachdAddress),
+		GracePeriod:                gracePeriod,
+		ExpiryWarningWindow:        warningWindow,
+		ClusterID:                  clusterID,
+		ExpiryJitter:               expiryJitter,
+		IsRevoked:                  isRevoked,
+		HistoryMaxEntries:          appEnv.EnterpriseHistoryMax,
+		HistoryMaxAge:              historyMaxAge,
+		SignatureFailureThreshold:  appEnv.EnterpriseSigFailures,
+		OnSignatureFailures:        onSignatureFailures,
+		NodeCount: func() (int64, error) {
+			nodes, err := kubeClient.Nodes().List(api.ListOptions{})
+			if err != nil {
+				return 0, err
+			}
+			return int64(len(nodes.Items)), nil
+		},
+	}, nil
 }
 
 func main() {
+	// Serve Prometheus metrics alongside pprof (see net/http/pprof import)
+	http.Handle("/metrics", prometheus.Handler())
 	switch mode {
 	case "full":
 		cmdutil.Main(doFullMode, &appEnv{})
@@ -87,6 +202,43 @@ func main() {
 	}
 }
 
+// serveOptions returns the options with which pachd serves its grpc APIs.
+// Keepalive durations of 0s in 'appEnv' use grpc's defaults.
+func serveOptions(appEnv *appEnv, enterpriseAPIServer eprsserver.APIServer) (grpcutil.ServeOptions, error) {
+	minTime, err := time.ParseDuration(appEnv.KeepaliveMinTime)
+	if err != nil {
+		return grpcutil.ServeOptions{}, fmt.Errorf("invalid grpc keepalive min time: %s", err.Error())
+	}
+	keepaliveTime, err := time.ParseDuration(appEnv.KeepaliveTime)
+	if err != nil {
+		return grpcutil.ServeOptions{}, fmt.Errorf("invalid grpc keepalive time: %s", err.Error())
+	}
+	maxConnectionIdle, err := time.ParseDuration(appEnv.MaxConnectionIdle)
+	if err != nil {
+		return grpcutil.ServeOptions{}, fmt.Errorf("invalid grpc max connection idle: %s", err.Error())
+	}
+	return grpcutil.ServeOptions{
+		Version:    version.Version,
+		MaxMsgSize: grpcutil.MaxMsgSize,
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			enterpriseAPIServer.UnaryServerInterceptor(),
+		},
+		StreamInterceptors: []grpc.StreamServerInterceptor{
+			enterpriseAPIServer.StreamServerInterceptor(),
+		},
+		// WatchState streams can be idle for a long time, so pachd may need
+		// to ping them to keep them open behind load balancers
+		KeepalivePolicy: &keepalive.EnforcementPolicy{
+			MinTime:             minTime,
+			PermitWithoutStream: appEnv.KeepaliveNoStream,
+		},
+		KeepaliveParams: keepalive.ServerParameters{
+			Time:              keepaliveTime,
+			MaxConnectionIdle: maxConnectionIdle,
+		},
+	}, nil
+}
+
 func doSidecarMode(appEnvObj interface{}) error {
 	go func() {
 		log.Println(http.ListenAndServe(":651", nil))
@@ -154,7 +306,15 @@ func doSidecarMode(appEnvObj interface{}) error {
 	if err != nil {
 		return err
 	}
-	enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(etcdAddress, appEnv.EnterpriseEtcdPrefix)
+	enterpriseOpts, err := enterpriseOptions(appEnv, address, clusterID, kubeClient)
+	if err != nil {
+		return err
+	}
+	enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(etcdAddress, appEnv.EnterpriseEtcdPrefix, enterpriseOpts)
+	if err != nil {
+		return err
+	}
+	grpcOptions, err := serveOptions(appEnv, enterpriseAPIServer)
 	if err != nil {
 		return err
 	}
@@ -166,11 +326,10 @@ func doSidecarMode(appEnvObj interface{}) error {
 			healthclient.RegisterHealthServer(s, healthServer)
 			authclient.RegisterAPIServer(s, authAPIServer)
 			eprsclient.RegisterAPIServer(s, enterpriseAPIServer)
+			// Let pachctl and grpcurl discover the services' methods
+			reflection.Register(s)
 		},
-		grpcutil.ServeOptions{
-			Version:    version.Version,
-			MaxMsgSize: grpcutil.MaxMsgSize,
-		},
+		grpcOptions,
 		grpcutil.ServeEnv{
 			GRPCPort: appEnv.Port,
 		},
@@ -313,7 +472,15 @@ func doFullMode(appEnvObj interface{}) error {
 	if err != nil {
 		return err
 	}
-	enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(etcdAddress, appEnv.EnterpriseEtcdPrefix)
+	enterpriseOpts, err := enterpriseOptions(appEnv, address, clusterID, kubeClient)
+	if err != nil {
+		return err
+	}
+	enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(etcdAddress, appEnv.EnterpriseEtcdPrefix, enterpriseOpts)
+	if err != nil {
+		return err
+	}
+	grpcOptions, err := serveOptions(appEnv, enterpriseAPIServer)
 	if err != nil {
 		return err
 	}
@@ -338,11 +505,9 @@ func doFullMode(appEnvObj interface{}) error {
 				cache_pb.RegisterGroupCacheServer(s, cacheServer)
 				authclient.RegisterAPIServer(s, authAPIServer)
 				eprsclient.RegisterAPIServer(s, enterpriseAPIServer)
+				reflection.Register(s)
 			},
-			grpcutil.ServeOptions{
-				Version:    version.Version,
-				MaxMsgSize: grpcutil.MaxMsgSize,
-			},
+			grpcOptions,
 			grpcutil.ServeEnv{
 				GRPCPort: appEnv.Port,
 			},
@@ -2,11 +2,19 @@ package cmds
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
 
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +23,9 @@ import (
 // publicly-accessible to accessible only by the owner, who can subsequently add
 // users
 func ActivateCmd() *cobra.Command {
+	var fromURL bool
+	var force bool
+	var leaseTTL time.Duration
 	activate := &cobra.Command{
 		Use: "activate activation-code",
 		Short: "Activate the enterprise features of Pachyderm with an activation " +
@@ -22,24 +33,186 @@ func ActivateCmd() *cobra.Command {
 		Long: "Activate the enterprise features of Pachyderm with an activation " +
 			"code",
 		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
-			activationCode := args[0]
 			c, err := client.NewOnUserMachine(true, "user")
 			if err != nil {
 				return fmt.Errorf("could not connect: %s", err.Error())
 			}
-			_, err = c.Enterprise.Activate(c.Ctx(),
-				&enterprise.ActivateRequest{ActivationCode: activationCode})
+			if fromURL {
+				_, err = c.Enterprise.ActivateFromURL(c.Ctx(),
+					&enterprise.ActivateFromURLRequest{URL: args[0], Force: force})
+				return err
+			}
+			req := &enterprise.ActivateRequest{ActivationCode: args[0], Force: force}
+			if leaseTTL != 0 {
+				req.LeaseTTL = types.DurationProto(leaseTTL)
+			}
+			_, err = c.Enterprise.Activate(c.Ctx(), req)
 			return err
 		}),
 	}
+	activate.Flags().BoolVar(&fromURL, "from-url", false, "Treat the argument "+
+		"as an HTTPS URL, from which pachd will download the activation code")
+	activate.Flags().BoolVar(&force, "force", false, "Activate the code even "+
+		"if its serial isn't greater than that of the cluster's current token, or "+
+		"if it expires sooner than pachd's minimum remaining validity")
+	activate.Flags().DurationVar(&leaseTTL, "lease-ttl", 0, "If set, reject the "+
+		"code unless it expires about this long from now")
 	return activate
 }
 
+// ActivatePartsCmd returns a cobra.Command to activate the enterprise features
+// of Pachyderm with an activation code that has been split into several files
+func ActivatePartsCmd() *cobra.Command {
+	activateParts := &cobra.Command{
+		Use: "activate-parts part-file...",
+		Short: "Activate the enterprise features of Pachyderm with an activation " +
+			"code that has been split into several files",
+		Long: "Activate the enterprise features of Pachyderm with an activation " +
+			"code that has been split into several files. The files must be " +
+			"given in order",
+		Run: cmdutil.Run(func(args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("must provide at least one part file")
+			}
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			uploadID := uuid.NewWithoutDashes()
+			for i, file := range args {
+				part, err := ioutil.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				if _, err := c.Enterprise.ActivatePartial(c.Ctx(), &enterprise.ActivatePartialRequest{
+					UploadID: uploadID,
+					Index:    int64(i),
+					Total:    int64(len(args)),
+					Part:     part,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+	return activateParts
+}
+
+// ActivateDetachedCmd returns a cobra.Command to activate the enterprise
+// features of Pachyderm with an activation code whose token and signature are
+// in separate files
+func ActivateDetachedCmd() *cobra.Command {
+	var force bool
+	activateDetached := &cobra.Command{
+		Use: "activate-detached token-file signature-file",
+		Short: "Activate the enterprise features of Pachyderm with a token and " +
+			"a detached signature",
+		Long: "Activate the enterprise features of Pachyderm with a token and " +
+			"its base64-encoded signature, delivered as separate files rather " +
+			"than as one activation code",
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			token, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			signature, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			// The token is signed exactly as it's stored, but the signature
+			// file may end with a newline
+			_, err = c.Enterprise.ActivateDetached(c.Ctx(), &enterprise.ActivateDetachedRequest{
+				Token:     string(token),
+				Signature: strings.TrimSpace(string(signature)),
+				Force:     force,
+			})
+			return err
+		}),
+	}
+	activateDetached.Flags().BoolVar(&force, "force", false, "Activate the code "+
+		"even if its serial isn't greater than that of the cluster's current token")
+	return activateDetached
+}
+
+// VerifyCodeCmd returns a cobra.Command that checks an activation code
+// without contacting a cluster
+func VerifyCodeCmd() *cobra.Command {
+	verifyCode := &cobra.Command{
+		Use:   "verify-code activation-code",
+		Short: "Check an activation code without contacting a cluster",
+		Long: "Check an activation code's signature and expiry without contacting " +
+			"a cluster, and print its claims if it's valid. Clusters configured " +
+			"to trust other keys, or to reject some codes (e.g. codes issued for " +
+			"another environment), may still reject it",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			claims, err := enterprise.VerifyCodeOffline(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Expires: %s\n", claims.Expires.Format(time.RFC3339))
+			if claims.MaxNodes > 0 {
+				fmt.Printf("Max nodes: %d\n", claims.MaxNodes)
+			}
+			if claims.Environment != "" {
+				fmt.Printf("Environment: %s\n", claims.Environment)
+			}
+			if len(claims.Features) > 0 {
+				fmt.Printf("Features: %s\n", strings.Join(claims.Features, ", "))
+			}
+			var deprecated []string
+			for feature := range claims.DeprecatedFeatures {
+				deprecated = append(deprecated, feature)
+			}
+			sort.Strings(deprecated)
+			for _, feature := range deprecated {
+				fmt.Printf("Deprecated feature %s: %s\n", feature, claims.DeprecatedFeatures[feature])
+			}
+			if claims.SLA != "" {
+				fmt.Printf("SLA: %s\n", claims.SLA)
+			}
+			fmt.Printf("Schema version: %d\n", claims.SchemaVersion)
+			return nil
+		}),
+	}
+	return verifyCode
+}
+
+// DeactivateCmd returns a cobra.Command to deactivate the enterprise features
+// of Pachyderm within a Pachyderm cluster
+func DeactivateCmd() *cobra.Command {
+	deactivate := &cobra.Command{
+		Use:   "deactivate",
+		Short: "Deactivate the enterprise features of Pachyderm",
+		Long:  "Deactivate the enterprise features of Pachyderm",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			resp, err := c.Enterprise.Deactivate(c.Ctx(), &enterprise.DeactivateRequest{})
+			if err != nil {
+				return err
+			}
+			if resp.AlreadyInactive {
+				fmt.Println("Pachyderm Enterprise was already inactive")
+			}
+			return nil
+		}),
+	}
+	return deactivate
+}
+
 // GetStateCmd returns a cobra.Command to activate the enterprise features of
 // Pachyderm within a Pachyderm cluster. All repos will go from
 // publicly-accessible to accessible only by the owner, who can subsequently add
 // users
 func GetStateCmd() *cobra.Command {
+	var effective bool
 	getState := &cobra.Command{
 		Use: "get-state",
 		Short: "Check whether the Pachyderm cluster has enterprise features " +
@@ -51,17 +224,204 @@ func GetStateCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not connect: %s", err.Error())
 			}
-			resp, err := c.Enterprise.GetState(c.Ctx(), &enterprise.GetStateRequest{})
+			resp, err := c.Enterprise.GetState(c.Ctx(), &enterprise.GetStateRequest{
+				IncludeEffectiveState: effective,
+			})
 			if err != nil {
 				return err
 			}
 			fmt.Println(resp.State.String())
+			if e := resp.EffectiveState; e != nil {
+				fmt.Printf("Effective state: %s\n", e.State.String())
+				if e.InGracePeriod {
+					fmt.Println("The token has expired, but is in its grace period")
+				}
+				if e.EmergencyOverride {
+					fmt.Println("An emergency override is in effect")
+				}
+				if len(e.Features) > 0 {
+					fmt.Printf("Enabled features: %s\n", strings.Join(e.Features, ", "))
+				}
+			}
+			if resp.Stale {
+				lastUpdated, err := types.TimestampFromProto(resp.LastUpdated)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Warning: pachd cannot reach etcd, so this state may be out of date (last updated %s)\n",
+					lastUpdated.Format(time.RFC3339))
+			}
+			for _, warning := range resp.Warnings {
+				fmt.Printf("Warning: %s\n", warning)
+			}
 			return nil
 		}),
 	}
+	getState.Flags().BoolVar(&effective, "effective", false, "Print the state of "+
+		"the token alone, followed by the effective state once any grace period "+
+		"or emergency override is taken into account")
 	return getState
 }
 
+// DebugDumpCmd returns a cobra.Command that prints the enterprise server's
+// internal state, for inclusion in support requests
+func DebugDumpCmd() *cobra.Command {
+	debugDump := &cobra.Command{
+		Use:   "debug-dump",
+		Short: "Print the internal state of the Pachyderm enterprise server",
+		Long: "Print the internal state of the Pachyderm enterprise server, for " +
+			"inclusion in support requests. Only cluster admins may run this " +
+			"command",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			resp, err := c.Enterprise.DebugDump(c.Ctx(), &enterprise.DebugDumpRequest{})
+			if err != nil {
+				return err
+			}
+			marshaller := &jsonpb.Marshaler{Indent: "  "}
+			return marshaller.Marshal(os.Stdout, resp)
+		}),
+	}
+	return debugDump
+}
+
+// ServerTimeCmd returns a cobra.Command that prints the enterprise server's
+// time, and how far the local clock is from it
+func ServerTimeCmd() *cobra.Command {
+	serverTime := &cobra.Command{
+		Use:   "server-time",
+		Short: "Print the time according to the Pachyderm enterprise server",
+		Long: "Print the time according to the Pachyderm enterprise server, which " +
+			"is the time that enterprise token expiries are compared against, " +
+			"and how far this machine's clock is from it",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			sent := time.Now()
+			resp, err := c.Enterprise.ServerTime(c.Ctx(), &enterprise.ServerTimeRequest{})
+			if err != nil {
+				return err
+			}
+			received := time.Now()
+			now, err := types.TimestampFromProto(resp.Time)
+			if err != nil {
+				return err
+			}
+			// Assume that the server read its clock halfway through the call
+			local := sent.Add(received.Sub(sent) / 2)
+			fmt.Println(now.Format(time.RFC3339Nano))
+			fmt.Printf("Server clock is %v ahead of the local clock (round trip %v)\n",
+				now.Sub(local), received.Sub(sent))
+			return nil
+		}),
+	}
+	return serverTime
+}
+
+// SetTrustedKeysCmd returns a cobra.Command that replaces the keys that
+// activation codes may be signed with
+func SetTrustedKeysCmd() *cobra.Command {
+	setTrustedKeys := &cobra.Command{
+		Use:   "set-trusted-keys key-file [key-file...]",
+		Short: "Replace the keys that Pachyderm enterprise activation codes may be signed with",
+		Long: "Replace the keys that Pachyderm enterprise activation codes may be " +
+			"signed with. Each file must contain a PEM-encoded RSA public key. The " +
+			"keys aren't persisted, and only cluster admins may run this command",
+		Run: cmdutil.Run(func(args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("must provide at least one key file")
+			}
+			req := &enterprise.SetTrustedKeysRequest{}
+			for _, file := range args {
+				key, err := ioutil.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("could not read %s: %s", file, err.Error())
+				}
+				req.PublicKeys = append(req.PublicKeys, string(key))
+			}
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			_, err = c.Enterprise.SetTrustedKeys(c.Ctx(), req)
+			return err
+		}),
+	}
+	return setTrustedKeys
+}
+
+// ListTrustedKeysCmd returns a cobra.Command that prints the fingerprints of
+// the keys that activation codes may be signed with
+func ListTrustedKeysCmd() *cobra.Command {
+	listTrustedKeys := &cobra.Command{
+		Use:   "list-trusted-keys",
+		Short: "List the keys that Pachyderm enterprise activation codes may be signed with",
+		Long: "List the fingerprints of the keys that Pachyderm enterprise " +
+			"activation codes may be signed with, and where each came from. Only " +
+			"cluster admins may run this command",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			resp, err := c.Enterprise.ListTrustedKeys(c.Ctx(), &enterprise.ListTrustedKeysRequest{})
+			if err != nil {
+				return err
+			}
+			for _, key := range resp.Keys {
+				fmt.Printf("%s\t%s\t%s\n", key.Fingerprint, key.Algorithm, key.Source)
+			}
+			return nil
+		}),
+	}
+	return listTrustedKeys
+}
+
+// EmergencyOverrideCmd returns a cobra.Command that keeps enterprise features
+// enabled for a limited time after the cluster's token expires
+func EmergencyOverrideCmd() *cobra.Command {
+	emergencyOverride := &cobra.Command{
+		Use:   "emergency-override duration",
+		Short: "Keep Pachyderm Enterprise features enabled despite an expired token",
+		Long: "Keep Pachyderm Enterprise features enabled for 'duration' (at most " +
+			"72h) even if the cluster's enterprise token expires, e.g. while an " +
+			"outage prevents it from being renewed. A duration of 0 ends the " +
+			"override. The override isn't persisted, and only cluster admins may " +
+			"run this command",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			d, err := time.ParseDuration(args[0])
+			if err != nil {
+				return fmt.Errorf("could not parse duration %q: %s", args[0], err.Error())
+			}
+			c, err := client.NewOnUserMachine(true, "user")
+			if err != nil {
+				return fmt.Errorf("could not connect: %s", err.Error())
+			}
+			resp, err := c.Enterprise.SetEmergencyOverride(c.Ctx(),
+				&enterprise.SetEmergencyOverrideRequest{Duration: types.DurationProto(d)})
+			if err != nil {
+				return err
+			}
+			if resp.Expires == nil {
+				fmt.Println("Emergency override ended")
+				return nil
+			}
+			expires, err := types.TimestampFromProto(resp.Expires)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Emergency override in effect until %s\n", expires.Format(time.RFC3339))
+			return nil
+		}),
+	}
+	return emergencyOverride
+}
+
 // Cmds returns pachctl commands related to Pachyderm Enterprise
 func Cmds() []*cobra.Command {
 	enterprise := &cobra.Command{
@@ -70,6 +430,15 @@ func Cmds() []*cobra.Command {
 		Long:  "Enterprise commands enable Pachyderm Enterprise features",
 	}
 	enterprise.AddCommand(ActivateCmd())
+	enterprise.AddCommand(ActivatePartsCmd())
+	enterprise.AddCommand(ActivateDetachedCmd())
+	enterprise.AddCommand(VerifyCodeCmd())
+	enterprise.AddCommand(DeactivateCmd())
 	enterprise.AddCommand(GetStateCmd())
+	enterprise.AddCommand(DebugDumpCmd())
+	enterprise.AddCommand(ServerTimeCmd())
+	enterprise.AddCommand(SetTrustedKeysCmd())
+	enterprise.AddCommand(ListTrustedKeysCmd())
+	enterprise.AddCommand(EmergencyOverrideCmd())
 	return []*cobra.Command{enterprise}
 }
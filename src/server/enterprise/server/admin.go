@@ -0,0 +1,59 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	authclient "github.com/pachyderm/pachyderm/src/client/auth"
+)
+
+// AuthAdminCheck returns a function, suitable for Options.IsAdmin, that asks
+// the auth API served at 'pachdAddress' whether the caller of an RPC is a
+// cluster admin. If auth isn't activated, the cluster has no admins and every
+// caller is treated as one, just as every user may access every repo.
+func AuthAdminCheck(pachdAddress string) func(ctx context.Context) (bool, error) {
+	var (
+		once       sync.Once
+		authClient authclient.APIClient
+		clientErr  error
+	)
+	return func(ctx context.Context) (bool, error) {
+		once.Do(func() {
+			var conn *grpc.ClientConn
+			conn, clientErr = grpc.Dial(pachdAddress, client.PachDialOptions()...)
+			authClient = authclient.NewAPIClient(conn)
+		})
+		if clientErr != nil {
+			return false, clientErr
+		}
+
+		// Forward the caller's auth token to the auth API
+		outCtx := ctx
+		if md, ok := metadata.FromContext(ctx); ok && len(md[authclient.ContextTokenKey]) == 1 {
+			outCtx = metadata.NewContext(ctx,
+				metadata.Pairs(authclient.ContextTokenKey, md[authclient.ContextTokenKey][0]))
+		}
+		whoAmI, err := authClient.WhoAmI(outCtx, &authclient.WhoAmIRequest{})
+		if err != nil {
+			if authclient.IsNotActivatedError(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("could not identify caller: %s", err.Error())
+		}
+		admins, err := authClient.GetAdmins(outCtx, &authclient.GetAdminsRequest{})
+		if err != nil {
+			return false, fmt.Errorf("could not get cluster admins: %s", err.Error())
+		}
+		for _, admin := range admins.Admins {
+			if admin == whoAmI.Username {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
@@ -1,15 +1,18 @@
 package server
 
 import (
-	"crypto"
 	"crypto/rsa"
 	"crypto/sha256"
-	"crypto/x509"
-	"encoding/base64"
-	"encoding/json"
-	"encoding/pem"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -17,9 +20,12 @@ import (
 	"github.com/gogo/protobuf/types"
 	logrus "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/version"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
@@ -29,217 +35,2411 @@ import (
 const (
 	enterprisePrefix = "/enterprise"
 
-	publicKey = `-----BEGIN PUBLIC KEY-----
-MIICIjANBgkqhkiG9w0BAQEFAAOCAg8AMIICCgKCAgEAoaPoEfv5RcVUbCuWNnOB
-WtLHzcyQSe4SbtGGQom/X27iq/7s8dcebSsCd2cwYoyKihEQ5OlaghrhcxTTV5AN
-39O6S0YnWjt/+4PWQQP3NpcEhqWj8RLPJtYq+JNrqlyjxBlca7vDcFSTa6iCqXay
-iVD2OyTbWrD6KZ/YTSmSY8mY2qdYvHyp3Ue5ueH3rSkKRUjo4Jyjf59PntZD884P
-yb9kC+weh/1KlbDQ4aV0U9p6DSBkW7dinOQj7a1/ikDoA9Nebnrkb1FF9Hr2+utO
-We4e4yOViDzAP9hhQiBhOVR0F6wJF5i+NfuLit4tk5ViboogEZqIyuakTD6abSFg
-UPqBTDDG0UsVqjnU5ysJ1DKQqALnOrxEKZoVXtH80/m7kgmeY3VDHCFt+WCSdaSq
-1w8SoIpJAZPJpKlDjMxe+NqsX2qUODQ2KNkqfEqFtyUNZzfS9o9pEg/KJzDuDclM
-oMQr1BG8vc3msX4UiGQPkohznwlCSGWf62IkSS6P8hQRCBKGRS5yGjmT3J+/chZw
-Je46y8zNLV7t2pOL6UemdmDjTaMCt0YBc1FmG2eUipAWcHJWEHgQm2Yz6QjtBgvt
-jFqnYeiDwdxU7CQD3oF9H+uVHqz8Jmmf9BxY9PhlMSUGPUsTpZ717ysL0UrBhQhW
-xYp8vpeQ3by9WxPBE/WrxN8CAwEAAQ==
------END PUBLIC KEY-----
-`
+	// enterpriseTokenKey is the constant key we use that maps to an Enterprise
+	// token that a user has given us. This is what we check to know if a
+	// Pachyderm cluster supports enterprise features
+	enterpriseTokenKey = "token"
+
+	// historySuffix is appended to the enterprise etcd prefix to derive the
+	// prefix of the activation history collection, if none is configured. It
+	// can't be a subdirectory of the enterprise prefix, or the token
+	// collection would see history entries as tokens.
+	historySuffix = "_history"
+
+	// coalesceWindow is how long watchEnterpriseToken waits for further
+	// events after receiving one, so that bursts of writes (e.g. a Put
+	// immediately followed by a Delete) are evaluated as a single state change
+	coalesceWindow = 100 * time.Millisecond
+
+	// subscriberBufferSize is the number of state changes that may be queued
+	// for a subscriber before sending to it blocks
+	subscriberBufferSize = 16
+
+	// defaultSubscriberSendTimeout is the value of
+	// Options.SubscriberSendTimeout used when none is set
+	defaultSubscriberSendTimeout = time.Second
+
+	// defaultExpiryWarningWindow is the value of Options.ExpiryWarningWindow
+	// used when none is set
+	defaultExpiryWarningWindow = 30 * 24 * time.Hour
+
+	// maxGracePeriod and maxExpiryWarningWindow are the longest values of
+	// Options.GracePeriod and Options.ExpiryWarningWindow that
+	// NewEnterpriseServer accepts. Longer values are clamped.
+	maxGracePeriod         = 365 * 24 * time.Hour
+	maxExpiryWarningWindow = 365 * 24 * time.Hour
+
+	// minCheckInterval and maxCheckInterval bound the NextCheckAfter hint
+	// returned by GetState
+	minCheckInterval = 10 * time.Second
+	maxCheckInterval = 24 * time.Hour
+
+	// maxActivationCodeSize is the largest activation code that pachd will
+	// accept, whether it's sent directly, downloaded by ActivateFromURL or
+	// assembled by ActivatePartial. Codes are stored in etcd, so this must stay
+	// well below etcd's request size limit (1.5 MiB by default)
+	maxActivationCodeSize = 64 * 1024
+
+	// defaultMaxRecordSize is the value of Options.MaxRecordSize used when
+	// none is set. It leaves room for the largest activation code, plus the
+	// claims stored alongside it
+	defaultMaxRecordSize = 2 * maxActivationCodeSize
+
+	// defaultProductID is the product that activation codes must be issued
+	// for (if they name one) when Options.ProductID is unset
+	defaultProductID = "pachyderm"
+
+	// leaseExpiryTolerance is how far an activation code's expiry may be from
+	// the end of the lease requested in an ActivateRequest
+	leaseExpiryTolerance = time.Hour
+
+	// maxActivationURLRedirects is the number of redirects that
+	// ActivateFromURL will follow (the same limit as http.DefaultClient's)
+	maxActivationURLRedirects = 10
+
+	// defaultActivationURLTimeout is the value of Options.ActivationURLTimeout
+	// used when none is set
+	defaultActivationURLTimeout = 30 * time.Second
+
+	// defaultHealthCheckInterval and defaultStaleThreshold are the values of
+	// Options.HealthCheckInterval and Options.StaleThreshold used when none
+	// are set
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultStaleThreshold      = 30 * time.Second
+
+	// defaultWarningInputsInterval is the value of
+	// Options.WarningInputsInterval used when none is set
+	defaultWarningInputsInterval = time.Minute
+
+	// defaultStartupRetryTimeout and defaultStartupReadTimeout bound the
+	// synchronous read made by start() when Options.StartupRetry and
+	// Options.StartupReadTimeout are unset
+	defaultStartupRetryTimeout = 15 * time.Second
+	defaultStartupReadTimeout  = 5 * time.Second
+)
+
+type apiServer struct {
+	pachLogger log.Logger
+	// etcd is the *etcdConn that the server reads and writes the token
+	// through. ReconnectEtcd replaces it wholesale (see conn)
+	etcd    atomic.Value
+	options Options
+
+	// etcdPrefix is the prefix of the token's collection, from which
+	// ReconnectEtcd rebuilds the collections
+	etcdPrefix string
+
+	// trialMu serializes maybeActivateTrial's attempts to activate a trial
+	// token, and trialDone is set once one no longer needs to be made (a
+	// trial was stored, or the cluster was seen to have a token)
+	trialMu   sync.Mutex
+	trialDone int32
+
+	// reconnectMu serializes ReconnectEtcd calls, and guards stopWatch, which
+	// stops the token's watch against the current etcdConn
+	reconnectMu sync.Mutex
+	stopWatch   context.CancelFunc
+
+	// env is the environment that the cluster runs in (Options.Environment)
+	env string
+
+	// pachVersion is the version of Pachyderm that the server runs in, which
+	// must be within the version range of any activation code that names one
+	pachVersion string
+
+	// enterpriseInfo is a cached tokenInfo, describing the current Pachyderm
+	// Enterprise token (or the zero tokenInfo if there is no Pachyderm
+	// Enterprise token). Until the token is first read from etcd, it holds a
+	// tokenInfo with 'uninitialized' set, which is distinct from there being
+	// no token.
+	enterpriseInfo atomic.Value
+
+	// stateEntered records when the cluster entered its current state, as
+	// observed by setTokenInfo (see stateEnteredAt). It's guarded by
+	// stateEnteredMu
+	stateEnteredMu sync.Mutex
+	stateEntered   stateEntry
+
+	// initMu ensures that only one RPC at a time reads the token from etcd if
+	// it's called before enterpriseInfo has been initialized
+	initMu sync.Mutex
+
+	// trustedKeys is the trustedKeySet whose keys activation codes may be
+	// signed with. It's replaced wholesale by storeTrustedKeys
+	trustedKeys atomic.Value
+
+	// backupKeys are the keys that activation codes flagged as Backup must be
+	// signed with (see Options.BackupPublicKeys). They're set before the
+	// server starts, and never change
+	backupKeys []*rsa.PublicKey
+
+	// jwks is the *jwkSet last fetched from Options.JWKSURL. If JWKSURL is
+	// set, its keys are trusted instead of trustedKeys
+	jwks atomic.Value
+
+	// disconnectedSince is the time.Time at which monitorEtcd first failed to
+	// reach etcd, or the zero time if etcd is currently reachable. Until etcd
+	// is reachable again, the cache can't be assumed to be up to date
+	disconnectedSince atomic.Value
+
+	// lastHealthy is the time.Time at which the last successful check that
+	// etcd was reachable (by start or monitorEtcd) began. GetState reports it
+	// as the time that a stale state was last updated
+	lastHealthy atomic.Value
+
+	// watchConnected (the number of connected watches: 1 or 0, except
+	// briefly while ReconnectEtcd replaces the watch), lastWatchEvent (a
+	// time.Time) and lastError (a string) describe the state of the
+	// background watch, for DebugDump
+	watchConnected int32
+	lastWatchEvent atomic.Value
+	lastError      atomic.Value
+
+	// watchStalled is 1 if the last watch health check (see
+	// checkWatchHealth) failed, and lastRoundTrip is the time.Time at which
+	// the last one that succeeded began
+	watchStalled  int32
+	lastRoundTrip atomic.Value
+
+	// collector is the snapshotCollector that exports the server's
+	// MetricsSnapshot, if NewEnterpriseServer registered one
+	collector *snapshotCollector
+
+	// subscribers receive the new enterprise state each time it changes.
+	// subscribersMu also serializes updates to enterpriseInfo made by
+	// setTokenInfo, so that subscribers observe changes in order
+	subscribersMu sync.Mutex
+	subscribers   map[chan ec.State]struct{}
+
+	// partials are the activation codes that ActivatePartial has received
+	// some of the parts of, keyed by upload ID
+	partialsMu sync.Mutex
+	partials   map[string]*partialActivationCode
+
+	// staged are the activation codes that StageActivation is holding for
+	// CommitActivation, keyed by stage ID
+	stagedMu sync.Mutex
+	staged   map[string]*stagedActivation
+
+	// mutateMu serializes the server's changes to the token in etcd
+	// (activations, Deactivate, trial activation and lease reconciliation):
+	// each one holds it from reading the token to committing its change. So
+	// concurrent changes made through the same server take effect one at a
+	// time, in the order that they acquire it, and the last one determines
+	// the final state. Changes made through other servers are only ordered by
+	// their etcd transactions
+	mutateMu sync.Mutex
+
+	// confirmWaiters are closed by setTokenInfo when it caches a token with
+	// the expiry (in nanoseconds since the Unix epoch) that they're keyed by
+	// (see awaitCachedRecord)
+	confirmMu      sync.Mutex
+	confirmWaiters map[int64][]chan struct{}
+
+	// warningInputs is the warningInputs last collected by
+	// refreshWarningInputs. inputsStale is signalled when the token changes,
+	// so that watchWarningInputs collects them again promptly
+	warningInputs atomic.Value
+	inputsStale   chan struct{}
+
+	// emergencyUntil is the time.Time at which the emergency override set by
+	// setEmergencyOverride ends, or the zero time if none has been set.
+	// emergencyTimer logs the end of the override, and is guarded by
+	// subscribersMu
+	emergencyUntil atomic.Value
+	emergencyTimer *time.Timer
+
+	// injection is the *stateInjection set by InjectState, or nil if there is
+	// none. injectionTimer ends it, and is guarded by subscribersMu
+	injection      atomic.Value
+	injectionTimer *time.Timer
+
+	// historySeq is the sequence number of the last activation history key
+	// written by this server (see historyKey)
+	historySeq uint64
+
+	// signatureFailures are the times of recent signature failures, for
+	// Options.OnSignatureFailures (see recordSignatureFailure)
+	signatureFailures signatureFailureTracker
+
+	// maintenance is 1 while the server is in maintenance mode (see
+	// SetMaintenance), and 0 otherwise
+	maintenance int32
+
+	// clockOffset is the time.Duration (in nanoseconds) added to the real
+	// time by now(). It's only ever non-zero in builds with the
+	// 'simulatedclock' tag (see SetSimulatedNow)
+	clockOffset int64
+
+	// latestNow is the latest time (in nanoseconds since the Unix epoch) that
+	// now() has returned, which it doesn't return times before unless the
+	// clock steps backward by more than maxClockSkew
+	latestNow int64
+
+	// clockWarnedFor is the IssuedAt (a time.Time) of the token for which
+	// clockWarning last logged that the clock may be incorrect, or the zero
+	// time if the clock currently looks correct
+	clockWarnedFor atomic.Value
+
+	// expiryOffset is how long before its signed expiry the cluster treats
+	// its token as expiring (see Options.ExpiryJitter and effectiveExpiry)
+	expiryOffset time.Duration
+
+	// asyncMu guards closed and async. async counts the background work
+	// items (see startAsync) that Close waits for
+	asyncMu sync.Mutex
+	closed  bool
+	async   sync.WaitGroup
+
+	// ctx is canceled by Close, which stops all of the server's background
+	// goroutines (watchEnterpriseToken, monitorEtcd, watchWarningInputs and
+	// compactHistoryPeriodically)
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Options contains optional configuration for the enterprise API server. The
+// zero value is a valid configuration.
+type Options struct {
+	// JSONRecords causes the enterprise record to be stored in etcd as JSON
+	// rather than as a protobuf, so that operators can read it with etcdctl.
+	// Records written with either setting can be read with the other.
+	JSONRecords bool
+
+	// CompressRecordsAbove, if set, causes records (the token and activation
+	// history) whose encoding is at least this many bytes to be stored in etcd
+	// compressed with gzip, to save space when their claims are large.
+	// Smaller records are stored uncompressed. Records written with any
+	// setting can be read with any other. It must not be negative
+	CompressRecordsAbove int
+
+	// HistoryPrefix is the etcd prefix of the activation history collection.
+	// If unset, it's the enterprise etcd prefix followed by "_history". It
+	// must not overlap with the enterprise etcd prefix.
+	HistoryPrefix string
+
+	// HistoryMaxEntries and HistoryMaxAge, if set, limit how much of the
+	// activation history is kept: every HistoryCompactionInterval (1 hour, if
+	// unset), records beyond the newest HistoryMaxEntries, and records older
+	// than HistoryMaxAge, are deleted. The newest record is always kept. If
+	// neither is set, the history is kept forever.
+	HistoryMaxEntries         int
+	HistoryMaxAge             time.Duration
+	HistoryCompactionInterval time.Duration
+
+	// ConnectRetry, if set, is used to retry connecting to etcd when
+	// NewEnterpriseServer is called before etcd is available (e.g. because
+	// pachd and etcd are starting at the same time). NewEnterpriseServer only
+	// fails once ConnectRetry stops. If unset, NewEnterpriseServer tries to
+	// connect exactly once.
+	ConnectRetry backoff.BackOff
+
+	// EtcdReadEndpoints and EtcdWriteEndpoints, if set, are the etcd
+	// endpoints that the server reads from and writes to, instead of the
+	// address passed to NewEnterpriseServer (which is used for whichever of
+	// them is unset). Only transactions, such as Activate's, are sent to
+	// EtcdWriteEndpoints; the token's watch, GetState's cache misses and other
+	// reads are sent to EtcdReadEndpoints, which may be e.g. a read replica.
+	EtcdReadEndpoints  []string
+	EtcdWriteEndpoints []string
+
+	// HideEtcdConfig stops NewEnterpriseServer from logging the etcd
+	// endpoints (with any credentials removed) and prefixes that it uses
+	HideEtcdConfig bool
+
+	// ConnectTimeout bounds each attempt to connect to etcd. If unset, the
+	// dial timeout in client.EtcdDialOptions() is used.
+	ConnectTimeout time.Duration
+
+	// StartupRetry is used to retry the synchronous read of the enterprise
+	// token that NewEnterpriseServer makes once it's connected, if etcd is
+	// unavailable. If unset, the read is retried for up to 15 seconds.
+	// Errors other than etcd being unavailable (e.g. a corrupt record) are
+	// never retried.
+	StartupRetry backoff.BackOff
+
+	// StartupReadTimeout bounds each attempt to read the enterprise token at
+	// startup (5 seconds, if unset)
+	StartupReadTimeout time.Duration
+
+	// ExpiryWarningWindow is how long before the enterprise token expires
+	// that GetState starts warning about it (30 days, if unset). It must not
+	// be negative, and NewEnterpriseServer clamps it to maxExpiryWarningWindow
+	ExpiryWarningWindow time.Duration
+
+	// GracePeriod is how long after the enterprise token expires that the
+	// cluster remains ACTIVE. GetState warns about the token for the duration
+	// of the grace period. It must not be negative, and NewEnterpriseServer
+	// clamps it to maxGracePeriod
+	GracePeriod time.Duration
+
+	// ClusterID identifies the cluster. It's only used to derive the cluster's
+	// ExpiryJitter offset
+	ClusterID string
+
+	// ExpiryJitter, if set, makes the cluster treat its token as expiring up
+	// to ExpiryJitter before the token's signed expiry (though never before the
+	// token was issued), so that a fleet of clusters sharing a token don't all
+	// expire at once. The offset is derived from ClusterID, so a cluster gets
+	// the same one each time it starts. It must not be negative, and requires
+	// ClusterID
+	ExpiryJitter time.Duration
+
+	// NodeCount, if set, returns the number of nodes in the cluster, which
+	// GetState compares against the token's node limit
+	NodeCount func() (int64, error)
+
+	// RequireMonotonicActivation causes Activate to reject activation codes
+	// whose serial isn't greater than that of the cluster's current token
+	// (unless the request sets Force, which ActivatePartial requests can't),
+	// so that an older, unexpired code can't be replayed to replace a newer one
+	RequireMonotonicActivation bool
+
+	// Environment is the environment that the cluster runs in (e.g. "prod" or
+	// "staging"). If set, Activate rejects activation codes issued for a
+	// different environment. Codes that don't name an environment may be used
+	// in any environment.
+	Environment string
+
+	// ProductID is the product that activation codes must be issued for. If
+	// it's unset, Activate rejects codes issued for any product other than
+	// "pachyderm", but accepts codes that don't name a product. If it's set,
+	// Activate also rejects codes that don't name a product.
+	ProductID string
+
+	// MinSchemaVersion, if set, is the oldest token schema version (see
+	// ec.Token.SchemaVersion) that the server accepts. Activate rejects codes
+	// whose tokens use an older schema, so that their holders must obtain new
+	// codes. It must not be negative
+	MinSchemaVersion int
+
+	// MinRemaining, if set, is the least time that an activation code must
+	// have left before it expires (once any ExpiryJitter is applied) for
+	// Activate to accept it, unless the request sets Force. This catches codes
+	// that would leave the cluster EXPIRED almost as soon as they're
+	// activated. It must not be negative
+	MinRemaining time.Duration
+
+	// IsRevoked, if set, reports whether an activation code has been revoked
+	IsRevoked func(activationCode string) (bool, error)
+
+	// IsAdmin, if set, reports whether the caller of an RPC is a cluster
+	// admin. Admin-only RPCs, such as Deactivate and DebugDump, always fail if
+	// it's unset
+	IsAdmin func(ctx context.Context) (bool, error)
+
+	// PublicKeys, if set, are the PEM-encoded RSA public keys that activation
+	// codes may be signed with, instead of the key embedded in pachd. They're
+	// parsed by NewEnterpriseServer, which fails if any is malformed.
+	// PublicKeysSource is where they came from (e.g. ec.TrustedKeySource_ENV),
+	// which ListTrustedKeys reports
+	PublicKeys       []string
+	PublicKeysSource ec.TrustedKeySource
+
+	// BackupPublicKeys, if set, are the PEM-encoded RSA public keys of the
+	// backup signing authority. They're only consulted for activation codes
+	// whose envelope sets Backup, and such codes are never checked against
+	// any other key. They're parsed by NewEnterpriseServer, like PublicKeys
+	BackupPublicKeys []string
+
+	// JWKSURL, if set, is the HTTPS URL of a JWKS (RFC 7517) containing the
+	// keys that activation codes may be signed with. It replaces the embedded
+	// key, and the keys are fetched when the server starts and every
+	// JWKSRefreshInterval (1 hour, if unset). If a fetch fails, the last keys
+	// fetched are kept.
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+
+	// ActivationURLHosts are the hosts from which ActivateFromURL may download
+	// activation codes. If empty, ActivateFromURL always fails.
+	ActivationURLHosts []string
+
+	// ActivationURLTimeout bounds each download made by ActivateFromURL, and
+	// each call to FetchCode (30 seconds, if unset)
+	ActivationURLTimeout time.Duration
+
+	// HTTPClient, if set, is used by ActivateFromURL to download activation
+	// codes instead of http.DefaultClient
+	HTTPClient *http.Client
+
+	// PartialActivationTimeout is how long ActivatePartial waits for all of
+	// the parts of an activation code to arrive, after receiving the first
+	// one (10 minutes, if unset)
+	PartialActivationTimeout time.Duration
+
+	// StagedActivationTimeout is how long StageActivation holds an activation
+	// code for CommitActivation (10 minutes, if unset)
+	StagedActivationTimeout time.Duration
+
+	// OnSignatureFailures, if set, is called when more than
+	// SignatureFailureThreshold activation codes with invalid signatures are
+	// rejected within SignatureFailureWindow (1 minute, if unset), as a spike
+	// in failures may mean that a key was compromised or the wrong keys were
+	// distributed. It's called once per spike, in its own goroutine (which
+	// Close waits for), with the number of failures in the window.
+	OnSignatureFailures       func(failures int, window time.Duration)
+	SignatureFailureThreshold int
+	SignatureFailureWindow    time.Duration
+
+	// WarningInputsInterval is how often the server re-runs NodeCount and
+	// IsRevoked, whose results GetState's warnings are based on (1 minute, if
+	// unset). GetState itself never calls them, so that it's served from
+	// memory.
+	WarningInputsInterval time.Duration
+
+	// ImmediateWatchReconnect makes the server re-establish the token's watch
+	// immediately the first time it fails after a healthy stretch, rather than
+	// after a backoff interval, so that updates are less likely to be missed.
+	// Repeated failures still back off.
+	ImmediateWatchReconnect bool
+
+	// WatchRetryFloor is the shortest time that the server waits before
+	// re-establishing the token's watch after it fails (250 milliseconds, if
+	// unset), so that a watch that etcd rejects immediately (e.g. because of
+	// an auth error) can't be retried in a tight loop. The immediate retry
+	// made by ImmediateWatchReconnect is exempt, as it only follows a healthy
+	// stretch.
+	WatchRetryFloor time.Duration
+
+	// ConfirmActivationTimeout, if set, makes activations wait (for up to
+	// this long) after writing the token until the watch has applied it to
+	// the cache, so that a GetState made as soon as they return reflects the
+	// new token. If the watch doesn't apply it in time, the activation still
+	// succeeds, as the token has been written. By default, activations return
+	// as soon as the token is written
+	ConfirmActivationTimeout time.Duration
+
+	// MaxRecordSize is the largest enterprise record, as encoded in etcd
+	// (including its activation code and claims, such as its features), that
+	// the server will store (128 KiB, if unset). Activations that would store
+	// a larger record are rejected, so that the record can't bloat etcd. It
+	// must not be negative
+	MaxRecordSize int
+
+	// HealthCheckInterval is how often the server checks that etcd is
+	// reachable (10 seconds, if unset)
+	HealthCheckInterval time.Duration
+
+	// WatchHealthCheckInterval, if set, is how often the server writes a
+	// sentinel record (apart from the token, and attached to a lease, so that
+	// it's removed if pachd goes away) and checks that a long-lived watch
+	// observes it within the interval. This detects watches that etcd has
+	// silently stopped delivering events to, which HealthCheckInterval's pings
+	// don't. While the check fails, GetState reports that its response is
+	// stale. It must not be negative, and is disabled by default
+	WatchHealthCheckInterval time.Duration
+
+	// StaleThreshold is how long etcd must be unreachable before GetState
+	// reports that its response is stale (30 seconds, if unset)
+	StaleThreshold time.Duration
+
+	// LeaseReconcileInterval is how often the server checks that a
+	// lease-backed token (see ActivateRequest.LeaseTTL) is still attached to
+	// a lease that ends once the token's grace period has passed, and
+	// corrects the lease if not (1 minute, if unset)
+	LeaseReconcileInterval time.Duration
+
+	// ShutdownTimeout is how long Close waits for background work to finish
+	// (10 seconds, if unset)
+	ShutdownTimeout time.Duration
+
+	// SubscriberSendTimeout is how long the watch waits to deliver a state
+	// change to a subscriber whose buffer is full (e.g. a WatchState caller
+	// that isn't reading) before dropping it (1 second, if unset)
+	SubscriberSendTimeout time.Duration
+
+	// EnableStateInjection allows admins to override the state that the
+	// server reports, for a bounded time, with the InjectState RPC. It's
+	// meant for tests and chaos experiments only, and must never be set in
+	// production; without it, InjectState always fails.
+	EnableStateInjection bool
+
+	// EnableTrials makes the server activate a trial token, generated by
+	// TrialGenerator, when it serves its first enterprise RPC, if the cluster
+	// has no token then. A trial never replaces an existing token.
+	// TrialGenerator must be set if EnableTrials is.
+	EnableTrials bool
+
+	// TrialGenerator returns the activation code of a trial token (e.g. one
+	// that expires in 30 days). It's validated like any other code
+	TrialGenerator func() (code string, err error)
+
+	// FetchCode, if set, puts the server in pull mode: it's called when the
+	// server starts, and then every PullInterval (1 hour, if unset), to fetch
+	// the cluster's latest activation code from a central license server. A
+	// fetched code is validated like any other, and activated only if it
+	// expires later than the cluster's current token, so that the cluster
+	// renews itself but is never downgraded. If a fetch fails (or returns an
+	// invalid code), it's retried according to PullRetry (an exponential
+	// backoff capped at PullInterval, if unset). PullInterval must not be
+	// negative
+	FetchCode    func(ctx context.Context) (code string, err error)
+	PullInterval time.Duration
+	PullRetry    backoff.BackOff
+
+	// AuditSink is called with an AuditEvent each time the cluster's token is
+	// activated, renewed or deactivated through the server (NoopAuditSink, if
+	// unset), e.g. to forward them to a SIEM. It's called in the background,
+	// and its errors are logged, so it never blocks or fails the change
+	AuditSink func(ctx context.Context, event AuditEvent) error
+}
+
+// tokenInfo is the information about the cluster's enterprise token that
+// apiServer caches
+type tokenInfo struct {
+	// expiry is the time at which the token expires, or the zero time if the
+	// cluster has no token
+	expiry          time.Time
+	maxNodes        int64
+	maxPipelines    int32
+	maxStorageBytes int64
+	features        []string
+	activationCode  string
+	// issuedAt and activatedAt are the times at which the token was issued and
+	// activated, or the zero time if they're unknown
+	issuedAt    time.Time
+	activatedAt time.Time
+	// keyID identifies the trusted key that the token was verified with (see
+	// ec.KeyID()), or is "" if it's unknown
+	keyID string
+	// sla is the support SLA that the token names, if any
+	sla string
+	// deprecatedFeatures maps the features that the token deprecates to their
+	// deprecation notices
+	deprecatedFeatures map[string]string
+	// featureExpiries maps the features that expire before the token to
+	// their expiries
+	featureExpiries map[string]time.Time
+
+	// uninitialized is set in the tokenInfo that apiServer caches until it
+	// has read the token from etcd for the first time
+	uninitialized bool
+}
+
+func newTokenInfo(record *ec.EnterpriseRecord) (tokenInfo, error) {
+	expiry, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return tokenInfo{}, fmt.Errorf("could not parse expiration timestamp: %s", err.Error())
+	}
+	info := tokenInfo{
+		expiry:             expiry,
+		maxNodes:           record.MaxNodes,
+		maxPipelines:       record.MaxPipelines,
+		maxStorageBytes:    record.MaxStorageBytes,
+		features:           record.Features,
+		activationCode:     record.ActivationCode,
+		keyID:              record.KeyID,
+		sla:                record.SLA,
+		deprecatedFeatures: record.DeprecatedFeatures,
+	}
+	if record.IssuedAt != nil {
+		if info.issuedAt, err = types.TimestampFromProto(record.IssuedAt); err != nil {
+			return tokenInfo{}, fmt.Errorf("could not parse issue timestamp: %s", err.Error())
+		}
+	}
+	if record.ActivatedAt != nil {
+		if info.activatedAt, err = types.TimestampFromProto(record.ActivatedAt); err != nil {
+			return tokenInfo{}, fmt.Errorf("could not parse activation timestamp: %s", err.Error())
+		}
+	}
+	for feature, expiryProto := range record.FeatureExpiries {
+		featureExpiry, err := types.TimestampFromProto(expiryProto)
+		if err != nil {
+			return tokenInfo{}, fmt.Errorf("could not parse expiration timestamp of feature %q: %s", feature, err.Error())
+		}
+		if info.featureExpiries == nil {
+			info.featureExpiries = make(map[string]time.Time)
+		}
+		info.featureExpiries[feature] = featureExpiry
+	}
+	return info, nil
+}
+
+// hasFeature returns true if the token enables 'feature'
+func (info tokenInfo) hasFeature(feature string) bool {
+	for _, f := range info.features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// APIServer is the server side of the enterprise API
+type APIServer interface {
+	ec.APIServer
+
+	// UnaryServerInterceptor returns an interceptor that must be installed on
+	// the grpc server that serves this APIServer. Among other things, it
+	// converts panics in enterprise RPCs into Internal errors.
+	UnaryServerInterceptor() grpc.UnaryServerInterceptor
+
+	// StreamServerInterceptor is the equivalent of UnaryServerInterceptor for
+	// streaming RPCs, and must also be installed
+	StreamServerInterceptor() grpc.StreamServerInterceptor
+
+	// Close shuts the server down: it stops accepting changes to the
+	// cluster's token, waits for background work (e.g. history compaction and
+	// notification hooks) to finish, and then stops the server's background
+	// goroutines. The server's cached state is no longer kept up to date
+	// afterwards. It returns an error if the background work doesn't finish
+	// within Options.ShutdownTimeout.
+	Close() error
+
+	// SetSimulatedNow makes the server evaluate the enterprise state as if
+	// the current time were 't' (which keeps advancing from there), for
+	// tests and demos. The zero time restores the real clock. It returns an
+	// error unless pachd was built with the 'simulatedclock' build tag.
+	SetSimulatedNow(t time.Time) error
+
+	// ReconnectEtcd moves the server to the etcd cluster at 'endpoints'
+	// without a restart (e.g. while migrating etcd clusters). It connects to
+	// the new endpoints, re-primes the server's cache from them and restarts
+	// the token's watch against them, and only then closes the old etcd
+	// clients. If it fails, the server keeps using the old clients.
+	ReconnectEtcd(endpoints []string, options ReconnectOptions) error
+
+	// LogFields returns the cluster's cached enterprise state as log fields.
+	// Other servers can pass it to log.NewLogger, so that their API logs can
+	// be correlated with the enterprise state.
+	LogFields() logrus.Fields
+
+	// SetMaintenance turns maintenance mode on or off. While it's on, RPCs
+	// that change the cluster's token (Activate, Deactivate, etc.) fail with
+	// Unavailable, but the state can still be read
+	SetMaintenance(on bool)
+
+	// MetricsSnapshot returns the server's current state and counters. The
+	// server's Prometheus metrics are built from the same snapshot.
+	MetricsSnapshot() ec.Metrics
+}
+
+// NewEnterpriseServer returns an implementation of ec.APIServer.
+func NewEnterpriseServer(etcdAddress string, etcdPrefix string, options Options) (APIServer, error) {
+	if err := checkPrefixes(etcdPrefix, historyPrefix(etcdPrefix, options)); err != nil {
+		return nil, err
+	}
+	if err := validateWindows(&options); err != nil {
+		return nil, err
+	}
+	if err := validateHistoryRetention(options); err != nil {
+		return nil, err
+	}
+	if err := validateExpiryJitter(options); err != nil {
+		return nil, err
+	}
+	if options.MinSchemaVersion < 0 {
+		return nil, fmt.Errorf("enterprise minimum schema version must not be negative, but was %d", options.MinSchemaVersion)
+	}
+	if options.MinRemaining < 0 {
+		return nil, fmt.Errorf("enterprise minimum remaining validity must not be negative, but was %v", options.MinRemaining)
+	}
+	if options.WatchRetryFloor < 0 {
+		return nil, fmt.Errorf("enterprise watch retry floor must not be negative, but was %v", options.WatchRetryFloor)
+	}
+	if options.ConfirmActivationTimeout < 0 {
+		return nil, fmt.Errorf("enterprise activation confirmation timeout must not be negative, but was %v", options.ConfirmActivationTimeout)
+	}
+	if options.WatchHealthCheckInterval < 0 {
+		return nil, fmt.Errorf("enterprise watch health check interval must not be negative, but was %v", options.WatchHealthCheckInterval)
+	}
+	if options.CompressRecordsAbove < 0 {
+		return nil, fmt.Errorf("enterprise record compression threshold must not be negative, but was %d", options.CompressRecordsAbove)
+	}
+	if options.MaxRecordSize < 0 {
+		return nil, fmt.Errorf("enterprise maximum record size must not be negative, but was %d", options.MaxRecordSize)
+	}
+	if options.PullInterval < 0 {
+		return nil, fmt.Errorf("enterprise activation code pull interval must not be negative, but was %v", options.PullInterval)
+	}
+	if options.EnableTrials && options.TrialGenerator == nil {
+		return nil, fmt.Errorf("enterprise trials are enabled, but no trial generator was provided")
+	}
+	if options.SignatureFailureThreshold < 0 || options.SignatureFailureWindow < 0 {
+		return nil, fmt.Errorf("enterprise signature failure threshold and window must not be negative")
+	}
+	if err := checkJWKSURL(options.JWKSURL); err != nil {
+		return nil, err
+	}
+	keys, keySource, err := parseTrustedKeys(options)
+	if err != nil {
+		return nil, err
+	}
+	backupKeys, err := parseBackupKeys(options)
+	if err != nil {
+		return nil, err
+	}
+	readEndpoints, writeEndpoints := etcdEndpoints(etcdAddress, options)
+	if !options.HideEtcdConfig {
+		logEtcdConfig(readEndpoints, writeEndpoints, etcdPrefix, historyPrefix(etcdPrefix, options))
+	}
+	etcdClient, err := connectEtcd(writeEndpoints, options)
+	if err != nil {
+		return nil, err
+	}
+	readClient := etcdClient
+	if !sameEndpoints(readEndpoints, writeEndpoints) {
+		if readClient, err = connectEtcd(readEndpoints, options); err != nil {
+			etcdClient.Close()
+			return nil, err
+		}
+	}
+
+	s := newAPIServerWithReadClient(etcdClient, readClient, etcdPrefix, options)
+	s.conn().owned = true
+	s.storeTrustedKeys(keys, keySource)
+	s.backupKeys = backupKeys
+	if err := s.start(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.registerSnapshotCollector()
+	return s, nil
+}
+
+// historyPrefix returns the etcd prefix of the activation history collection
+func historyPrefix(etcdPrefix string, options Options) string {
+	if options.HistoryPrefix != "" {
+		return options.HistoryPrefix
+	}
+	return strings.TrimSuffix(etcdPrefix, "/") + historySuffix
+}
+
+// checkPrefixes returns an error if the token and history collections'
+// prefixes overlap, meaning that one collection would contain the other's
+// keys
+func checkPrefixes(tokenPrefix, historyPrefix string) error {
+	// Collections always add a trailing slash to their prefix
+	tokenDir := strings.TrimSuffix(tokenPrefix, "/") + "/"
+	historyDir := strings.TrimSuffix(historyPrefix, "/") + "/"
+	if strings.HasPrefix(tokenDir, historyDir) || strings.HasPrefix(historyDir, tokenDir) {
+		return fmt.Errorf("enterprise etcd prefix %q and history etcd prefix %q overlap", tokenPrefix, historyPrefix)
+	}
+	return nil
+}
+
+// validateWindows returns an error if options.GracePeriod or
+// options.ExpiryWarningWindow is negative. Values longer than a year are almost
+// certainly misconfigurations (e.g. a unit mixup), so they're logged and
+// clamped to maxGracePeriod and maxExpiryWarningWindow respectively.
+func validateWindows(options *Options) error {
+	if options.GracePeriod < 0 {
+		return fmt.Errorf("enterprise grace period must not be negative, but was %v", options.GracePeriod)
+	}
+	if options.ExpiryWarningWindow < 0 {
+		return fmt.Errorf("enterprise expiry warning window must not be negative, but was %v", options.ExpiryWarningWindow)
+	}
+	if options.GracePeriod > maxGracePeriod {
+		logrus.Warnf("enterprise grace period %v is implausibly long; using %v", options.GracePeriod, maxGracePeriod)
+		options.GracePeriod = maxGracePeriod
+	}
+	if options.ExpiryWarningWindow > maxExpiryWarningWindow {
+		logrus.Warnf("enterprise expiry warning window %v is implausibly long; using %v",
+			options.ExpiryWarningWindow, maxExpiryWarningWindow)
+		options.ExpiryWarningWindow = maxExpiryWarningWindow
+	}
+	return nil
+}
+
+// etcdEndpoints returns the etcd endpoints that NewEnterpriseServer reads
+// from and writes to: options.EtcdReadEndpoints and
+// options.EtcdWriteEndpoints, or 'etcdAddress' for whichever of them is unset
+func etcdEndpoints(etcdAddress string, options Options) (read []string, write []string) {
+	read, write = options.EtcdReadEndpoints, options.EtcdWriteEndpoints
+	if len(read) == 0 {
+		read = []string{etcdAddress}
+	}
+	if len(write) == 0 {
+		write = []string{etcdAddress}
+	}
+	return read, write
+}
+
+// sameEndpoints returns true if 'a' and 'b' contain the same etcd endpoints,
+// in any order
+func sameEndpoints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// connectEtcd constructs an etcd client and confirms that etcd is reachable,
+// retrying according to options.ConnectRetry
+func connectEtcd(endpoints []string, options Options) (*etcd.Client, error) {
+	dialOptions := client.EtcdDialOptions()
+	if options.ConnectTimeout > 0 {
+		// Appended last, so that it overrides the timeout in EtcdDialOptions()
+		dialOptions = append(dialOptions, grpc.WithTimeout(options.ConnectTimeout))
+	}
+	retry := options.ConnectRetry
+	if retry == nil {
+		retry = &backoff.StopBackOff{}
+	}
+	var etcdClient *etcd.Client
+	err := backoff.RetryNotify(func() error {
+		c, err := etcd.New(etcd.Config{
+			Endpoints:   endpoints,
+			DialOptions: dialOptions,
+		})
+		if err != nil {
+			return fmt.Errorf("error constructing etcdClient: %s", err.Error())
+		}
+		if err := pingEtcd(c, options.ConnectTimeout); err != nil {
+			c.Close()
+			return fmt.Errorf("error connecting to etcd: %s", err.Error())
+		}
+		etcdClient = c
+		return nil
+	}, retry, func(err error, d time.Duration) error {
+		logrus.Printf("could not connect to etcd: %v; retrying in %v", err, d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etcdClient, nil
+}
+
+// pingEtcd confirms that 'etcdClient' can actually serve requests
+func pingEtcd(etcdClient *etcd.Client, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := etcdClient.Get(ctx, enterpriseTokenKey, etcd.WithCountOnly())
+	return err
+}
+
+// newAPIServer constructs an apiServer without contacting etcd. Callers must
+// call start() before serving any requests.
+func newAPIServer(etcdClient *etcd.Client, etcdPrefix string, options Options) *apiServer {
+	return newAPIServerWithReadClient(etcdClient, etcdClient, etcdPrefix, options)
+}
+
+// newAPIServerWithReadClient is like newAPIServer, but the returned apiServer
+// reads from etcd (outside of transactions) with 'readClient'
+func newAPIServerWithReadClient(etcdClient, readClient *etcd.Client, etcdPrefix string, options Options) *apiServer {
+	if options.ExpiryWarningWindow == 0 {
+		options.ExpiryWarningWindow = defaultExpiryWarningWindow
+	}
+	if options.ActivationURLTimeout == 0 {
+		options.ActivationURLTimeout = defaultActivationURLTimeout
+	}
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
+	if options.PartialActivationTimeout == 0 {
+		options.PartialActivationTimeout = defaultPartialActivationTimeout
+	}
+	if options.JWKSRefreshInterval == 0 {
+		options.JWKSRefreshInterval = defaultJWKSRefreshInterval
+	}
+	if options.HistoryCompactionInterval == 0 {
+		options.HistoryCompactionInterval = defaultHistoryCompactionInterval
+	}
+	if options.SignatureFailureWindow == 0 {
+		options.SignatureFailureWindow = defaultSignatureFailureWindow
+	}
+	if options.StagedActivationTimeout == 0 {
+		options.StagedActivationTimeout = defaultStagedActivationTimeout
+	}
+	if options.WarningInputsInterval == 0 {
+		options.WarningInputsInterval = defaultWarningInputsInterval
+	}
+	if options.HealthCheckInterval == 0 {
+		options.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if options.StaleThreshold == 0 {
+		options.StaleThreshold = defaultStaleThreshold
+	}
+	if options.WatchRetryFloor == 0 {
+		options.WatchRetryFloor = defaultWatchRetryFloor
+	}
+	if options.LeaseReconcileInterval == 0 {
+		options.LeaseReconcileInterval = defaultLeaseReconcileInterval
+	}
+	if options.ShutdownTimeout == 0 {
+		options.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if options.SubscriberSendTimeout == 0 {
+		options.SubscriberSendTimeout = defaultSubscriberSendTimeout
+	}
+	if options.StartupRetry == nil {
+		options.StartupRetry = backoff.NewCappedBackOff(100*time.Millisecond, 2*time.Second, defaultStartupRetryTimeout)
+	}
+	if options.StartupReadTimeout == 0 {
+		options.StartupReadTimeout = defaultStartupReadTimeout
+	}
+	if options.AuditSink == nil {
+		options.AuditSink = NoopAuditSink
+	}
+	if options.MaxRecordSize == 0 {
+		options.MaxRecordSize = defaultMaxRecordSize
+	}
+	if options.PullInterval == 0 {
+		options.PullInterval = defaultPullInterval
+	}
+	if options.PullRetry == nil {
+		options.PullRetry = backoff.NewCappedBackOff(defaultPullRetryInitial, options.PullInterval, 0)
+	}
+	s := &apiServer{
+		options:        options,
+		env:            options.Environment,
+		pachVersion:    version.PrettyPrintVersion(version.Version),
+		etcdPrefix:     etcdPrefix,
+		subscribers:    make(map[chan ec.State]struct{}),
+		partials:       make(map[string]*partialActivationCode),
+		staged:         make(map[string]*stagedActivation),
+		confirmWaiters: make(map[int64][]chan struct{}),
+		inputsStale:    make(chan struct{}, 1),
+		expiryOffset:   expiryJitterOffset(options.ClusterID, options.ExpiryJitter),
+	}
+	s.pachLogger = log.NewLogger("enterprise.API", s.LogFields)
+	s.etcd.Store(newEtcdConn(etcdClient, readClient, etcdPrefix, options))
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	if etcdClient != nil {
+		s.enterpriseInfo.Store(tokenInfo{uninitialized: true})
+	} else {
+		// There's no etcd to read the token from (e.g. in tests), so start
+		// with no token
+		s.enterpriseInfo.Store(tokenInfo{})
+	}
+	s.warningInputs.Store(warningInputs{nodeCount: -1})
+	s.disconnectedSince.Store(time.Time{})
+	s.emergencyUntil.Store(time.Time{})
+	s.injection.Store((*stateInjection)(nil))
+	s.lastHealthy.Store(time.Time{})
+	s.lastWatchEvent.Store(time.Time{})
+	s.lastRoundTrip.Store(time.Time{})
+	s.lastError.Store("")
+	s.clockWarnedFor.Store(time.Time{})
+	if embeddedKeyErr == nil {
+		s.storeTrustedKeys([]*rsa.PublicKey{embeddedKey}, ec.TrustedKeySource_EMBEDDED)
+	} else {
+		s.storeTrustedKeys(nil, ec.TrustedKeySource_EMBEDDED)
+	}
+	return s
+}
+
+// start primes the enterprise state cache with a synchronous read from etcd
+// and then starts the background watch that keeps the cache up to date. After
+// start returns, GetState never needs to contact etcd.
+func (a *apiServer) start() error {
+	err := backoff.RetryNotifyCtx(a.ctx, func() error {
+		ctx, cancel := context.WithTimeout(a.ctx, a.options.StartupReadTimeout)
+		defer cancel()
+		checked := time.Now()
+		if err := a.refreshState(ctx); err != nil {
+			return err
+		}
+		a.lastHealthy.Store(checked)
+		return nil
+	}, a.options.StartupRetry, func(err error, d time.Duration) error {
+		if !isEtcdUnavailable(err) {
+			return err // retrying won't help
+		}
+		logrus.Printf("could not read enterprise token: %v; retrying in %v", err, d)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error reading enterprise token: %s", err.Error())
+	}
+	if a.options.JWKSURL != "" {
+		// Codes can't be activated until the keys have been fetched, but the
+		// current token can still be served, so don't fail if they can't be
+		if err := a.refreshJWKS(a.ctx); err != nil {
+			logrus.Errorf("%v; retrying in %v", err, a.options.JWKSRefreshInterval)
+		}
+		go a.watchJWKS(a.ctx)
+	}
+	a.reconnectMu.Lock()
+	a.watchToken(a.conn())
+	a.reconnectMu.Unlock()
+	go a.monitorEtcd()
+	go a.watchWarningInputs()
+	go a.reconcileLeasePeriodically()
+	if a.options.WatchHealthCheckInterval > 0 {
+		go a.checkWatchHealthPeriodically()
+	}
+	if a.options.HistoryMaxEntries > 0 || a.options.HistoryMaxAge > 0 {
+		go a.compactHistoryPeriodically()
+	}
+	if a.options.FetchCode != nil {
+		go a.pullCodePeriodically()
+	}
+	return nil
+}
+
+// isEtcdUnavailable returns true if 'err' indicates that an etcd request
+// failed because etcd couldn't be reached in time, rather than because of
+// something that retrying the request wouldn't fix
+func isEtcdUnavailable(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	switch grpc.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// monitorEtcd periodically checks that etcd is reachable, and records when it
+// stops being reachable in disconnectedSince. This is necessary because the
+// etcd client's watches retry indefinitely, so watchEnterpriseToken isn't
+// notified when etcd goes away. It also keeps the token age metrics, which
+// change with time, up to date.
+func (a *apiServer) monitorEtcd() {
+	ticker := time.NewTicker(a.options.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.ctx.Done():
+			return
+		}
+		updateTokenMetrics(a.loadTokenInfo(), time.Now())
+		checked := time.Now()
+		if err := pingEtcd(a.conn().readClient, a.options.HealthCheckInterval); err != nil {
+			a.lastError.Store(fmt.Sprintf("error checking etcd health: %v", err))
+			if since, ok := a.disconnectedSince.Load().(time.Time); ok && since.IsZero() {
+				logrus.Printf("enterprise server lost contact with etcd: %v", err)
+				a.disconnectedSince.Store(time.Now())
+			}
+			continue
+		}
+		a.lastHealthy.Store(checked)
+		a.disconnectedSince.Store(time.Time{})
+	}
+}
+
+// refreshState reads the enterprise token directly from etcd and stores it
+// (or the zero tokenInfo, if there is no token) in the cache
+func (a *apiServer) refreshState(ctx context.Context) error {
+	info, err := readTokenInfo(ctx, a.conn().enterpriseToken)
+	if err != nil {
+		return err
+	}
+	a.setTokenInfo(info)
+	return nil
+}
+
+// readTokenInfo reads the enterprise token from 'tokens', and returns its
+// tokenInfo (or the zero tokenInfo, if there is no token)
+func readTokenInfo(ctx context.Context, tokens col.Collection) (tokenInfo, error) {
+	var record ec.EnterpriseRecord
+	if err := tokens.ReadOnly(ctx).Get(enterpriseTokenKey, &record); err != nil {
+		if _, ok := err.(col.ErrNotFound); ok {
+			return tokenInfo{}, nil
+		}
+		return tokenInfo{}, tokenReadError(err)
+	}
+	return newTokenInfo(&record)
+}
+
+// watchEnterpriseToken keeps the cached tokenInfo up to date with the token in
+// 'tokens', re-establishing its watch whenever it fails, until 'ctx' is
+// canceled
+func (a *apiServer) watchEnterpriseToken(ctx context.Context, tokens col.Collection) {
+	retry := &reconnectBackOff{
+		inner:     backoff.NewInfiniteBackOff(),
+		immediate: a.options.ImmediateWatchReconnect,
+		floor:     a.options.WatchRetryFloor,
+	}
+	backoff.RetryNotifyCtx(ctx, func() error {
+		// Watch for incoming enterprise tokens
+		watcher, err := tokens.ReadOnly(ctx).Watch()
+		if err != nil {
+			return err
+		}
+		defer watcher.Close()
+		retry.connected(time.Now())
+		atomic.AddInt32(&a.watchConnected, 1)
+		defer atomic.AddInt32(&a.watchConnected, -1)
+		return a.processWatchEvents(watcher.Watch())
+	}, retry, func(err error, d time.Duration) error {
+		if ctx.Err() != nil {
+			return ctx.Err() // the watch failed because it was canceled
+		}
+		a.lastError.Store(fmt.Sprintf("error watching enterprise token: %v", err))
+		logrus.Printf("error from activation check: %v; retrying in %v", err, d)
+		return nil
+	})
+}
+
+// receivedEvent is a watch event, and the time at which processWatchEvents
+// received it
+type receivedEvent struct {
+	*watch.Event
+	received time.Time
+}
+
+// processWatchEvents reads events from 'eventCh' and applies them to the
+// cached tokenInfo until the channel closes or delivers an error.
+//
+// Events that arrive within 'coalesceWindow' of each other are applied as a
+// single batch, and the enterprise state is only re-evaluated (and subscribers
+// only notified) once per batch. This way, a token that is written and then
+// immediately deleted doesn't cause a spurious NONE -> ACTIVE -> NONE flap.
+func (a *apiServer) processWatchEvents(eventCh <-chan *watch.Event) error {
+	for {
+		ev, ok := <-eventCh
+		if !ok {
+			return errors.New("enterprise token watch closed unexpectedly")
+		}
+		batch := []receivedEvent{{ev, time.Now()}}
+		timer := time.NewTimer(coalesceWindow)
+	coalesce:
+		for {
+			select {
+			case ev, ok := <-eventCh:
+				if !ok {
+					break coalesce
+				}
+				batch = append(batch, receivedEvent{ev, time.Now()})
+			case <-timer.C:
+				break coalesce
+			}
+		}
+		timer.Stop()
+		a.lastWatchEvent.Store(time.Now())
+
+		info := a.loadTokenInfo()
+		if info.uninitialized {
+			// The batch determines the new state, so it doesn't matter that the
+			// cache hasn't been primed
+			info = tokenInfo{}
+		}
+		for _, r := range batch {
+			ev := r.Event
+			switch ev.Type {
+			case watch.EventPut:
+				var key string
+				var record ec.EnterpriseRecord
+				if err := ev.Unmarshal(&key, &record); err != nil {
+					return fmt.Errorf("could not unmarshal enterprise record: %s", err.Error())
+				}
+				var err error
+				info, err = newTokenInfo(&record)
+				if err != nil {
+					return err
+				}
+				if record.Written != nil {
+					if written, err := types.TimestampFromProto(record.Written); err == nil {
+						// Measure the lag up to when the event was received, so that
+						// coalescing doesn't inflate it
+						watchLagSeconds.Observe(r.received.Sub(written).Seconds())
+					}
+				}
+			case watch.EventDelete:
+				// The token collection only ever contains enterpriseTokenKey, so any
+				// delete means that the cluster no longer has a token
+				info = tokenInfo{}
+			case watch.EventError:
+				return ev.Err
+			}
+		}
+		a.setTokenInfo(info)
+	}
+}
+
+// setTokenInfo updates the cached tokenInfo and, if the resulting enterprise
+// state differs from the previous one, notifies all subscribers.
+//
+// Subscribers whose buffers are full are given until a single deadline,
+// Options.SubscriberSendTimeout after the first of them is found, to accept the
+// new state, and are then dropped (and their channels closed). Because
+// subscribersMu is held throughout (so that subscribers receive states in
+// order), this bounds how long slow subscribers can stall the watch, and thus
+// every other subscriber, no matter how many of them there are.
+func (a *apiServer) setTokenInfo(info tokenInfo) {
+	a.subscribersMu.Lock()
+	defer a.subscribersMu.Unlock()
+	prevState := a.cachedState()
+	if prev, ok := a.enterpriseInfo.Load().(tokenInfo); !ok || prev.activationCode != info.activationCode {
+		select {
+		case a.inputsStale <- struct{}{}:
+		default: // a refresh is already pending
+		}
+	}
+	now := a.now()
+	prev := a.loadTokenInfo()
+	a.enterpriseInfo.Store(info)
+	updateTokenMetrics(info, time.Now())
+	a.notifyConfirmWaiters(info.expiry)
+	state := a.cachedState()
+	if !prev.uninitialized && state != prevState {
+		a.recordStateEntered(state, now)
+	}
+	// Subscribers are also notified if only the token's features (or those
+	// of them that expire soon) changed, so that WatchState can report them
+	next := a.loadTokenInfo()
+	if state == prevState && sameFeatures(prev.features, next.features) &&
+		sameFeatures(a.expiringFeatures(prev, now), a.expiringFeatures(next, now)) {
+		return
+	}
+	a.notifySubscribers(state)
+}
+
+// featureDiff returns the features in 'next' but not 'prev', and those in
+// 'prev' but not 'next', each sorted
+func featureDiff(prev, next []string) (added []string, removed []string) {
+	inPrev := make(map[string]bool, len(prev))
+	for _, feature := range prev {
+		inPrev[feature] = true
+	}
+	inNext := make(map[string]bool, len(next))
+	for _, feature := range next {
+		inNext[feature] = true
+		if !inPrev[feature] {
+			added = append(added, feature)
+		}
+	}
+	for _, feature := range prev {
+		if !inNext[feature] {
+			removed = append(removed, feature)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// notifySubscribers sends 'state' to every subscriber, dropping any that
+// can't keep up. The caller must hold subscribersMu
+func (a *apiServer) notifySubscribers(state ec.State) {
+	var timer *time.Timer
+	expired := false
+	for ch := range a.subscribers {
+		select {
+		case ch <- state:
+			continue
+		default:
+		}
+		// ch's buffer is full; give the subscriber until the deadline to catch up
+		if !expired {
+			if timer == nil {
+				timer = time.NewTimer(a.options.SubscriberSendTimeout)
+				defer timer.Stop()
+			}
+			select {
+			case ch <- state:
+				continue
+			case <-timer.C:
+				expired = true
+			}
+		}
+		logrus.Printf("dropping enterprise state subscriber that has not read "+
+			"the last %d state changes", cap(ch))
+		delete(a.subscribers, ch)
+		close(ch)
+		slowConsumersTotal.Inc()
+		subscriberCount.Dec()
+	}
+}
+
+// subscribe returns a channel that receives the cluster's new enterprise state
+// every time it changes, and a function that cancels the subscription. The
+// channel is closed if the subscriber falls too far behind (see setTokenInfo)
+func (a *apiServer) subscribe() (<-chan ec.State, func()) {
+	a.subscribersMu.Lock()
+	defer a.subscribersMu.Unlock()
+	ch := make(chan ec.State, subscriberBufferSize)
+	a.subscribers[ch] = struct{}{}
+	subscriberCount.Inc()
+	return ch, func() {
+		a.subscribersMu.Lock()
+		defer a.subscribersMu.Unlock()
+		if _, ok := a.subscribers[ch]; ok {
+			delete(a.subscribers, ch)
+			subscriberCount.Dec()
+		}
+	}
+}
+
+// embeddedKey is ec.PublicKey, parsed once when the package is initialized. If
+// it can't be parsed, embeddedKeyErr is set instead, and NewEnterpriseServer
+// fails (unless Options.PublicKeys replaces it)
+var embeddedKey, embeddedKeyErr = ec.ParsePublicKey(ec.PublicKey)
+
+// parseTrustedKeys returns the keys that a server configured with 'options'
+// initially trusts, and where they came from: Options.PublicKeys if set, or
+// else the embedded key
+func parseTrustedKeys(options Options) ([]*rsa.PublicKey, ec.TrustedKeySource, error) {
+	if len(options.PublicKeys) == 0 {
+		if embeddedKeyErr != nil {
+			return nil, 0, fmt.Errorf("could not parse the embedded public key: %s", embeddedKeyErr.Error())
+		}
+		return []*rsa.PublicKey{embeddedKey}, ec.TrustedKeySource_EMBEDDED, nil
+	}
+	keys := make([]*rsa.PublicKey, 0, len(options.PublicKeys))
+	for i, pemKey := range options.PublicKeys {
+		key, err := ec.ParsePublicKey(pemKey)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not parse public key %d: %s", i, err.Error())
+		}
+		keys = append(keys, key)
+	}
+	return keys, options.PublicKeysSource, nil
+}
+
+// trustedKeySet is a set of trusted keys, and where they came from
+type trustedKeySet struct {
+	keys   []*rsa.PublicKey
+	source ec.TrustedKeySource
+}
+
+// setTrustedKeys replaces the set of keys that activation codes may be signed
+// with, as the SetTrustedKeys RPC does. It's safe to call concurrently with
+// Activate, and takes effect for all activation codes validated after it
+// returns. It doesn't affect tokens that have already been activated.
+func (a *apiServer) setTrustedKeys(keys []*rsa.PublicKey) {
+	a.storeTrustedKeys(keys, ec.TrustedKeySource_SET_BY_RPC)
+}
+
+// storeTrustedKeys is like setTrustedKeys, but records that the keys came
+// from 'source'
+func (a *apiServer) storeTrustedKeys(keys []*rsa.PublicKey, source ec.TrustedKeySource) {
+	a.trustedKeys.Store(&trustedKeySet{
+		keys:   append([]*rsa.PublicKey(nil), keys...),
+		source: source,
+	})
+}
+
+// SetTrustedKeys implements the SetTrustedKeys RPC
+func (a *apiServer) SetTrustedKeys(ctx context.Context, req *ec.SetTrustedKeysRequest) (resp *ec.SetTrustedKeysResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if a.options.JWKSURL != "" {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "trusted keys are "+
+			"fetched from %s, and can't be set", redactURL(a.options.JWKSURL))
+	}
+	if len(req.PublicKeys) == 0 {
+		return nil, fmt.Errorf("invalid request: at least one public key must be trusted")
+	}
+	keys := make([]*rsa.PublicKey, 0, len(req.PublicKeys))
+	for i, pemKey := range req.PublicKeys {
+		key, err := ec.ParsePublicKey(pemKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request: public key %d: %s", i, err.Error())
+		}
+		keys = append(keys, key)
+	}
+	a.setTrustedKeys(keys)
+	return &ec.SetTrustedKeysResponse{}, nil
+}
+
+// ListTrustedKeys implements the ListTrustedKeys RPC. The keys fetched from
+// Options.JWKSURL are listed if it's set, as they're trusted instead of any
+// others.
+func (a *apiServer) ListTrustedKeys(ctx context.Context, req *ec.ListTrustedKeysRequest) (resp *ec.ListTrustedKeysResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var set trustedKeySet
+	if a.options.JWKSURL != "" {
+		if jwks, ok := a.jwks.Load().(*jwkSet); ok && jwks != nil {
+			set = trustedKeySet{keys: jwks.keys, source: ec.TrustedKeySource_JWKS}
+		}
+	} else if trusted, ok := a.trustedKeys.Load().(*trustedKeySet); ok {
+		set = *trusted
+	}
+	resp = &ec.ListTrustedKeysResponse{}
+	for _, key := range set.keys {
+		resp.Keys = append(resp.Keys, &ec.TrustedKey{
+			Fingerprint: ec.KeyID(key),
+			Algorithm:   fmt.Sprintf("RSA-%d", key.N.BitLen()),
+			Source:      set.source,
+		})
+	}
+	return resp, nil
+}
+
+// trimActivationCode removes the whitespace that often surrounds activation
+// codes pasted from emails and terminals, and returns an InvalidArgument error
+// if nothing else is left (rather than failing to decode the code)
+func trimActivationCode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", grpc.Errorf(codes.InvalidArgument, "invalid request: activation code is required")
+	}
+	return code, nil
+}
+
+// validateActivationCode checks the validity of an activation code, which
+// must be signed by one of 'keys', and if it is valid, returns the record
+// that Activate should store for it. The code is verified by ec.VerifyCode,
+// so that pachctl can verify codes offline in exactly the same way.
+func validateActivationCode(code string, keys []*rsa.PublicKey) (record *ec.EnterpriseRecord, err error) {
+	return validateActivationCodeAsOf(code, keys, time.Now())
+}
+
+// validateActivationCodeAsOf is like validateActivationCode, but checks the
+// code's expiry against 'asOf', rather than now (see ec.VerifyCodeAsOf)
+func validateActivationCodeAsOf(code string, keys []*rsa.PublicKey, asOf time.Time) (record *ec.EnterpriseRecord, err error) {
+	if code, err = trimActivationCode(code); err != nil {
+		return nil, err
+	}
+	claims, err := ec.VerifyCodeAsOf(code, keys, asOf)
+	if err != nil {
+		verr, ok := err.(*ec.VerificationError)
+		if !ok {
+			return nil, err
+		}
+		err := newActivationError(verr.Reason, "%s", verr.Message)
+		if !verr.Expires.IsZero() {
+			err.expires, _ = types.TimestampProto(verr.Expires)
+		}
+		return nil, err
+	}
+	// VerifyCode has checked that the timestamps are in range
+	expiryProto, err := types.TimestampProto(claims.Expires)
+	if err != nil {
+		return nil, err
+	}
+	var issuedAtProto *types.Timestamp
+	if !claims.IssuedAt.IsZero() {
+		if issuedAtProto, err = types.TimestampProto(claims.IssuedAt); err != nil {
+			return nil, err
+		}
+	}
+	var featureExpiries map[string]*types.Timestamp
+	for feature, expiry := range claims.FeatureExpiries {
+		if featureExpiries == nil {
+			featureExpiries = make(map[string]*types.Timestamp)
+		}
+		if featureExpiries[feature], err = types.TimestampProto(expiry); err != nil {
+			return nil, err
+		}
+	}
+	return &ec.EnterpriseRecord{
+		ActivationCode:     code,
+		Expires:            expiryProto,
+		MaxNodes:           claims.MaxNodes,
+		MaxPipelines:       claims.MaxPipelines,
+		MaxStorageBytes:    claims.MaxStorageBytes,
+		Serial:             claims.Serial,
+		Environment:        claims.Environment,
+		IssuedAt:           issuedAtProto,
+		Features:           claims.Features,
+		KeyID:              claims.KeyID,
+		SchemaVersion:      int64(claims.SchemaVersion),
+		SLA:                claims.SLA,
+		Product:            claims.Product,
+		DeprecatedFeatures: claims.DeprecatedFeatures,
+		FeatureExpiries:    featureExpiries,
+		MinVersion:         claims.MinVersion,
+		MaxVersion:         claims.MaxVersion,
+	}, nil
+}
+
+// historyKey returns the key of the activation history entry for an
+// activation at time 't'. 'seq' orders activations made at the same instant.
+// Keys sort in activation order (including keys written before the sequence
+// suffix was added, which consist only of the time).
+func historyKey(t time.Time, seq uint64) string {
+	return fmt.Sprintf("%019d-%020d", t.UnixNano(), seq)
+}
+
+// Activate implements the Activate RPC
+func (a *apiServer) Activate(ctx context.Context, req *ec.ActivateRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	record, err := a.validate(req.ActivationCode)
+	if err != nil {
+		return nil, err
+	}
+	if req.LeaseTTL != nil {
+		if err := checkLease(record, req.LeaseTTL, a.now()); err != nil {
+			return nil, toGRPCError(err, "error validating activation code: ")
+		}
+	}
+	if err := a.writeRecord(ctx, record, req.Force, req.LeaseTTL != nil); err != nil {
+		return nil, err
+	}
+	return a.activateResponse(record)
+}
+
+// checkLease returns an error if the token in 'record' doesn't expire within
+// leaseExpiryTolerance of the end of a lease of length 'leaseTTL' starting at
+// 'now'. The lease that the token is stored with isn't 'leaseTTL' long, but
+// ends at its leaseEnd, so a lease that ends before the token expires never
+// deletes it early.
+func checkLease(record *ec.EnterpriseRecord, leaseTTL *types.Duration, now time.Time) error {
+	ttl, err := types.DurationFromProto(leaseTTL)
+	if err != nil || ttl <= 0 {
+		return fmt.Errorf("invalid request: lease_ttl must be a positive duration")
+	}
+	expiry, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return fmt.Errorf("could not parse expiration timestamp: %s", err.Error())
+	}
+	leaseEnd := now.Add(ttl)
+	diff := expiry.Sub(leaseEnd)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > leaseExpiryTolerance {
+		return newActivationError(ec.ActivationErrorReason_LEASE_MISMATCH,
+			"the activation code expires at %s, but the requested %v lease ends at "+
+				"%s (more than %v apart)", expiry.Format(time.RFC3339), ttl,
+			leaseEnd.Format(time.RFC3339), leaseExpiryTolerance)
+	}
+	return nil
+}
+
+// ActivateFromURL implements the ActivateFromURL RPC
+func (a *apiServer) ActivateFromURL(ctx context.Context, req *ec.ActivateFromURLRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	code, err := a.fetchActivationCode(ctx, req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading activation code: %s", err.Error())
+	}
+	record, err := a.activate(ctx, code, req.Force)
+	if err != nil {
+		return nil, err
+	}
+	return a.activateResponse(record)
+}
 
-	// enterpriseTokenKey is the constant key we use that maps to an Enterprise
-	// token that a user has given us. This is what we check to know if a
-	// Pachyderm cluster supports enterprise features
-	enterpriseTokenKey = "token"
-)
+// ActivateDetached implements the ActivateDetached RPC
+func (a *apiServer) ActivateDetached(ctx context.Context, req *ec.ActivateDetachedRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	if req.Token == "" || req.Signature == "" {
+		return nil, fmt.Errorf("invalid request: both the token and the signature must be set")
+	}
+	code, err := ec.DetachedActivationCode(req.Token, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	record, err := a.activate(ctx, code, req.Force)
+	if err != nil {
+		return nil, err
+	}
+	return a.activateResponse(record)
+}
 
-type apiServer struct {
-	pachLogger log.Logger
-	etcdClient *etcd.Client
+// fetchActivationCode downloads an activation code from 'rawURL', which must be
+// an HTTPS URL whose host is in Options.ActivationURLHosts. Redirects are
+// followed only if their targets satisfy the same requirements. Query strings
+// are removed from the URLs in any error returned, as they may contain
+// credentials (e.g. in signed URLs).
+func (a *apiServer) fetchActivationCode(ctx context.Context, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("could not parse URL %q", redactURL(rawURL))
+	}
+	if err := a.checkActivationURL(u); err != nil {
+		return "", err
+	}
 
-	// enterpriseState is a cached timestamp, indicating when the current
-	// Pachyderm Enterprise token will expire (or 0 if there is no Pachyderm
-	// Enterprise token
-	enterpriseExpiry atomic.Value
+	ctx, cancel := context.WithTimeout(ctx, a.options.ActivationURLTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	// Copy the client, so that its other settings (e.g. its transport) are
+	// kept, but redirects are checked
+	client := *a.options.HTTPClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxActivationURLRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxActivationURLRedirects)
+		}
+		return a.checkActivationURL(req.URL)
+	}
+	httpResp, err := client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = redactURL(urlErr.URL)
+		}
+		return "", err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response status %q", httpResp.Status)
+	}
+	// Read one byte past the limit, so that oversized responses can be detected
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxActivationCodeSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxActivationCodeSize {
+		return "", fmt.Errorf("response is larger than the maximum activation code size (%d bytes)", maxActivationCodeSize)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
 
-	// enterpriseToken is a collection containing at most one Pachyderm enterprise
-	// token
-	enterpriseToken col.Collection
+// checkActivationURL returns an error if activation codes may not be
+// downloaded from 'u', because it isn't an HTTPS URL or its host isn't in
+// Options.ActivationURLHosts
+func (a *apiServer) checkActivationURL(u *url.URL) error {
+	if u.Scheme != "https" {
+		return fmt.Errorf("activation codes may only be downloaded over https, not %q", u.Scheme)
+	}
+	for _, host := range a.options.ActivationURLHosts {
+		if strings.EqualFold(host, u.Hostname()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q is not allowed to serve activation codes", u.Hostname())
 }
 
-// NewEnterpriseServer returns an implementation of ec.APIServer.
-func NewEnterpriseServer(etcdAddress string, etcdPrefix string) (ec.APIServer, error) {
-	etcdClient, err := etcd.New(etcd.Config{
-		Endpoints:   []string{etcdAddress},
-		DialOptions: client.EtcdDialOptions(),
-	})
+// redactURL returns 'rawURL' without its query string, fragment or user info
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("error constructing etcdClient: %s", err.Error())
+		return "<unparseable URL>"
 	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
 
-	s := &apiServer{
-		pachLogger: log.NewLogger("enterprise.API"),
-		etcdClient: etcdClient,
-		enterpriseToken: col.NewCollection(
-			etcdClient,
-			etcdPrefix, // enterprise API only has one collection, no extra prefix needed
-			nil,
-			&types.Timestamp{},
-			nil,
-		),
-	}
-	s.enterpriseExpiry.Store(time.Time{})
-	go s.watchEnterpriseToken(etcdPrefix)
-	return s, nil
+// checkEnvironment returns an error if 'record' was issued for a different
+// environment than the cluster's
+func (a *apiServer) checkEnvironment(record *ec.EnterpriseRecord) error {
+	if record.Environment != "" && a.env != "" && record.Environment != a.env {
+		return newActivationError(ec.ActivationErrorReason_WRONG_ENVIRONMENT,
+			"the activation code was issued for the %q environment, but this cluster "+
+				"is in the %q environment", record.Environment, a.env)
+	}
+	return nil
 }
 
-func (a *apiServer) watchEnterpriseToken(etcdPrefix string) {
-	backoff.RetryNotify(func() error {
-		// Watch for incoming enterprise tokens
-		watcher, err := a.enterpriseToken.ReadOnly(context.Background()).Watch()
+// checkProduct returns an error if 'record' was issued for a different
+// product than Options.ProductID (defaultProductID, if unset)
+func (a *apiServer) checkProduct(record *ec.EnterpriseRecord) error {
+	product, strict := a.options.ProductID, a.options.ProductID != ""
+	if !strict {
+		product = defaultProductID
+	}
+	if record.Product == "" {
+		if strict {
+			return newActivationError(ec.ActivationErrorReason_WRONG_PRODUCT,
+				"the activation code doesn't name a product, but this cluster "+
+					"requires codes issued for %q", product)
+		}
+		return nil
+	}
+	if record.Product != product {
+		return newActivationError(ec.ActivationErrorReason_WRONG_PRODUCT,
+			"the activation code was issued for %q, not %q", record.Product, product)
+	}
+	return nil
+}
+
+// checkVersion returns an error if 'record' names a range of Pachyderm
+// versions that doesn't include pachVersion
+func (a *apiServer) checkVersion(record *ec.EnterpriseRecord) error {
+	if record.MinVersion == "" && record.MaxVersion == "" {
+		return nil
+	}
+	ok, err := ec.VersionInRange(a.pachVersion, record.MinVersion, record.MaxVersion)
+	if err != nil {
+		return fmt.Errorf("could not check the activation code's version range: %v", err)
+	}
+	if !ok {
+		min, max := record.MinVersion, record.MaxVersion
+		if min == "" {
+			min = "any"
+		}
+		if max == "" {
+			max = "any"
+		}
+		return newActivationError(ec.ActivationErrorReason_UNSUPPORTED_VERSION,
+			"the activation code may only be activated on Pachyderm versions %s "+
+				"through %s, but this cluster runs version %s", min, max, a.pachVersion)
+	}
+	return nil
+}
+
+// checkRemaining returns an error if the token in 'record' expires less than
+// Options.MinRemaining from now
+func (a *apiServer) checkRemaining(record *ec.EnterpriseRecord) error {
+	if a.options.MinRemaining == 0 {
+		return nil
+	}
+	info, err := newTokenInfo(record)
+	if err != nil {
+		return err
+	}
+	if remaining := a.effectiveExpiry(info).Sub(a.now()); remaining < a.options.MinRemaining {
+		return newActivationError(ec.ActivationErrorReason_EXPIRES_TOO_SOON,
+			"the activation code expires in %v, but must have at least %v "+
+				"remaining; set Force to activate it anyway",
+			remaining.Round(time.Second), a.options.MinRemaining)
+	}
+	return nil
+}
+
+// checkRecordSize returns an error if 'record', encoded with 'codec', is
+// larger than Options.MaxRecordSize
+func (a *apiServer) checkRecordSize(codec col.Codec, record *ec.EnterpriseRecord) error {
+	data, err := codec.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if len(data) > a.options.MaxRecordSize {
+		return fmt.Errorf("invalid request: the enterprise record would be %d "+
+			"bytes, which is larger than the maximum record size (%d bytes)",
+			len(data), a.options.MaxRecordSize)
+	}
+	return nil
+}
+
+// checkSchemaVersion returns an error if the token in 'record' uses an older
+// schema than Options.MinSchemaVersion
+func (a *apiServer) checkSchemaVersion(record *ec.EnterpriseRecord) error {
+	if record.SchemaVersion < int64(a.options.MinSchemaVersion) {
+		return newActivationError(ec.ActivationErrorReason_SCHEMA_TOO_OLD,
+			"code schema too old, please obtain a new code (the code's schema "+
+				"version is %d, but this cluster requires at least version %d)",
+			record.SchemaVersion, a.options.MinSchemaVersion)
+	}
+	return nil
+}
+
+// validate checks that 'code' is a valid activation code, signed by one of
+// the server's trusted keys and issued for its environment, and returns the
+// record that activating it would write
+func (a *apiServer) validate(code string) (*ec.EnterpriseRecord, error) {
+	record, err := a.validateCode(code)
+	if err != nil {
+		details := ec.GetActivationErrorDetails(err)
+		if details != nil && details.Reason == ec.ActivationErrorReason_INVALID_SIGNATURE {
+			a.recordSignatureFailure(time.Now())
+		}
+		return nil, err
+	}
+	return record, nil
+}
+
+// validateCode implements validate, without tracking signature failures
+func (a *apiServer) validateCode(code string) (*ec.EnterpriseRecord, error) {
+	return a.validateCodeAsOf(code, time.Now())
+}
+
+// validateCodeAsOf is like validateCode, but checks the code's expiry against
+// 'asOf', rather than now
+func (a *apiServer) validateCodeAsOf(code string, asOf time.Time) (*ec.EnterpriseRecord, error) {
+	code, err := trimActivationCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > maxActivationCodeSize {
+		return nil, fmt.Errorf("invalid request: activation code is larger than the "+
+			"maximum activation code size (%d bytes)", maxActivationCodeSize)
+	}
+	backup := decodeEnvelope(code).Backup
+	var keys []*rsa.PublicKey
+	if backup {
+		if keys = a.backupKeys; len(keys) == 0 {
+			return nil, toGRPCError(newActivationError(ec.ActivationErrorReason_INVALID_SIGNATURE,
+				"the activation code is flagged as signed by the backup signing "+
+					"authority, but no backup keys are configured"), "error validating activation code: ")
+		}
+	} else if a.options.JWKSURL != "" {
+		if keys, err = a.jwksKeys(codeKeyID(code)); err != nil {
+			return nil, err
+		}
+	} else {
+		trusted, ok := a.trustedKeys.Load().(*trustedKeySet)
+		if !ok {
+			return nil, fmt.Errorf("could not retrieve trusted keys")
+		}
+		keys = trusted.keys
+	}
+	record, err := validateActivationCodeAsOf(code, keys, asOf)
+	if err != nil {
+		return nil, toGRPCError(err, "error validating activation code: ")
+	}
+	if err := a.checkEnvironment(record); err != nil {
+		return nil, toGRPCError(err, "error validating activation code: ")
+	}
+	if err := a.checkProduct(record); err != nil {
+		return nil, toGRPCError(err, "error validating activation code: ")
+	}
+	if err := a.checkSchemaVersion(record); err != nil {
+		return nil, toGRPCError(err, "error validating activation code: ")
+	}
+	if err := a.checkVersion(record); err != nil {
+		return nil, toGRPCError(err, "error validating activation code: ")
+	}
+	if backup {
+		logBackupCode(record)
+	}
+	return record, nil
+}
+
+// activate validates 'code' and, if it's valid and was issued for the
+// cluster's environment, stores it in etcd. If
+// Options.RequireMonotonicActivation is set, the code must also have a greater
+// serial than the current token, unless 'force' is set.
+func (a *apiServer) activate(ctx context.Context, code string, force bool) (*ec.EnterpriseRecord, error) {
+	record, err := a.validate(code)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.writeRecord(ctx, record, force, false); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// writeRecord stores 'record', which was returned by validate, in etcd as the
+// cluster's token, and adds it to the activation history. If 'leased' is set,
+// the token is attached to an etcd lease that ends once its grace period has
+// passed (see leaseEnd), which reconcileLease keeps in line with the record.
+// If Options.ConfirmActivationTimeout is set, it then waits for the cache to
+// reflect the record.
+func (a *apiServer) writeRecord(ctx context.Context, record *ec.EnterpriseRecord, force bool, leased bool) error {
+	return a.writeRecordIf(ctx, record, force, leased, nil)
+}
+
+// writeRecordIf is like writeRecord, but if 'precondition' is set and the
+// cluster has a token, it's called with the token in the same transaction as
+// the write, and the record isn't written if it returns an error (which
+// writeRecordIf returns as-is)
+func (a *apiServer) writeRecordIf(ctx context.Context, record *ec.EnterpriseRecord, force bool, leased bool,
+	precondition func(current *ec.EnterpriseRecord) error) error {
+	if !force {
+		if err := a.checkRemaining(record); err != nil {
+			return toGRPCError(err, "error validating activation code: ")
+		}
+	}
+	// renewed is set if the record replaces an existing token, for the audit
+	// event
+	renewed := false
+	a.mutateMu.Lock()
+	_, err := col.NewSTM(ctx, a.conn().etcdClient, func(stm col.STM) error {
+		e := a.conn().enterpriseToken.ReadWrite(stm)
+		now := time.Now()
+		written, err := types.TimestampProto(now)
 		if err != nil {
 			return err
 		}
-		defer watcher.Close()
-		for {
-			ev, ok := <-watcher.Watch()
-			if !ok {
-				return errors.New("admin watch closed unexpectedly")
+		record.Written = written
+		record.ActivatedAt = written
+		// A token that can't be decoded is still replaced, unless its serial
+		// needs checking
+		var current ec.EnterpriseRecord
+		getErr := e.Get(enterpriseTokenKey, &current)
+		_, notFound := getErr.(col.ErrNotFound)
+		renewed = !notFound
+		if precondition != nil {
+			if getErr != nil && !notFound {
+				return tokenReadError(getErr)
+			} else if getErr == nil {
+				if err := precondition(&current); err != nil {
+					return err
+				}
 			}
-
-			// Parse event data and potentially update adminCache
-			var key string
-			var record ec.EnterpriseRecord
-			ev.Unmarshal(&key, &record)
-			expiry, err := types.TimestampFromProto(record.Expires)
+		}
+		if a.options.RequireMonotonicActivation && !force {
+			if getErr != nil && !notFound {
+				return tokenReadError(getErr)
+			} else if getErr == nil && record.Serial <= current.Serial {
+				return newActivationError(ec.ActivationErrorReason_SERIAL_NOT_INCREASING,
+					"the activation code's serial (%d) must be greater than that of the "+
+						"current token (%d); set Force to activate it anyway",
+					record.Serial, current.Serial)
+			}
+		}
+		if leased {
+			end, err := a.leaseEnd(record)
 			if err != nil {
-				return fmt.Errorf("could not parse expiration timestamp: %s", err.Error())
+				return err
 			}
-			switch ev.Type {
-			case watch.EventPut:
-				a.enterpriseExpiry.Store(expiry)
-			case watch.EventDelete:
-				cachedExpiry, ok := a.enterpriseExpiry.Load().(time.Time)
-				if !ok {
-					return errors.New("could not retrieve cached expiration time")
-				}
-				if expiry == cachedExpiry {
-					// unexpected, but we'll dutifully unset the expiration time if it
-					// does
-					a.enterpriseExpiry.Store(time.Time{})
-				}
-			case watch.EventError:
-				return ev.Err
+			leaseNow := a.now()
+			seconds := leaseSeconds(end.Sub(leaseNow))
+			leaseExpires, err := types.TimestampProto(leaseNow.Add(time.Duration(seconds) * time.Second))
+			if err != nil {
+				return err
+			}
+			record.LeaseExpires = leaseExpires
+			if err := a.checkRecordSize(a.conn().codec, record); err != nil {
+				return err
 			}
+			if err := e.PutTTL(enterpriseTokenKey, record, seconds); err != nil {
+				return err
+			}
+		} else {
+			record.LeaseExpires = nil
+			if err := a.checkRecordSize(a.conn().codec, record); err != nil {
+				return err
+			}
+			e.Put(enterpriseTokenKey, record)
 		}
-	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
-		logrus.Printf("error from activation check: %v; retrying in %v", err, d)
-		return nil
+		return a.putHistoryRecord(stm, now, &ec.ActivationHistoryRecord{
+			Activated:                 written,
+			Expires:                   record.Expires,
+			ActivationCodeFingerprint: CodeFingerprint(record.ActivationCode),
+			Features:                  record.Features,
+		})
 	})
+	a.mutateMu.Unlock()
+	if err != nil {
+		return storeError(err)
+	}
+	if renewed {
+		a.audit(ctx, AuditRenew, record.ActivationCode)
+	} else {
+		a.audit(ctx, AuditActivate, record.ActivationCode)
+	}
+	a.awaitCachedRecord(ctx, record)
+	return nil
 }
 
-type activationCode struct {
-	Token     string
-	Signature string
+// activateResponse describes the state of the cluster once 'record' (which
+// was just written by activate) is its enterprise token. It doesn't consult
+// the cache, which may not have been updated yet.
+func (a *apiServer) activateResponse(record *ec.EnterpriseRecord) (*ec.ActivateResponse, error) {
+	info, err := newTokenInfo(record)
+	if err != nil {
+		return nil, err
+	}
+	// Don't wait for the watch to update the token metrics
+	updateTokenMetrics(info, time.Now())
+	return &ec.ActivateResponse{
+		State:   a.state(info, a.now()),
+		Expires: record.Expires,
+		KeyID:   record.KeyID,
+	}, nil
+}
+
+// WatchState implements the WatchState RPC
+func (a *apiServer) WatchState(req *ec.WatchStateRequest, server ec.API_WatchStateServer) error {
+	states, unsubscribe := a.subscribe()
+	defer unsubscribe()
+	// Subscribe before reading the current state, so that no change is missed
+	info, err := a.cachedTokenInfo(server.Context())
+	if err != nil {
+		return err
+	}
+	// 'last' is the most recent state observed, which is only sent if it's
+	// in req.StateMask, 'features' are the token's features as of the last
+	// response, which later responses report the changes to, and 'expiring'
+	// are the features that were expiring soon as of the last response
+	now := a.now()
+	last := a.state(info, now)
+	features := info.features
+	expiring := a.expiringFeatures(info, now)
+	if req.SendInitial == nil || req.SendInitial.Value {
+		if err := server.Send(&ec.WatchStateResponse{
+			State:            last,
+			ExpiringFeatures: expiring,
+		}); err != nil {
+			return err
+		}
+	}
+	for {
+		// Tokens (and their features) expire without being updated, so
+		// subscribers aren't notified of it; wake up when the current token
+		// expires, or a feature enters its warning window, to send that change
+		var expired <-chan time.Time
+		var timer *time.Timer
+		now := a.now()
+		d, ok := a.untilStateChange(info, now)
+		if featureD, featureOK := a.untilExpiringFeaturesChange(info, now); featureOK && (!ok || featureD < d) {
+			d, ok = featureD, true
+		}
+		if ok {
+			timer = time.NewTimer(d)
+			expired = timer.C
+		}
+		var state ec.State
+		select {
+		case s, ok := <-states:
+			if !ok {
+				return grpc.Errorf(codes.ResourceExhausted, "WatchState caller fell too far behind")
+			}
+			state = s
+		case <-expired:
+			state = a.state(info, a.now())
+		case <-server.Context().Done():
+			return server.Context().Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if info, err = a.cachedTokenInfo(server.Context()); err != nil {
+			return err
+		}
+		// A time-based change may already have been observed
+		added, removed := featureDiff(features, info.features)
+		nowExpiring := a.expiringFeatures(info, a.now())
+		if state != last || len(added) > 0 || len(removed) > 0 || !sameFeatures(expiring, nowExpiring) {
+			if inStateMask(req.StateMask, state) {
+				if err := server.Send(&ec.WatchStateResponse{
+					State:            state,
+					AddedFeatures:    added,
+					RemovedFeatures:  removed,
+					ExpiringFeatures: nowExpiring,
+				}); err != nil {
+					return err
+				}
+				features = info.features
+				expiring = nowExpiring
+			}
+			last = state
+		}
+	}
 }
 
-type token struct {
-	Expiry string
+// inStateMask returns true if 'state' matches 'mask', which matches every
+// state if it's empty
+func inStateMask(mask []ec.State, state ec.State) bool {
+	if len(mask) == 0 {
+		return true
+	}
+	for _, s := range mask {
+		if s == state {
+			return true
+		}
+	}
+	return false
 }
 
-// validateActivationCode checks the validity of an activation code
-func validateActivationCode(code string) (expiry time.Time, err error) {
-	// Parse the public key.  If these steps fail, something is seriously
-	// wrong and we should crash the service by panicking.
-	block, _ := pem.Decode([]byte(publicKey))
-	if block == nil {
-		return time.Time{}, fmt.Errorf("failed to pem decode public key")
+// Deactivate implements the Deactivate RPC. It only deletes the token that it
+// read, so that a token activated concurrently isn't deleted by mistake
+func (a *apiServer) Deactivate(ctx context.Context, req *ec.DeactivateRequest) (resp *ec.DeactivateResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
 	}
-	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse DER encoded public key: %s", err.Error())
+	if err := a.checkMaintenance(); err != nil {
+		return nil, err
 	}
-	rsaPub, ok := pub.(*rsa.PublicKey)
-	if !ok {
-		return time.Time{}, fmt.Errorf("public key isn't an RSA key")
+	a.mutateMu.Lock()
+	defer a.mutateMu.Unlock()
+	var observed ec.EnterpriseRecord
+	if err := a.conn().enterpriseToken.ReadOnly(ctx).Get(enterpriseTokenKey, &observed); err != nil {
+		if _, ok := err.(col.ErrNotFound); ok {
+			return &ec.DeactivateResponse{AlreadyInactive: true}, nil
+		}
+		return nil, tokenReadError(err)
 	}
-
-	// Decode the base64-encoded activation code
-	decodedActivationCode, err := base64.StdEncoding.DecodeString(code)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("activation code is not base64 encoded")
+	var deleted bool
+	if _, err := col.NewSTM(ctx, a.conn().etcdClient, func(stm col.STM) error {
+		var err error
+		deleted, err = a.conn().enterpriseToken.ReadWrite(stm).CompareAndDelete(enterpriseTokenKey, &observed)
+		return err
+	}); err != nil {
+		return nil, err
 	}
-	activationCode := &activationCode{}
-	if err := json.Unmarshal(decodedActivationCode, &activationCode); err != nil {
-		return time.Time{}, fmt.Errorf("activation code is not valid JSON")
+	if !deleted {
+		// Only possible if another server changed the token (see mutateMu)
+		return nil, grpc.Errorf(codes.Aborted, "the enterprise token changed while it "+
+			"was being deactivated; the new token has not been deactivated")
 	}
+	a.audit(ctx, AuditDeactivate, observed.ActivationCode)
+	return &ec.DeactivateResponse{}, nil
+}
 
-	// Decode the signature
-	decodedSignature, err := base64.StdEncoding.DecodeString(activationCode.Signature)
+// GetState implements the GetState RPC. It is served entirely from the cached
+// tokenInfo, which is primed by start() and kept current by
+// watchEnterpriseToken(), so it never contacts etcd (even when the cluster has
+// no token at all), unless it's called before the cache has been primed
+func (a *apiServer) GetState(ctx context.Context, req *ec.GetStateRequest) (resp *ec.GetStateResponse, retErr error) {
+	info, err := a.cachedTokenInfo(ctx)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("signature is not base64 encoded")
+		return nil, err
+	}
+	now := a.now()
+	resp = &ec.GetStateResponse{
+		State:          a.state(info, now),
+		Warnings:       a.warnings(),
+		NextCheckAfter: types.DurationProto(a.nextCheckAfter(info, now)),
+	}
+	if resp.StateEnteredAt, err = types.TimestampProto(a.stateEnteredAt(info, now)); err != nil {
+		return nil, err
 	}
+	if req.IncludeEffectiveState {
+		resp.State = a.rawState(info, now)
+		resp.EffectiveState = a.effectiveState(info, now)
+	}
+	// If etcd has been unreachable for a while, still serve the last known
+	// state, but tell the caller that it may be out of date, and when etcd was
+	// last known to be reachable
+	if since, ok := a.disconnectedSince.Load().(time.Time); ok && !since.IsZero() &&
+		time.Since(since) > a.options.StaleThreshold {
+		resp.Stale = true
+		lastHealthy, _ := a.lastHealthy.Load().(time.Time)
+		resp.LastUpdated, err = types.TimestampProto(lastHealthy)
+		if err != nil {
+			return nil, err
+		}
+	} else if atomic.LoadInt32(&a.watchStalled) > 0 {
+		// Likewise if etcd is reachable, but has stopped delivering events
+		resp.Stale = true
+		lastRoundTrip, _ := a.lastRoundTrip.Load().(time.Time)
+		resp.LastUpdated, err = types.TimestampProto(lastRoundTrip)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
 
-	// Compute the sha256 checksum of the token
-	hashedToken := sha256.Sum256([]byte(activationCode.Token))
+// RefreshState implements the RefreshState RPC. Unlike GetState, it re-reads
+// the enterprise token from etcd before answering
+func (a *apiServer) RefreshState(ctx context.Context, req *ec.RefreshStateRequest) (resp *ec.RefreshStateResponse, retErr error) {
+	if err := a.refreshState(ctx); err != nil {
+		return nil, fmt.Errorf("error refreshing enterprise state: %s", err.Error())
+	}
+	return &ec.RefreshStateResponse{State: a.cachedState()}, nil
+}
 
-	// Verify that the signature is valid
-	if err := rsa.VerifyPKCS1v15(rsaPub, crypto.SHA256, hashedToken[:], decodedSignature); err != nil {
-		return time.Time{}, fmt.Errorf("invalid signature in activation code")
+// GetQuota implements the GetQuota RPC
+func (a *apiServer) GetQuota(ctx context.Context, req *ec.GetQuotaRequest) (resp *ec.GetQuotaResponse, retErr error) {
+	info, err := a.cachedTokenInfo(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return &ec.GetQuotaResponse{
+		State:           a.state(info, a.now()),
+		MaxNodes:        info.maxNodes,
+		MaxPipelines:    info.maxPipelines,
+		MaxStorageBytes: info.maxStorageBytes,
+	}, nil
+}
 
-	// Unmarshal the token
-	token := token{}
-	if err := json.Unmarshal([]byte(activationCode.Token), &token); err != nil {
-		return time.Time{}, fmt.Errorf("token is not valid JSON")
+// GetActivationInfo implements the GetActivationInfo RPC. Like GetState, it's
+// served from the cache, except for RecordHash (see recordHash)
+func (a *apiServer) GetActivationInfo(ctx context.Context, req *ec.GetActivationInfoRequest) (resp *ec.GetActivationInfoResponse, retErr error) {
+	info, err := a.cachedTokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &ec.GetActivationInfoResponse{
+		State:              a.state(info, a.now()),
+		KeyID:              info.keyID,
+		SLA:                info.sla,
+		DeprecatedFeatures: info.deprecatedFeatures,
+	}
+	if resp.RecordHash, err = a.recordHash(ctx); err != nil {
+		return nil, err
+	}
+	if !info.expiry.IsZero() {
+		if resp.Expires, err = types.TimestampProto(info.expiry); err != nil {
+			return nil, err
+		}
+	}
+	if !info.activatedAt.IsZero() {
+		if resp.ActivatedAt, err = types.TimestampProto(info.activatedAt); err != nil {
+			return nil, err
+		}
 	}
+	return resp, nil
+}
 
-	// Parse the expiry
-	expiry, err = time.Parse(time.RFC3339, token.Expiry)
+// recordHash returns the hex-encoded SHA-256 hash of the stored
+// EnterpriseRecord's bytes, or "" if there is no token. It reads the record
+// from etcd rather than re-encoding the cached one, so that the hash always
+// matches the persisted value, however it was written.
+func (a *apiServer) recordHash(ctx context.Context) (string, error) {
+	conn := a.conn()
+	if conn.readClient == nil {
+		return "", nil // there's no etcd (e.g. in tests), and so no record
+	}
+	resp, err := conn.readClient.Get(ctx, conn.enterpriseToken.Path(enterpriseTokenKey))
 	if err != nil {
-		return time.Time{}, fmt.Errorf("expiry is not valid ISO 8601 string")
+		return "", fmt.Errorf("error reading enterprise record: %v", err)
 	}
-	// Check that the activation code has not expired
-	if time.Now().After(expiry) {
-		return time.Time{}, fmt.Errorf("the activation code has expired")
+	if len(resp.Kvs) == 0 {
+		return "", nil
 	}
-	return expiry, nil
+	hash := sha256.Sum256(resp.Kvs[0].Value)
+	return hex.EncodeToString(hash[:]), nil
 }
 
-// Activate implements the Activate RPC
-func (a *apiServer) Activate(ctx context.Context, req *ec.ActivateRequest) (resp *ec.ActivateResponse, retErr error) {
-	// Validate the activation code
-	expiry, err := validateActivationCode(req.ActivationCode)
+// CheckFeatures implements the CheckFeatures RPC
+func (a *apiServer) CheckFeatures(ctx context.Context, req *ec.CheckFeaturesRequest) (resp *ec.CheckFeaturesResponse, retErr error) {
+	if len(req.Names) == 0 {
+		return nil, fmt.Errorf("invalid request: must name at least one feature")
+	}
+	info, err := a.cachedTokenInfo(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error validating activation code: %s", err.Error())
+		return nil, err
 	}
-	if _, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
-		e := a.enterpriseToken.ReadWrite(stm)
-		expiryProto, err := types.TimestampProto(expiry)
-		if err != nil {
-			return err
-		}
-		// blind write
-		e.Put(enterpriseTokenKey, &ec.EnterpriseRecord{
-			ActivationCode: req.ActivationCode,
-			Expires:        expiryProto,
+	active := a.state(info, a.now()) == ec.State_ACTIVE
+	resp = &ec.CheckFeaturesResponse{AllEntitled: true}
+	for _, name := range req.Names {
+		entitled := active && info.hasFeature(name)
+		resp.Features = append(resp.Features, &ec.FeatureEntitlement{
+			Name:        name,
+			Entitled:    entitled,
+			Deprecation: info.deprecatedFeatures[name],
 		})
-		return nil
-	}); err != nil {
+		resp.AllEntitled = resp.AllEntitled && entitled
+	}
+	return resp, nil
+}
+
+// ServerTime implements the ServerTime RPC. It reports now(), i.e. the time
+// that GetState evaluates the token against, including any simulated offset
+func (a *apiServer) ServerTime(ctx context.Context, req *ec.ServerTimeRequest) (resp *ec.ServerTimeResponse, retErr error) {
+	now, err := types.TimestampProto(a.now())
+	if err != nil {
 		return nil, err
 	}
-	return &ec.ActivateResponse{}, nil
+	return &ec.ServerTimeResponse{Time: now}, nil
 }
 
-// GetState implements the GetState RPC, but just returns NotActivatedError
-func (a *apiServer) GetState(ctx context.Context, req *ec.GetStateRequest) (resp *ec.GetStateResponse, retErr error) {
-	expiry, ok := a.enterpriseExpiry.Load().(time.Time)
-	if !ok {
-		return nil, fmt.Errorf("could not retrieve enterprise expiration time")
+// cachedTokenInfo returns the cached tokenInfo. If the cache hasn't been
+// primed yet (e.g. because an RPC arrived before start() read the token), it
+// reads the token from etcd first, so that callers never mistake an
+// uninitialized cache for a cluster with no token.
+func (a *apiServer) cachedTokenInfo(ctx context.Context) (tokenInfo, error) {
+	if info := a.loadTokenInfo(); !info.uninitialized {
+		return info, nil
+	}
+	a.initMu.Lock()
+	defer a.initMu.Unlock()
+	// Check again, in case another RPC primed the cache while we waited
+	if info := a.loadTokenInfo(); info.uninitialized {
+		if err := a.refreshState(ctx); err != nil {
+			return tokenInfo{}, fmt.Errorf("error reading enterprise token: %s", err.Error())
+		}
 	}
-	if expiry.IsZero() {
-		return &ec.GetStateResponse{State: ec.State_NONE}, nil
+	info := a.loadTokenInfo()
+	if info.uninitialized {
+		return tokenInfo{}, fmt.Errorf("could not retrieve cached enterprise token")
 	}
-	if time.Now().After(expiry) {
-		return &ec.GetStateResponse{State: ec.State_EXPIRED}, nil
+	return info, nil
+}
+
+// loadTokenInfo returns the cached tokenInfo. newAPIServer always stores one,
+// but if none has been stored, it returns the zero tokenInfo (i.e. no token)
+// rather than failing, so that callers never report a spurious error.
+func (a *apiServer) loadTokenInfo() tokenInfo {
+	info, _ := a.enterpriseInfo.Load().(tokenInfo)
+	if inj := a.activeInjection(); inj != nil {
+		return inj.apply(info)
+	}
+	return info
+}
+
+// cachedState computes the cluster's enterprise state from the cached
+// tokenInfo. It's NONE if the cache hasn't been primed yet.
+func (a *apiServer) cachedState() ec.State {
+	return a.state(a.loadTokenInfo(), a.now())
+}
+
+// state returns the enterprise state of a cluster whose token is described by
+// 'info', at time 'now'. Tokens in their grace period are still ACTIVE. While
+// a state injection (see InjectState) is in effect, it's the injected state.
+func (a *apiServer) state(info tokenInfo, now time.Time) ec.State {
+	if inj := a.activeInjection(); inj != nil {
+		return inj.state
+	}
+	if info.expiry.IsZero() {
+		return ec.State_NONE
+	}
+	if now.After(a.effectiveExpiry(info).Add(a.options.GracePeriod)) {
+		if a.emergencyOverrideActive(now) {
+			return ec.State_ACTIVE
+		}
+		return ec.State_EXPIRED
+	}
+	return ec.State_ACTIVE
+}
+
+// rawState is like state, but reflects the token alone: tokens are EXPIRED
+// as soon as they expire, regardless of the grace period or any emergency
+// override. A state injection still applies, as it replaces the token.
+func (a *apiServer) rawState(info tokenInfo, now time.Time) ec.State {
+	if inj := a.activeInjection(); inj != nil {
+		return inj.state
+	}
+	if info.expiry.IsZero() {
+		return ec.State_NONE
+	}
+	if now.After(a.effectiveExpiry(info)) {
+		return ec.State_EXPIRED
+	}
+	return ec.State_ACTIVE
+}
+
+// effectiveState describes what the token described by 'info' entitles the
+// cluster to at time 'now', for GetState's effective_state
+func (a *apiServer) effectiveState(info tokenInfo, now time.Time) *ec.EffectiveState {
+	effective := &ec.EffectiveState{
+		State:             a.state(info, now),
+		EmergencyOverride: a.emergencyOverrideActive(now),
+	}
+	if effective.State == ec.State_ACTIVE {
+		effective.Features = info.features
+	}
+	effective.InGracePeriod = a.rawState(info, now) == ec.State_EXPIRED &&
+		!now.After(a.effectiveExpiry(info).Add(a.options.GracePeriod))
+	return effective
+}
+
+// untilStateChange returns how long it will be until the enterprise state of a
+// cluster whose token is described by 'info' changes without the token being
+// updated (i.e. until the token's grace period, or any emergency override,
+// ends and it becomes EXPIRED), or false if that will never happen
+func (a *apiServer) untilStateChange(info tokenInfo, now time.Time) (time.Duration, bool) {
+	if a.state(info, now) != ec.State_ACTIVE {
+		return 0, false
+	}
+	end := a.effectiveExpiry(info).Add(a.options.GracePeriod)
+	if until, ok := a.emergencyOverrideUntil(); ok && until.After(end) {
+		end = until
+	}
+	return end.Sub(now), true
+}
+
+// nextCheckAfter computes how long a client polling GetState can wait before
+// calling it again: half the time remaining until the token's next transition
+// (the start of the expiry warning window, its expiry, or the end of the grace
+// period), bounded by minCheckInterval
+// and maxCheckInterval. The start of the expiry warning window is also a
+// transition, so that clients notice the new warning promptly.
+func (a *apiServer) nextCheckAfter(info tokenInfo, now time.Time) time.Duration {
+	if info.expiry.IsZero() {
+		return maxCheckInterval
+	}
+	expiry := a.effectiveExpiry(info)
+	transitions := []time.Time{
+		expiry.Add(-a.options.ExpiryWarningWindow),
+		expiry,
+		expiry.Add(a.options.GracePeriod),
+	}
+	if until, ok := a.emergencyOverrideUntil(); ok {
+		transitions = append(transitions, until)
+		sort.Slice(transitions, func(i, j int) bool { return transitions[i].Before(transitions[j]) })
+	}
+	for _, transition := range transitions {
+		if !transition.After(now) {
+			continue
+		}
+		d := transition.Sub(now) / 2
+		if d < minCheckInterval {
+			return minCheckInterval
+		}
+		if d > maxCheckInterval {
+			return maxCheckInterval
+		}
+		return d
 	}
-	return &ec.GetStateResponse{State: ec.State_ACTIVE}, nil
+	return maxCheckInterval
 }
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+// AuditEventType is the kind of change to the cluster's token that an
+// AuditEvent describes
+type AuditEventType string
+
+const (
+	// AuditActivate is the activation of a cluster that had no token
+	AuditActivate AuditEventType = "activate"
+	// AuditRenew is the activation of a cluster that already had a token,
+	// which the new token replaced
+	AuditRenew AuditEventType = "renew"
+	// AuditDeactivate is the removal of the cluster's token by Deactivate
+	AuditDeactivate AuditEventType = "deactivate"
+)
+
+// AuditEvent describes a change to the cluster's token, for
+// Options.AuditSink
+type AuditEvent struct {
+	Type AuditEventType
+	// Actor identifies who made the change: the address of the RPC's caller,
+	// or "" if the server made it itself (e.g. by activating a trial)
+	Actor string
+	Time  time.Time
+	// CodeFingerprint is the CodeFingerprint of the activation code that was
+	// activated or, for AuditDeactivate, removed
+	CodeFingerprint string
+}
+
+// NoopAuditSink is the default Options.AuditSink, which discards every event
+func NoopAuditSink(ctx context.Context, event AuditEvent) error {
+	return nil
+}
+
+// auditActor returns the AuditEvent.Actor of the RPC whose context is 'ctx'
+func auditActor(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
+
+// audit sends an event of type 'eventType', for the RPC whose context is
+// 'ctx' and the activation code 'code', to Options.AuditSink. The sink is
+// called in the background, so that a slow or failing sink never blocks the
+// change that it's told about; its errors are logged.
+func (a *apiServer) audit(ctx context.Context, eventType AuditEventType, code string) {
+	event := AuditEvent{
+		Type:            eventType,
+		Actor:           auditActor(ctx),
+		Time:            time.Now(),
+		CodeFingerprint: CodeFingerprint(code),
+	}
+	if !a.startAsync() {
+		logrus.Errorf("could not send enterprise %s audit event for %s: the "+
+			"server is shutting down", event.Type, event.CodeFingerprint)
+		return
+	}
+	go func() {
+		defer a.async.Done()
+		if err := a.options.AuditSink(a.ctx, event); err != nil {
+			logrus.Errorf("could not send enterprise %s audit event for %s: %v",
+				event.Type, event.CodeFingerprint, err)
+		}
+	}()
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	logrus "github.com/sirupsen/logrus"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// parseBackupKeys parses Options.BackupPublicKeys, the keys of the backup
+// signing authority
+func parseBackupKeys(options Options) ([]*rsa.PublicKey, error) {
+	var keys []*rsa.PublicKey
+	for i, pemKey := range options.BackupPublicKeys {
+		key, err := ec.ParsePublicKey(pemKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse backup public key %d: %s", i, err.Error())
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// logBackupCode logs that 'record', from an activation code signed by the
+// backup signing authority, has been accepted. Backup codes should only be
+// issued after the primary signing key is revoked, so each one is logged as
+// loudly as possible.
+func logBackupCode(record *ec.EnterpriseRecord) {
+	logrus.WithFields(logrus.Fields{
+		"keyID":       record.KeyID,
+		"fingerprint": CodeFingerprint(record.ActivationCode),
+	}).Warnf("BACKUP SIGNING AUTHORITY: accepted an activation code signed by " +
+		"the backup signing authority; this should only happen while recovering " +
+		"from the revocation of the primary signing key")
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// now returns the time at which the enterprise state is evaluated. It's the
+// real time, unless it has been shifted by SetSimulatedNow.
+//
+// It's wall time, intentionally: tokens' expiries are wall times, so they
+// can only be compared with the wall clock. But the wall clock may step
+// backward (e.g. when NTP corrects it), which would make an expired token
+// ACTIVE again until the clock catches up. So now never moves backward by up
+// to maxClockSkew: until the clock returns to the latest time that now has
+// returned, it keeps returning that time. Larger backward steps are taken to
+// be deliberate corrections of a clock that was wrong, and are followed.
+// Durations that don't involve a token (e.g. timeouts) are measured with
+// time.Now, which uses the monotonic clock, rather than with now.
+func (a *apiServer) now() time.Time {
+	now := time.Now().Add(time.Duration(atomic.LoadInt64(&a.clockOffset)))
+	for {
+		latest := atomic.LoadInt64(&a.latestNow)
+		if wall := now.UnixNano(); wall >= latest || latest-wall > int64(maxClockSkew) {
+			if !atomic.CompareAndSwapInt64(&a.latestNow, latest, wall) {
+				continue // another caller moved latestNow; check against it
+			}
+			return now
+		}
+		return time.Unix(0, latest)
+	}
+}
+
+// SetSimulatedNow implements the APIServer interface. Subscribers (i.e.
+// WatchState callers) are notified if the simulated time changes the state
+func (a *apiServer) SetSimulatedNow(t time.Time) error {
+	if !simulatedClockEnabled {
+		return fmt.Errorf("the simulated clock is only available in builds with the 'simulatedclock' build tag")
+	}
+	var offset time.Duration
+	if !t.IsZero() {
+		offset = t.Sub(time.Now())
+	}
+	a.subscribersMu.Lock()
+	defer a.subscribersMu.Unlock()
+	prevState := a.cachedState()
+	atomic.StoreInt64(&a.clockOffset, int64(offset))
+	// Shifting the clock backward is deliberate, so now mustn't hold it
+	atomic.StoreInt64(&a.latestNow, 0)
+	if state := a.cachedState(); state != prevState {
+		a.notifySubscribers(state)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+// +build !simulatedclock
+
+package server
+
+// simulatedClockEnabled is false in production builds, so SetSimulatedNow
+// always fails
+const simulatedClockEnabled = false
@@ -0,0 +1,7 @@
+// +build simulatedclock
+
+package server
+
+// simulatedClockEnabled is true only in builds with the 'simulatedclock' tag,
+// which must never be used for production builds of pachd
+const simulatedClockEnabled = true
@@ -0,0 +1,43 @@
+// +build simulatedclock
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pkg/uuid"
+)
+
+func TestSimulatedNow(t *testing.T) {
+	s := newAPIServer(nil, uuid.NewWithoutDashes(), Options{})
+	s.setTokenInfo(tokenInfo{expiry: time.Now().Add(time.Hour)})
+	stream, stop := watchState(t, s, &ec.WatchStateRequest{})
+	defer stop()
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, ec.State_ACTIVE, resp.State)
+
+	// Simulating a time after the token's expiry expires it, both for
+	// GetState and for WatchState callers
+	require.NoError(t, s.SetSimulatedNow(time.Now().Add(2*time.Hour)))
+	state, err := s.GetState(context.Background(), &ec.GetStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, ec.State_EXPIRED, state.State)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, ec.State_EXPIRED, resp.State)
+
+	// The zero time restores the real clock
+	require.NoError(t, s.SetSimulatedNow(time.Time{}))
+	state, err = s.GetState(context.Background(), &ec.GetStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, ec.State_ACTIVE, state.State)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, ec.State_ACTIVE, resp.State)
+}
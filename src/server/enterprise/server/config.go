@@ -0,0 +1,51 @@
+package server
+
+import (
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// GetConfig implements the GetConfig RPC. It reports the server's Options
+// after defaults are applied, so that support can see how a cluster is
+// configured without access to pachd's environment. Options that only hold
+// callbacks (e.g. IsAdmin) aren't reported, and JWKSURL is redacted.
+func (a *apiServer) GetConfig(ctx context.Context, req *ec.GetConfigRequest) (resp *ec.GetConfigResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	source := ec.TrustedKeySource_JWKS
+	if a.options.JWKSURL == "" {
+		source = ec.TrustedKeySource_UNKNOWN_KEY_SOURCE
+		if trusted, ok := a.trustedKeys.Load().(*trustedKeySet); ok {
+			source = trusted.source
+		}
+	}
+	resp = &ec.GetConfigResponse{
+		ExpiryWarningWindow:        types.DurationProto(a.options.ExpiryWarningWindow),
+		GracePeriod:                types.DurationProto(a.options.GracePeriod),
+		ExpiryJitter:               types.DurationProto(a.options.ExpiryJitter),
+		ExpiryOffset:               types.DurationProto(a.expiryOffset),
+		MaxClockSkew:               types.DurationProto(maxClockSkew),
+		MinRemaining:               types.DurationProto(a.options.MinRemaining),
+		TrustedKeySource:           source,
+		BackupKeysConfigured:       len(a.backupKeys) > 0,
+		TrialsEnabled:              a.options.EnableTrials,
+		StateInjectionEnabled:      a.options.EnableStateInjection,
+		RequireMonotonicActivation: a.options.RequireMonotonicActivation,
+		Environment:                a.env,
+		ProductID:                  a.options.ProductID,
+		MinSchemaVersion:           int64(a.options.MinSchemaVersion),
+		ActivationURLHosts:         append([]string(nil), a.options.ActivationURLHosts...),
+		EtcdPrefix:                 a.etcdPrefix,
+		HistoryPrefix:              historyPrefix(a.etcdPrefix, a.options),
+		JSONRecords:                a.options.JSONRecords,
+		CompressRecordsAbove:       int64(a.options.CompressRecordsAbove),
+		MaxRecordSize:              int64(a.options.MaxRecordSize),
+	}
+	if a.options.JWKSURL != "" {
+		resp.JWKSURL = redactURL(a.options.JWKSURL)
+	}
+	return resp, nil
+}
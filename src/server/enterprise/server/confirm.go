@@ -0,0 +1,82 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// awaitCachedRecord waits, for up to Options.ConfirmActivationTimeout, until
+// the cache holds a token with the expiry of 'record', which the caller has
+// just written to etcd. It doesn't fail if the watch doesn't apply the token
+// in time, as the token has been written regardless: it logs a warning, and
+// the cache catches up later.
+func (a *apiServer) awaitCachedRecord(ctx context.Context, record *ec.EnterpriseRecord) {
+	if a.options.ConfirmActivationTimeout <= 0 {
+		return
+	}
+	expiry, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return
+	}
+	key := expiry.UnixNano()
+	// Register before checking the cache, so that an update made in between
+	// isn't missed
+	ch := make(chan struct{})
+	a.confirmMu.Lock()
+	a.confirmWaiters[key] = append(a.confirmWaiters[key], ch)
+	a.confirmMu.Unlock()
+	defer a.removeConfirmWaiter(key, ch)
+	if info, _ := a.enterpriseInfo.Load().(tokenInfo); info.expiry.Equal(expiry) {
+		return
+	}
+
+	timer := time.NewTimer(a.options.ConfirmActivationTimeout)
+	defer timer.Stop()
+	select {
+	case <-ch:
+	case <-timer.C:
+		logrus.Warnf("the enterprise token with expiry %s was written, but the "+
+			"cache didn't reflect it within %v; GetState may briefly report the "+
+			"previous state", expiry.Format(time.RFC3339), a.options.ConfirmActivationTimeout)
+	case <-ctx.Done():
+	}
+}
+
+// removeConfirmWaiter unregisters 'ch', registered by awaitCachedRecord for
+// the expiry 'key', unless notifyConfirmWaiters already has
+func (a *apiServer) removeConfirmWaiter(key int64, ch chan struct{}) {
+	a.confirmMu.Lock()
+	defer a.confirmMu.Unlock()
+	waiters := a.confirmWaiters[key]
+	for i, waiter := range waiters {
+		if waiter == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(a.confirmWaiters, key)
+	} else {
+		a.confirmWaiters[key] = waiters
+	}
+}
+
+// notifyConfirmWaiters wakes the awaitCachedRecord callers waiting for a token
+// with the expiry 'expiry', which is now cached
+func (a *apiServer) notifyConfirmWaiters(expiry time.Time) {
+	if expiry.IsZero() {
+		return
+	}
+	key := expiry.UnixNano()
+	a.confirmMu.Lock()
+	defer a.confirmMu.Unlock()
+	for _, ch := range a.confirmWaiters[key] {
+		close(ch)
+	}
+	delete(a.confirmWaiters, key)
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// etcdHealthTimeout bounds each etcd endpoint health check made by DebugDump
+const etcdHealthTimeout = 5 * time.Second
+
+// checkAdmin returns a PermissionDenied error unless the caller of the RPC
+// in 'ctx' is a cluster admin
+func (a *apiServer) checkAdmin(ctx context.Context) error {
+	if a.options.IsAdmin == nil {
+		return grpc.Errorf(codes.PermissionDenied, "no admin check is configured for the enterprise server")
+	}
+	isAdmin, err := a.options.IsAdmin(ctx)
+	if err != nil {
+		return fmt.Errorf("could not check whether caller is an admin: %s", err.Error())
+	}
+	if !isAdmin {
+		return grpc.Errorf(codes.PermissionDenied, "only cluster admins may call this RPC")
+	}
+	return nil
+}
+
+// RevokedFingerprints returns an Options.IsRevoked callback that reports an
+// activation code as revoked if its fingerprint (as reported by DebugDump and
+// the activation history) is in 'fingerprints'
+func RevokedFingerprints(fingerprints []string) func(string) (bool, error) {
+	revoked := make(map[string]bool)
+	for _, f := range fingerprints {
+		revoked[strings.TrimSpace(f)] = true
+	}
+	return func(activationCode string) (bool, error) {
+		return revoked[CodeFingerprint(activationCode)], nil
+	}
+}
+
+// DebugDump implements the DebugDump RPC
+func (a *apiServer) DebugDump(ctx context.Context, req *ec.DebugDumpRequest) (resp *ec.DebugDumpResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	info, err := a.cachedTokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &ec.DebugDumpResponse{
+		State:                     a.state(info, a.now()),
+		Features:                  info.features,
+		ActivationCodeFingerprint: CodeFingerprint(info.activationCode),
+		WatchConnected:            atomic.LoadInt32(&a.watchConnected) > 0,
+	}
+	if !info.expiry.IsZero() {
+		if resp.Expires, err = types.TimestampProto(info.expiry); err != nil {
+			return nil, err
+		}
+	}
+	if lastWatchEvent, ok := a.lastWatchEvent.Load().(time.Time); ok && !lastWatchEvent.IsZero() {
+		if resp.LastWatchEvent, err = types.TimestampProto(lastWatchEvent); err != nil {
+			return nil, err
+		}
+	}
+	resp.LastError, _ = a.lastError.Load().(string)
+
+	conn := a.conn()
+	clients := []*etcd.Client{conn.etcdClient}
+	if conn.readClient != conn.etcdClient {
+		clients = append(clients, conn.readClient)
+	}
+	for _, c := range clients {
+		if c == nil {
+			continue
+		}
+		for _, endpoint := range c.Endpoints() {
+			health := &ec.EtcdEndpointHealth{Endpoint: sanitizeEndpoint(endpoint), Healthy: true}
+			statusCtx, cancel := context.WithTimeout(ctx, etcdHealthTimeout)
+			if _, err := c.Status(statusCtx, endpoint); err != nil {
+				health.Healthy = false
+				health.Error = err.Error()
+			}
+			cancel()
+			resp.EtcdEndpoints = append(resp.EtcdEndpoints, health)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// maxEmergencyOverride is the longest emergency override that
+// SetEmergencyOverride accepts. Overrides can be renewed, but each renewal
+// must be a deliberate decision by an admin.
+const maxEmergencyOverride = 72 * time.Hour
+
+// emergencyOverrideUntil returns the time at which the current emergency
+// override ends, or false if no override has been set
+func (a *apiServer) emergencyOverrideUntil() (time.Time, bool) {
+	until, ok := a.emergencyUntil.Load().(time.Time)
+	return until, ok && !until.IsZero()
+}
+
+// emergencyOverrideActive returns true if an emergency override is in effect
+// at 'now'
+func (a *apiServer) emergencyOverrideActive(now time.Time) bool {
+	until, ok := a.emergencyOverrideUntil()
+	return ok && now.Before(until)
+}
+
+// setEmergencyOverride makes the server treat an expired token as ACTIVE for
+// 'd', or ends any override if 'd' is zero, and returns when the override
+// ends. WatchState callers are notified if this changes the state.
+func (a *apiServer) setEmergencyOverride(d time.Duration) (time.Time, error) {
+	if d < 0 || d > maxEmergencyOverride {
+		return time.Time{}, fmt.Errorf("invalid request: the override's duration "+
+			"must be between 0 and %v, but was %v", maxEmergencyOverride, d)
+	}
+	var until time.Time
+	if d > 0 {
+		until = a.now().Add(d)
+	}
+	a.subscribersMu.Lock()
+	defer a.subscribersMu.Unlock()
+	prevState := a.cachedState()
+	a.emergencyUntil.Store(until)
+	if a.emergencyTimer != nil {
+		a.emergencyTimer.Stop()
+		a.emergencyTimer = nil
+	}
+	if d > 0 {
+		logrus.Warnf("EMERGENCY OVERRIDE ENABLED: Pachyderm Enterprise features "+
+			"will remain enabled until %s, even if the enterprise token has expired",
+			until.Format(time.RFC3339))
+		a.emergencyTimer = time.AfterFunc(d, func() {
+			logrus.Warnf("EMERGENCY OVERRIDE ENDED: the emergency override expired at %s",
+				until.Format(time.RFC3339))
+		})
+	} else {
+		logrus.Warnf("EMERGENCY OVERRIDE DISABLED")
+	}
+	if state := a.cachedState(); state != prevState {
+		a.notifySubscribers(state)
+	}
+	return until, nil
+}
+
+// SetEmergencyOverride implements the SetEmergencyOverride RPC
+func (a *apiServer) SetEmergencyOverride(ctx context.Context, req *ec.SetEmergencyOverrideRequest) (resp *ec.SetEmergencyOverrideResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var d time.Duration
+	if req.Duration != nil {
+		var err error
+		if d, err = types.DurationFromProto(req.Duration); err != nil {
+			return nil, fmt.Errorf("invalid request: could not parse duration: %s", err.Error())
+		}
+	}
+	until, err := a.setEmergencyOverride(d)
+	if err != nil {
+		return nil, err
+	}
+	resp = &ec.SetEmergencyOverrideResponse{}
+	if !until.IsZero() {
+		if resp.Expires, err = types.TimestampProto(until); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// emergencyOverrideWarning warns that an emergency override is in effect, and
+// whether it's what is keeping enterprise features enabled
+func emergencyOverrideWarning(a *apiServer, info tokenInfo, now time.Time) (string, error) {
+	if !a.emergencyOverrideActive(now) {
+		return "", nil
+	}
+	until, _ := a.emergencyOverrideUntil()
+	expiry := a.effectiveExpiry(info)
+	if now.After(expiry.Add(a.options.GracePeriod)) {
+		return fmt.Sprintf("EMERGENCY OVERRIDE: the Pachyderm Enterprise token expired "+
+			"at %s, but enterprise features remain enabled by an emergency override "+
+			"until %s", expiry.Format(time.RFC3339), until.Format(time.RFC3339)), nil
+	}
+	return fmt.Sprintf("EMERGENCY OVERRIDE: an emergency override is in effect "+
+		"until %s", until.Format(time.RFC3339)), nil
+}
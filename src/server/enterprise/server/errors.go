@@ -0,0 +1,107 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/golang/protobuf/ptypes/any"
+	logrus "github.com/sirupsen/logrus"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// activationError is returned when an activation code is rejected. RPC
+// handlers convert it (with toGRPCError) into a grpc error carrying an
+// ec.ActivationErrorDetails, so that clients can tell why the code was
+// rejected without parsing the error message.
+type activationError struct {
+	reason ec.ActivationErrorReason
+	// expires is the code's expiry, if reason is CODE_EXPIRED
+	expires *types.Timestamp
+	message string
+}
+
+func newActivationError(reason ec.ActivationErrorReason, format string, args ...interface{}) *activationError {
+	return &activationError{
+		reason:  reason,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *activationError) Error() string {
+	return e.message
+}
+
+// code returns the grpc code that RPCs rejecting an activation code for
+// e.reason return
+func (e *activationError) code() codes.Code {
+	switch e.reason {
+	case ec.ActivationErrorReason_SERIAL_NOT_INCREASING, ec.ActivationErrorReason_EXPIRES_TOO_SOON:
+		return codes.FailedPrecondition
+	}
+	return codes.InvalidArgument
+}
+
+// toGRPCError converts 'err' into a grpc error with details if it's an
+// *activationError, prepending 'prefix' to its message. Other errors are
+// returned unchanged.
+func toGRPCError(err error, prefix string) error {
+	e, ok := err.(*activationError)
+	if !ok {
+		return err
+	}
+	details, err := (&ec.ActivationErrorDetails{
+		Reason:  e.reason,
+		Expires: e.expires,
+	}).Marshal()
+	if err != nil {
+		// Still return the original error, just without details
+		return status.Error(e.code(), prefix+e.message)
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(e.code()),
+		Message: prefix + e.message,
+		Details: []*any.Any{{
+			TypeUrl: ec.ActivationErrorDetailsTypeURL,
+			Value:   details,
+		}},
+	})
+}
+
+// tokenReadError converts 'err', returned by reading the enterprise token from
+// etcd, into a grpc error. A token that exists but can't be decoded is an
+// Internal error, so that it's never mistaken for there being no token (NONE).
+func tokenReadError(err error) error {
+	if decodeErr, ok := err.(col.ErrDecode); ok {
+		return status.Errorf(codes.Internal, "the enterprise token stored in etcd "+
+			"is corrupt: %v", decodeErr.Err)
+	}
+	return err
+}
+
+// storeError converts 'err', returned by the transaction that stores a new
+// enterprise token, into a grpc error. If etcd aborted the transaction, the
+// error (which is logged) says why, rather than just that it failed.
+func storeError(err error) error {
+	abort, ok := err.(col.ErrTransactionAborted)
+	if !ok {
+		return toGRPCError(err, "")
+	}
+	logrus.Errorf("could not store the enterprise token: %v", abort)
+	switch abort.Reason {
+	case col.AbortConflict:
+		return status.Errorf(codes.Aborted, "could not store the enterprise token: "+
+			"it was modified concurrently on each of %d attempts; try again", abort.Attempts)
+	case col.AbortLease:
+		return status.Errorf(codes.Aborted, "could not store the enterprise token: "+
+			"etcd lease error: %v", abort.Err)
+	case col.AbortAuth:
+		return status.Errorf(codes.Internal, "could not store the enterprise token: "+
+			"etcd rejected pachd's credentials: %v", abort.Err)
+	}
+	return status.Errorf(codes.Internal, "could not store the enterprise token: %v", abort)
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// etcdConn is a set of etcd clients, and the collections that the server
+// keeps its token and activation history in, built on them
+type etcdConn struct {
+	// etcdClient is the client that transactions (i.e. writes) use, and
+	// readClient is the client that the token's watch and other reads outside
+	// of transactions use. They're the same client unless Options has
+	// separate read endpoints
+	etcdClient *etcd.Client
+	readClient *etcd.Client
+
+	// enterpriseToken is a collection containing at most one Pachyderm
+	// enterprise token
+	enterpriseToken col.Collection
+
+	// activationHistory is a collection containing an ActivationHistoryRecord
+	// for every activation of the cluster, keyed by activation time
+	activationHistory col.Collection
+
+	// selfTest is a collection containing the sentinel records that SelfTest
+	// and the watch health check write. It never contains the cluster's token
+	selfTest col.Collection
+
+	// codec is the codec that the collections encode records with
+	codec col.Codec
+
+	// owned is set if the clients were created by the server (by
+	// NewEnterpriseServer or ReconnectEtcd), and so should be closed by it
+	owned bool
+}
+
+// newEtcdConn builds the server's collections on 'etcdClient' and
+// 'readClient'
+func newEtcdConn(etcdClient, readClient *etcd.Client, etcdPrefix string, options Options) *etcdConn {
+	codec := col.ProtoCodec
+	if options.JSONRecords {
+		codec = col.JSONCodec
+	}
+	if options.CompressRecordsAbove > 0 {
+		codec = col.GzipCodec(options.CompressRecordsAbove, codec)
+	}
+	return &etcdConn{
+		etcdClient: etcdClient,
+		readClient: readClient,
+		codec:      codec,
+		enterpriseToken: col.NewCollectionWithCodec(
+			readClient,
+			etcdPrefix, // enterprise API only has one collection, no extra prefix needed
+			nil,
+			&ec.EnterpriseRecord{},
+			nil,
+			codec,
+		),
+		activationHistory: col.NewCollectionWithCodec(
+			readClient,
+			historyPrefix(etcdPrefix, options),
+			nil,
+			&ec.ActivationHistoryRecord{},
+			nil,
+			codec,
+		),
+		selfTest: col.NewCollectionWithCodec(
+			readClient,
+			strings.TrimSuffix(etcdPrefix, "/")+selfTestSuffix,
+			nil,
+			&ec.EnterpriseRecord{},
+			nil,
+			codec,
+		),
+	}
+}
+
+// close closes the connection's clients, if the server owns them
+func (c *etcdConn) close() error {
+	if !c.owned {
+		return nil
+	}
+	err := c.etcdClient.Close()
+	if c.readClient != c.etcdClient {
+		if readErr := c.readClient.Close(); err == nil {
+			err = readErr
+		}
+	}
+	return err
+}
+
+// conn returns the etcdConn that the server currently uses. Operations that
+// use more than one of its clients or collections should call it once, so
+// that they all come from the same etcdConn even if ReconnectEtcd replaces it
+func (a *apiServer) conn() *etcdConn {
+	return a.etcd.Load().(*etcdConn)
+}
+
+// ReconnectOptions configures how ReconnectEtcd connects to etcd
+type ReconnectOptions struct {
+	// ReadEndpoints, if set, are the endpoints that the server reads from
+	// outside of transactions (see Options.EtcdReadEndpoints). Otherwise, it
+	// reads from the endpoints that it writes to
+	ReadEndpoints []string
+
+	// ConnectTimeout and ConnectRetry are as in Options. If they're unset,
+	// the values that the server was constructed with are used
+	ConnectTimeout time.Duration
+	ConnectRetry   backoff.BackOff
+}
+
+// ReconnectEtcd implements the ReconnectEtcd method of APIServer
+func (a *apiServer) ReconnectEtcd(endpoints []string, options ReconnectOptions) error {
+	if len(endpoints) == 0 {
+		return fmt.Errorf("must provide at least one etcd endpoint to reconnect to")
+	}
+	if !a.startAsync() {
+		return status.Error(codes.Unavailable, "the enterprise server is shutting down")
+	}
+	defer a.async.Done()
+	a.reconnectMu.Lock()
+	defer a.reconnectMu.Unlock()
+
+	connectOptions := a.options
+	if options.ConnectTimeout > 0 {
+		connectOptions.ConnectTimeout = options.ConnectTimeout
+	}
+	if options.ConnectRetry != nil {
+		connectOptions.ConnectRetry = options.ConnectRetry
+	}
+	readEndpoints := options.ReadEndpoints
+	if len(readEndpoints) == 0 {
+		readEndpoints = endpoints
+	}
+	if !a.options.HideEtcdConfig {
+		logEtcdConfig(readEndpoints, endpoints, a.etcdPrefix, historyPrefix(a.etcdPrefix, a.options))
+	}
+	etcdClient, err := connectEtcd(endpoints, connectOptions)
+	if err != nil {
+		return fmt.Errorf("error connecting to new etcd endpoints: %v", err)
+	}
+	readClient := etcdClient
+	if !sameEndpoints(readEndpoints, endpoints) {
+		if readClient, err = connectEtcd(readEndpoints, connectOptions); err != nil {
+			etcdClient.Close()
+			return fmt.Errorf("error connecting to new etcd read endpoints: %v", err)
+		}
+	}
+	conn := newEtcdConn(etcdClient, readClient, a.etcdPrefix, a.options)
+	conn.owned = true
+
+	// Read the token through the new clients before switching to them, so
+	// that if they can't serve it, the server keeps using the old ones
+	ctx, cancel := context.WithTimeout(a.ctx, a.options.StartupReadTimeout)
+	info, err := readTokenInfo(ctx, conn.enterpriseToken)
+	cancel()
+	if err != nil {
+		if closeErr := conn.close(); closeErr != nil {
+			logrus.Errorf("could not close new etcd client: %v", closeErr)
+		}
+		return fmt.Errorf("error reading enterprise token from new etcd endpoints: %v", err)
+	}
+
+	// Re-prime the cache and restart the watch against the new clients, and
+	// only then switch to them and close the old ones
+	old := a.conn()
+	if a.stopWatch != nil {
+		a.stopWatch()
+	}
+	a.setTokenInfo(info)
+	a.watchToken(conn)
+	a.etcd.Store(conn)
+	if err := old.close(); err != nil {
+		logrus.Errorf("could not close previous etcd client: %v", err)
+	}
+	logrus.Printf("enterprise server reconnected to etcd at %v", sanitizeEndpoints(endpoints))
+	return nil
+}
+
+// watchToken starts a watch that keeps the cached tokenInfo up to date with
+// the token in 'conn', until it's replaced by another watch or the server is
+// closed. The caller must hold reconnectMu.
+func (a *apiServer) watchToken(conn *etcdConn) {
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.stopWatch = cancel
+	go a.watchEnterpriseToken(ctx, conn.enterpriseToken)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"sort"
+	"time"
+)
+
+// expiringFeatures returns the token's features whose own expiries (see
+// tokenInfo.featureExpiries) are within Options.ExpiryWarningWindow of 'now',
+// but haven't passed, sorted
+func (a *apiServer) expiringFeatures(info tokenInfo, now time.Time) []string {
+	var expiring []string
+	for feature, expiry := range info.featureExpiries {
+		if !info.hasFeature(feature) || now.After(expiry) ||
+			expiry.Sub(now) > a.options.ExpiryWarningWindow {
+			continue
+		}
+		expiring = append(expiring, feature)
+	}
+	sort.Strings(expiring)
+	return expiring
+}
+
+// untilExpiringFeaturesChange returns how long it is until the result of
+// expiringFeatures changes, because a feature's warning window starts or its
+// expiry passes, and false if it never will
+func (a *apiServer) untilExpiringFeaturesChange(info tokenInfo, now time.Time) (time.Duration, bool) {
+	var next time.Time
+	for feature, expiry := range info.featureExpiries {
+		if !info.hasFeature(feature) {
+			continue
+		}
+		for _, transition := range []time.Time{expiry.Add(-a.options.ExpiryWarningWindow), expiry} {
+			if transition.After(now) && (next.IsZero() || transition.Before(next)) {
+				next = transition
+			}
+		}
+	}
+	if next.IsZero() {
+		return 0, false
+	}
+	return next.Sub(now), true
+}
+
+// sameFeatures returns true if 'a' and 'b' contain the same features
+func sameFeatures(a, b []string) bool {
+	added, removed := featureDiff(a, b)
+	return len(added) == 0 && len(removed) == 0
+}
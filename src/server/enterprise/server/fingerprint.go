@@ -0,0 +1,56 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// CodeFingerprint identifies 'activationCode' without revealing it: it's the
+// hex-encoded first 8 bytes of the code's SHA-256 hash. It's what DebugDump,
+// the activation history, RevokedFingerprints and the server's logs use to
+// refer to codes, so that they can be correlated with each other.
+func CodeFingerprint(activationCode string) string {
+	if activationCode == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(activationCode))
+	return hex.EncodeToString(sum[:8])
+}
+
+// redactRequest returns a copy of the RPC request 'req' that's safe to log:
+// any activation code in it is replaced by its fingerprint. Other requests
+// are returned unchanged.
+func redactRequest(req interface{}) interface{} {
+	redact := func(code string) string {
+		if code == "" {
+			return ""
+		}
+		return "fingerprint:" + CodeFingerprint(code)
+	}
+	switch r := req.(type) {
+	case *ec.ActivateRequest:
+		redacted := *r
+		redacted.ActivationCode = redact(r.ActivationCode)
+		return &redacted
+	case *ec.StageActivationRequest:
+		redacted := *r
+		redacted.ActivationCode = redact(r.ActivationCode)
+		return &redacted
+	case *ec.PreviewCodeRequest:
+		redacted := *r
+		redacted.Code = redact(r.Code)
+		return &redacted
+	case *ec.ActivateDetachedRequest:
+		redacted := *r
+		redacted.Token = redact(r.Token)
+		redacted.Signature = redact(r.Signature)
+		return &redacted
+	case *ec.ActivatePartialRequest:
+		redacted := *r
+		redacted.Part = []byte(redact(string(r.Part)))
+		return &redacted
+	}
+	return req
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// ListAllExpiries returns the expiry of the enterprise token of every
+// Pachyderm instance whose enterprise etcd prefix (the 'etcdPrefix' passed to
+// NewEnterpriseServer) is under 'basePrefix', e.g. for an overview of a
+// deployment that runs many instances against one etcd cluster. The map is
+// keyed by those prefixes, with 'basePrefix' removed. Instances without a
+// token have no entry, and keys under 'basePrefix' that merely look like
+// tokens (i.e. whose values aren't enterprise records) are skipped.
+func ListAllExpiries(etcdClient *etcd.Client, basePrefix string) (map[string]time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	base := strings.TrimSuffix(basePrefix, "/") + "/"
+	if basePrefix == "" {
+		base = ""
+	}
+	suffix := "/" + enterpriseTokenKey
+	expiries := make(map[string]time.Time)
+	// Scan keys only, as the prefix may contain an instance's entire state,
+	// and then read just the tokens
+	keys, err := etcdClient.Get(ctx, base, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return nil, fmt.Errorf("error listing keys under %q: %v", basePrefix, err)
+	}
+	for _, kv := range keys.Kvs {
+		key := string(kv.Key)
+		if !strings.HasSuffix(key, suffix) {
+			continue
+		}
+		resp, err := etcdClient.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %q: %v", key, err)
+		}
+		if len(resp.Kvs) == 0 {
+			continue // deleted since it was listed
+		}
+		var record ec.EnterpriseRecord
+		// Both codecs can decode values written by either of them
+		if err := col.ProtoCodec.Unmarshal(resp.Kvs[0].Value, &record); err != nil || record.Expires == nil {
+			continue
+		}
+		expiry, err := types.TimestampFromProto(record.Expires)
+		if err != nil {
+			continue
+		}
+		// An instance whose prefix is 'basePrefix' itself is keyed by ""
+		instance := strings.TrimPrefix(key, base)
+		if instance == enterpriseTokenKey {
+			instance = ""
+		}
+		expiries[strings.TrimSuffix(instance, suffix)] = expiry
+	}
+	return expiries, nil
+}
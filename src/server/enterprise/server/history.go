@@ -0,0 +1,213 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+const (
+	// exportHistoryPageSize is the number of activation history records that
+	// ExportHistory reads from etcd, and sends, at a time
+	exportHistoryPageSize = 100
+
+	// defaultHistoryCompactionInterval is the value of
+	// Options.HistoryCompactionInterval used when none is set
+	defaultHistoryCompactionInterval = time.Hour
+
+	// historyDeleteBatchSize is the number of history records that
+	// compactHistory deletes per transaction, as etcd limits the number of
+	// operations in one
+	historyDeleteBatchSize = 50
+)
+
+// ExportHistory implements the ExportHistory RPC. History keys sort in
+// activation order (see historyKey), so paging through the history collection
+// in key order yields the records in chronological order.
+func (a *apiServer) ExportHistory(req *ec.ExportHistoryRequest, server ec.API_ExportHistoryServer) (retErr error) {
+	if err := a.checkAdmin(server.Context()); err != nil {
+		return err
+	}
+	iter, err := a.conn().activationHistory.ReadOnly(server.Context()).ListPaged(exportHistoryPageSize)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := iter.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	resp := &ec.ExportHistoryResponse{}
+	var prev *ec.ActivationHistoryRecord
+	for {
+		var key string
+		record := &ec.ActivationHistoryRecord{}
+		ok, err := iter.Next(&key, record)
+		if err != nil {
+			return err
+		}
+		// Consecutive records of the same activation (e.g. because a client
+		// retried Activate) are exported once, as the first of them
+		if ok && (prev == nil || !sameActivation(prev, record)) {
+			resp.Records = append(resp.Records, record)
+			prev = record
+		}
+		if len(resp.Records) == exportHistoryPageSize || (!ok && len(resp.Records) > 0) {
+			if err := server.Send(resp); err != nil {
+				return err
+			}
+			resp = &ec.ExportHistoryResponse{}
+		}
+		if !ok {
+			return nil
+		}
+	}
+}
+
+// sameActivation returns true if the history records 'a' and 'b' describe
+// activations of the same code, with the same claims, even if they were made
+// at different times
+func sameActivation(a, b *ec.ActivationHistoryRecord) bool {
+	a2, b2 := *a, *b
+	a2.Activated, b2.Activated = nil, nil
+	return proto.Equal(&a2, &b2)
+}
+
+// validateHistoryRetention returns an error if the history retention policy
+// in 'options' is invalid
+func validateHistoryRetention(options Options) error {
+	if options.HistoryMaxEntries < 0 {
+		return fmt.Errorf("enterprise history max entries must not be negative, but was %d", options.HistoryMaxEntries)
+	}
+	if options.HistoryMaxAge < 0 {
+		return fmt.Errorf("enterprise history max age must not be negative, but was %v", options.HistoryMaxAge)
+	}
+	if options.HistoryCompactionInterval < 0 {
+		return fmt.Errorf("enterprise history compaction interval must not be negative, but was %v", options.HistoryCompactionInterval)
+	}
+	return nil
+}
+
+// compactHistoryPeriodically calls compactHistory every
+// Options.HistoryCompactionInterval, until the server is closed
+func (a *apiServer) compactHistoryPeriodically() {
+	ticker := time.NewTicker(a.options.HistoryCompactionInterval)
+	defer ticker.Stop()
+	for {
+		if !a.startAsync() {
+			return // Close has been called
+		}
+		if _, err := a.compactHistory(a.ctx); err != nil && a.ctx.Err() == nil {
+			logrus.Errorf("error compacting enterprise activation history: %v", err)
+		}
+		a.async.Done()
+		select {
+		case <-ticker.C:
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// compactHistory deletes the activation history records that the retention
+// policy (Options.HistoryMaxEntries and Options.HistoryMaxAge) doesn't keep,
+// and returns the number deleted. History keys sort in activation order, so
+// the records to delete are a prefix of the collection.
+func (a *apiServer) compactHistory(ctx context.Context) (int, error) {
+	iter, err := a.conn().activationHistory.ReadOnly(ctx).ListPaged(exportHistoryPageSize)
+	if err != nil {
+		return 0, err
+	}
+	var keys []string
+	for {
+		var key string
+		record := &ec.ActivationHistoryRecord{}
+		ok, err := iter.Next(&key, record)
+		if err != nil {
+			iter.Close()
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		keys = append(keys, key)
+	}
+	if err := iter.Close(); err != nil {
+		return 0, err
+	}
+
+	// Never delete the newest record, which describes the current token
+	var expired []string
+	cutoff := time.Now().Add(-a.options.HistoryMaxAge)
+	for i := 0; i < len(keys)-1; i++ {
+		key := keys[i]
+		tooMany := a.options.HistoryMaxEntries > 0 && i < len(keys)-a.options.HistoryMaxEntries
+		// Keys that can't be parsed are kept, as their age is unknown
+		activated := historyKeyTime(key)
+		tooOld := a.options.HistoryMaxAge > 0 && !activated.IsZero() && activated.Before(cutoff)
+		if !tooMany && !tooOld {
+			break
+		}
+		expired = append(expired, key)
+	}
+
+	for batch := 0; batch < len(expired); batch += historyDeleteBatchSize {
+		end := batch + historyDeleteBatchSize
+		if end > len(expired) {
+			end = len(expired)
+		}
+		if _, err := col.NewSTM(ctx, a.conn().etcdClient, func(stm col.STM) error {
+			history := a.conn().activationHistory.ReadWrite(stm)
+			for _, key := range expired[batch:end] {
+				if err := history.Delete(key); err != nil {
+					// Another pachd may have compacted the history concurrently
+					if _, ok := err.(col.ErrNotFound); !ok {
+						return err
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return batch, err
+		}
+	}
+	if len(expired) > 0 {
+		logrus.Infof("deleted %d records from the enterprise activation history", len(expired))
+	}
+	return len(expired), nil
+}
+
+// putHistoryRecord adds 'record', for an activation at 't', to the
+// activation history. Each record gets a new key, even if another pachd
+// writes a record for the same instant.
+func (a *apiServer) putHistoryRecord(stm col.STM, t time.Time, record *ec.ActivationHistoryRecord) error {
+	history := a.conn().activationHistory.ReadWrite(stm)
+	for {
+		err := history.Create(historyKey(t, atomic.AddUint64(&a.historySeq, 1)), record)
+		if _, ok := err.(col.ErrExists); !ok {
+			return err
+		}
+	}
+}
+
+// historyKeyTime returns the activation time encoded in the history key 'key'
+// (see historyKey), or the zero time if it can't be parsed
+func historyKeyTime(key string) time.Time {
+	if i := strings.IndexByte(key, '-'); i >= 0 {
+		key = key[:i]
+	}
+	nanos, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// maxStateInjection is the longest state injection that InjectState accepts,
+// so that a forgotten injection doesn't outlive the test that made it
+const maxStateInjection = time.Hour
+
+// stateInjection is a state that InjectState has made the server report in
+// place of the state derived from its token, until 'until'
+type stateInjection struct {
+	state ec.State
+	// expiry, if set, replaces the token's expiry
+	expiry time.Time
+	until  time.Time
+}
+
+// activeInjection returns the state injection in effect, or nil if there is
+// none. Injections end by the clock, not the simulated clock, so that a test
+// shifting the simulated clock can't make one last forever.
+func (a *apiServer) activeInjection() *stateInjection {
+	inj, _ := a.injection.Load().(*stateInjection)
+	if inj == nil || !time.Now().Before(inj.until) {
+		return nil
+	}
+	return inj
+}
+
+// apply returns 'info' as it appears while 'inj' is in effect
+func (inj *stateInjection) apply(info tokenInfo) tokenInfo {
+	if inj.state == ec.State_NONE {
+		return tokenInfo{}
+	}
+	if !inj.expiry.IsZero() {
+		info.expiry = inj.expiry
+	}
+	return info
+}
+
+// injectState makes the server report 'state' (and 'expiry', if it's set)
+// for 'ttl', or ends any injection if 'ttl' is zero, and returns when the
+// injection ends. WatchState callers are notified if this changes the state.
+func (a *apiServer) injectState(state ec.State, expiry time.Time, ttl time.Duration) (time.Time, error) {
+	if !a.options.EnableStateInjection {
+		return time.Time{}, grpc.Errorf(codes.FailedPrecondition, "state injection "+
+			"is disabled; it can only be enabled when the server is constructed")
+	}
+	if _, ok := ec.State_name[int32(state)]; !ok {
+		return time.Time{}, fmt.Errorf("invalid request: unknown state %d", state)
+	}
+	if ttl < 0 || ttl > maxStateInjection {
+		return time.Time{}, fmt.Errorf("invalid request: the injection's ttl must "+
+			"be between 0 and %v, but was %v", maxStateInjection, ttl)
+	}
+	a.subscribersMu.Lock()
+	defer a.subscribersMu.Unlock()
+	prevState := a.cachedState()
+	if a.injectionTimer != nil {
+		a.injectionTimer.Stop()
+		a.injectionTimer = nil
+	}
+	var inj *stateInjection
+	if ttl > 0 {
+		inj = &stateInjection{state: state, expiry: expiry, until: time.Now().Add(ttl)}
+		logrus.Warnf("STATE INJECTION ENABLED: the enterprise server will report "+
+			"the state %s, regardless of the cluster's token, until %s", state,
+			inj.until.Format(time.RFC3339))
+		a.injectionTimer = time.AfterFunc(ttl, func() { a.endInjection(inj) })
+	} else {
+		logrus.Warnf("STATE INJECTION DISABLED")
+	}
+	a.injection.Store(inj)
+	if state := a.cachedState(); state != prevState {
+		a.notifySubscribers(state)
+	}
+	if inj == nil {
+		return time.Time{}, nil
+	}
+	return inj.until, nil
+}
+
+// endInjection reverts the state injection 'inj' once its ttl has passed,
+// unless it has already been replaced
+func (a *apiServer) endInjection(inj *stateInjection) {
+	a.subscribersMu.Lock()
+	defer a.subscribersMu.Unlock()
+	if current, _ := a.injection.Load().(*stateInjection); current != inj {
+		return
+	}
+	a.injection.Store((*stateInjection)(nil))
+	a.injectionTimer = nil
+	logrus.Warnf("STATE INJECTION ENDED: the injection of the state %s expired "+
+		"at %s", inj.state, inj.until.Format(time.RFC3339))
+	// The injection has already stopped applying, so compare against the
+	// state that it injected
+	if state := a.cachedState(); state != inj.state {
+		a.notifySubscribers(state)
+	}
+}
+
+// InjectState implements the InjectState RPC
+func (a *apiServer) InjectState(ctx context.Context, req *ec.InjectStateRequest) (resp *ec.InjectStateResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var ttl time.Duration
+	if req.TTL != nil {
+		var err error
+		if ttl, err = types.DurationFromProto(req.TTL); err != nil {
+			return nil, fmt.Errorf("invalid request: could not parse ttl: %s", err.Error())
+		}
+	}
+	var expiry time.Time
+	if req.Expiry != nil {
+		var err error
+		if expiry, err = types.TimestampFromProto(req.Expiry); err != nil {
+			return nil, fmt.Errorf("invalid request: could not parse expiry: %s", err.Error())
+		}
+	}
+	until, err := a.injectState(req.State, expiry, ttl)
+	if err != nil {
+		return nil, err
+	}
+	resp = &ec.InjectStateResponse{}
+	if !until.IsZero() {
+		if resp.Expires, err = types.TimestampProto(until); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// stateInjectionWarning warns that a state injection is in effect, so that
+// an injected state is never mistaken for the real one
+func stateInjectionWarning(a *apiServer) string {
+	inj := a.activeInjection()
+	if inj == nil {
+		return ""
+	}
+	return fmt.Sprintf("STATE INJECTION: the enterprise state is being reported "+
+		"as %s for testing, regardless of the cluster's token, until %s",
+		inj.state, inj.until.Format(time.RFC3339))
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"runtime/debug"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// UnaryServerInterceptor implements the corresponding method of APIServer
+func (a *apiServer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return a.intercept
+}
+
+// StreamServerInterceptor implements the corresponding method of APIServer
+func (a *apiServer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return a.interceptStream
+}
+
+// intercept wraps every unary enterprise RPC. It ignores RPCs served by other APIs.
+func (a *apiServer) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, retErr error) {
+	if info.Server != interface{}(a) {
+		return handler(ctx, req)
+	}
+	// Deferred first, so that it observes the error set by recoverPanic
+	defer func(start time.Time) {
+		rpcDurationSeconds.WithLabelValues(
+			path.Base(info.FullMethod),
+			grpc.Code(retErr).String(),
+		).Observe(time.Since(start).Seconds())
+	}(time.Now())
+	defer a.recoverPanic(req, info.FullMethod, &retErr)
+	a.maybeActivateTrial()
+	return handler(ctx, req)
+}
+
+// interceptStream is like intercept, but wraps streaming enterprise RPCs (e.g.
+// WatchState)
+func (a *apiServer) interceptStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (retErr error) {
+	if srv != interface{}(a) {
+		return handler(srv, stream)
+	}
+	defer func(start time.Time) {
+		rpcDurationSeconds.WithLabelValues(
+			path.Base(info.FullMethod),
+			grpc.Code(retErr).String(),
+		).Observe(time.Since(start).Seconds())
+	}(time.Now())
+	defer a.recoverPanic(nil, info.FullMethod, &retErr)
+	a.maybeActivateTrial()
+	return handler(srv, stream)
+}
+
+// recoverPanic is deferred by intercept and interceptStream. If the RPC handler panicked, it logs
+// the panic along with the handler's stack trace and converts the panic into
+// an Internal error, so that a bug in one RPC doesn't crash pachd
+func (a *apiServer) recoverPanic(req interface{}, fullMethod string, retErr *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	err := fmt.Errorf("panic in %s: %v\n%s", fullMethod, r, debug.Stack())
+	a.pachLogger.LogAtLevelFromDepth(redactRequest(req), nil, err, 0, logrus.ErrorLevel, 3)
+	*retErr = grpc.Errorf(codes.Internal, "internal error in %s: %v", fullMethod, r)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// validateExpiryJitter returns an error if options.ExpiryJitter is negative,
+// or is set without options.ClusterID (every cluster would get the same
+// offset, defeating its purpose)
+func validateExpiryJitter(options Options) error {
+	if options.ExpiryJitter < 0 {
+		return fmt.Errorf("enterprise expiry jitter must not be negative, but was %v", options.ExpiryJitter)
+	}
+	if options.ExpiryJitter > 0 && options.ClusterID == "" {
+		return fmt.Errorf("enterprise expiry jitter requires a cluster ID")
+	}
+	return nil
+}
+
+// expiryJitterOffset returns how long before its token's signed expiry the
+// cluster 'clusterID' treats the token as expiring. It's derived from a hash
+// of 'clusterID', so it's the same every time that the cluster starts, and is
+// in [0, jitter).
+func expiryJitterOffset(clusterID string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	sum := sha256.Sum256([]byte(clusterID))
+	return time.Duration(binary.BigEndian.Uint64(sum[:8]) % uint64(jitter))
+}
+
+// effectiveExpiry returns the time at which the cluster treats the token
+// described by 'info' as expiring: its signed expiry, brought forward by the
+// cluster's expiry jitter offset (see Options.ExpiryJitter). It's never later
+// than the signed expiry, nor earlier than the token's issue time, if known.
+func (a *apiServer) effectiveExpiry(info tokenInfo) time.Time {
+	if info.expiry.IsZero() || a.expiryOffset == 0 {
+		return info.expiry
+	}
+	expiry := info.expiry.Add(-a.expiryOffset)
+	if !info.issuedAt.IsZero() && expiry.Before(info.issuedAt) {
+		return info.issuedAt
+	}
+	return expiry
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+const (
+	// defaultJWKSRefreshInterval is the value of Options.JWKSRefreshInterval
+	// used when none is set
+	defaultJWKSRefreshInterval = time.Hour
+
+	// jwksFetchTimeout bounds each download of the JWKS
+	jwksFetchTimeout = 30 * time.Second
+
+	// maxJWKSSize is the largest JWKS document that pachd will accept
+	maxJWKSSize = 1024 * 1024
+)
+
+// jsonWebKey is a single key in a JWKS document (RFC 7517). Only RSA keys are
+// used.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jwkSet is the set of trusted keys most recently fetched from
+// Options.JWKSURL
+type jwkSet struct {
+	// keys are all of the keys in the set, in order of ID
+	keys []*rsa.PublicKey
+	// byID indexes the keys that have IDs
+	byID map[string]*rsa.PublicKey
+}
+
+// checkJWKSURL returns an error if trusted keys may not be fetched from
+// 'rawURL'. Keys are only fetched over HTTPS, as anyone who could tamper with
+// them could issue activation codes.
+func checkJWKSURL(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("could not parse JWKS URL %q", redactURL(rawURL))
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("JWKS URL %q must be an HTTPS URL", redactURL(rawURL))
+	}
+	return nil
+}
+
+// parseJWKS parses the JWKS document 'data'. Keys that aren't RSA signing
+// keys are ignored, but it's an error for the document to contain none.
+func parseJWKS(data []byte) (*jwkSet, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("JWKS is not valid JSON: %s", err.Error())
+	}
+	// Sort the keys, so that codes without a key ID are checked against them
+	// in a deterministic order
+	sort.SliceStable(doc.Keys, func(i, j int) bool { return doc.Keys[i].Kid < doc.Keys[j].Kid })
+	set := &jwkSet{byID: make(map[string]*rsa.PublicKey)}
+	for i, jwk := range doc.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %d has an invalid modulus", i)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("key %d has an invalid exponent", i)
+		}
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		if jwk.Kid != "" {
+			if _, ok := set.byID[jwk.Kid]; ok {
+				return nil, fmt.Errorf("key ID %q is used by more than one key", jwk.Kid)
+			}
+			set.byID[jwk.Kid] = key
+		}
+		set.keys = append(set.keys, key)
+	}
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no RSA signing keys")
+	}
+	return set, nil
+}
+
+// fetchJWKS downloads and parses the JWKS at Options.JWKSURL
+func (a *apiServer) fetchJWKS(ctx context.Context) (*jwkSet, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequest("GET", a.options.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := a.options.HTTPClient.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = redactURL(urlErr.URL)
+		}
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %q", httpResp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxJWKSSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxJWKSSize {
+		return nil, fmt.Errorf("response is larger than the maximum JWKS size (%d bytes)", maxJWKSSize)
+	}
+	return parseJWKS(body)
+}
+
+// refreshJWKS replaces the cached JWKS with a freshly downloaded one. If the
+// download fails, the last set that was downloaded successfully is kept.
+func (a *apiServer) refreshJWKS(ctx context.Context) error {
+	set, err := a.fetchJWKS(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching trusted keys from %s: %s", redactURL(a.options.JWKSURL), err.Error())
+	}
+	a.jwks.Store(set)
+	return nil
+}
+
+// watchJWKS calls refreshJWKS every Options.JWKSRefreshInterval, until 'ctx'
+// is canceled
+func (a *apiServer) watchJWKS(ctx context.Context) {
+	ticker := time.NewTicker(a.options.JWKSRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.refreshJWKS(ctx); err != nil {
+				if ctx.Err() != nil {
+					return // the fetch failed because it was canceled
+				}
+				logrus.Errorf("%v; still trusting the keys fetched previously", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// jwksKeys returns the keys in the cached JWKS that may have signed a code
+// that names the key 'kid'. Codes that don't name a key may have been signed
+// by any of them.
+func (a *apiServer) jwksKeys(kid string) ([]*rsa.PublicKey, error) {
+	set, ok := a.jwks.Load().(*jwkSet)
+	if !ok || set == nil {
+		return nil, grpc.Errorf(codes.Unavailable, "trusted keys haven't been "+
+			"fetched from %s yet", redactURL(a.options.JWKSURL))
+	}
+	if kid == "" {
+		return set.keys, nil
+	}
+	key, ok := set.byID[kid]
+	if !ok {
+		return nil, toGRPCError(newActivationError(ec.ActivationErrorReason_INVALID_SIGNATURE,
+			"no trusted key has ID %q", kid), "error validating activation code: ")
+	}
+	return []*rsa.PublicKey{key}, nil
+}
+
+// codeKeyID returns the ID of the key that 'code' claims to be signed with,
+// or "" if it doesn't name one (or is malformed, which validateActivationCode
+// reports)
+func codeKeyID(code string) string {
+	return decodeEnvelope(code).KID
+}
+
+// decodeEnvelope returns the envelope of 'code', without verifying it, or the
+// zero ActivationCode if 'code' is malformed (which validateActivationCode
+// reports)
+func decodeEnvelope(code string) ec.ActivationCode {
+	decoded, err := base64.StdEncoding.DecodeString(code)
+	if err != nil {
+		return ec.ActivationCode{}
+	}
+	var c ec.ActivationCode
+	if err := json.Unmarshal(decoded, &c); err != nil {
+		return ec.ActivationCode{}
+	}
+	return c
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+const (
+	// defaultLeaseReconcileInterval is the value of
+	// Options.LeaseReconcileInterval used when none is set
+	defaultLeaseReconcileInterval = time.Minute
+
+	// leaseDriftTolerance is how far the remaining TTL of a lease-backed
+	// token's lease may be from the time left until the record's LeaseExpires
+	// before reconcileLease corrects it. etcd reports TTLs in whole seconds,
+	// so this must be at least a few seconds.
+	leaseDriftTolerance = time.Minute
+)
+
+// leaseSeconds returns 'ttl' as a whole number of seconds (rounded up), as
+// etcd leases require
+func leaseSeconds(ttl time.Duration) int64 {
+	return int64((ttl + time.Second - 1) / time.Second)
+}
+
+// leaseEnd returns when the lease of a lease-backed token with 'record'
+// should end: once the token's grace period (Options.GracePeriod) has passed,
+// so that etcd never deletes a token that the cluster still honors. It's
+// derived from the record, rather than from the TTL that Activate was called
+// with, which only has to agree with the token's expiry (see checkLease).
+func (a *apiServer) leaseEnd(record *ec.EnterpriseRecord) (time.Time, error) {
+	expiry, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not parse expiration timestamp: %s", err.Error())
+	}
+	return expiry.Add(a.options.GracePeriod), nil
+}
+
+// reconcileLeasePeriodically calls reconcileLease every
+// Options.LeaseReconcileInterval, until the server is closed
+func (a *apiServer) reconcileLeasePeriodically() {
+	ticker := time.NewTicker(a.options.LeaseReconcileInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.ctx.Done():
+			return
+		}
+		if !a.startAsync() {
+			return // Close has been called
+		}
+		if _, err := a.reconcileLease(a.ctx); err != nil && a.ctx.Err() == nil {
+			logrus.Errorf("error reconciling the enterprise token's lease: %v", err)
+		}
+		a.async.Done()
+	}
+}
+
+// reconcileLease checks that a lease-backed token (one whose record has
+// LeaseExpires set) is still attached to a lease that ends at its leaseEnd.
+// Manual etcd operations (e.g. re-putting the key with etcdctl, or revoking
+// or extending the lease) can detach or alter the lease, in which case the
+// token would outlive its lease, or be deleted early. If the lease has
+// drifted, reconcileLease re-attaches the token to a new lease that ends at
+// its leaseEnd, and returns true. The end is derived from the record's expiry
+// rather than from LeaseExpires, so that tokens stored with a lease that ends
+// too early are corrected too.
+func (a *apiServer) reconcileLease(ctx context.Context) (bool, error) {
+	a.mutateMu.Lock()
+	defer a.mutateMu.Unlock()
+	conn := a.conn()
+	path := conn.enterpriseToken.Path(enterpriseTokenKey)
+	resp, err := conn.etcdClient.Get(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	if len(resp.Kvs) == 0 {
+		return false, nil // no token
+	}
+	kv := resp.Kvs[0]
+	var record ec.EnterpriseRecord
+	// Both codecs can decode values written by either of them
+	if err := col.ProtoCodec.Unmarshal(kv.Value, &record); err != nil {
+		return false, err
+	}
+	if record.LeaseExpires == nil {
+		return false, nil // not lease-backed
+	}
+	leaseExpires, err := a.leaseEnd(&record)
+	if err != nil {
+		return false, err
+	}
+	expected := leaseExpires.Sub(a.now())
+	if expected <= 0 {
+		return false, nil // etcd deletes the token once its lease ends
+	}
+
+	var drift string
+	if kv.Lease == 0 {
+		drift = "the token isn't attached to a lease"
+	} else {
+		ttl, err := conn.etcdClient.TimeToLive(ctx, etcd.LeaseID(kv.Lease))
+		if err != nil && err != rpctypes.ErrLeaseNotFound {
+			return false, err
+		}
+		if err != nil || ttl.TTL < 0 {
+			drift = fmt.Sprintf("the token's lease (%x) no longer exists", kv.Lease)
+		} else if remaining := time.Duration(ttl.TTL) * time.Second; remaining < expected-leaseDriftTolerance || remaining > expected+leaseDriftTolerance {
+			drift = fmt.Sprintf("the token's lease (%x) ends in %v rather than %v",
+				kv.Lease, remaining, expected.Round(time.Second))
+		}
+	}
+	if drift == "" {
+		return false, nil
+	}
+
+	lease, err := conn.etcdClient.Grant(ctx, leaseSeconds(expected))
+	if err != nil {
+		return false, fmt.Errorf("error granting lease: %v", err)
+	}
+	// Only re-attach the token if it hasn't changed since it was read; if it
+	// has, the next check will examine the new token
+	txnResp, err := conn.etcdClient.Txn(ctx).
+		If(etcd.Compare(etcd.ModRevision(path), "=", kv.ModRevision)).
+		Then(etcd.OpPut(path, string(kv.Value), etcd.WithLease(lease.ID))).
+		Commit()
+	if err == nil && !txnResp.Succeeded {
+		err = fmt.Errorf("the enterprise token changed while its lease was being reconciled")
+	}
+	if err != nil {
+		if _, revokeErr := conn.etcdClient.Revoke(ctx, lease.ID); revokeErr != nil {
+			logrus.Errorf("could not revoke unused lease %x: %v", lease.ID, revokeErr)
+		}
+		return false, err
+	}
+	logrus.Printf("corrected the enterprise token's lease: %s; re-attached it to lease %x, "+
+		"which ends at %s", drift, lease.ID, leaseExpires.Format(time.RFC3339))
+	return true, nil
+}
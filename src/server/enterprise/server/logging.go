@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// stateLogField is the log field that LogFields reports the enterprise state
+// in
+const stateLogField = "enterpriseState"
+
+// LogFields implements the APIServer interface. It only reads the cached
+// tokenInfo, so it never blocks on etcd; before the cache has been primed, the
+// state is reported as "UNKNOWN"
+func (a *apiServer) LogFields() logrus.Fields {
+	info, ok := a.enterpriseInfo.Load().(tokenInfo)
+	if !ok || info.uninitialized {
+		return logrus.Fields{stateLogField: "UNKNOWN"}
+	}
+	return logrus.Fields{stateLogField: a.state(info, a.now()).String()}
+}
+
+// logEtcdConfig logs the etcd endpoints and prefixes that the server uses, so
+// that a misconfigured prefix (which makes the cluster appear to have no
+// token) is easy to spot. Credentials are stripped from the endpoints.
+func logEtcdConfig(readEndpoints, writeEndpoints []string, etcdPrefix, historyPrefix string) {
+	logrus.WithFields(logrus.Fields{
+		"etcdReadEndpoints":  sanitizeEndpoints(readEndpoints),
+		"etcdWriteEndpoints": sanitizeEndpoints(writeEndpoints),
+		"etcdPrefix":         etcdPrefix,
+		"historyPrefix":      historyPrefix,
+	}).Info("starting enterprise server")
+}
+
+// sanitizeEndpoints applies sanitizeEndpoint to each of 'endpoints'
+func sanitizeEndpoints(endpoints []string) []string {
+	sanitized := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		sanitized[i] = sanitizeEndpoint(endpoint)
+	}
+	return sanitized
+}
+
+// sanitizeEndpoint removes any userinfo (e.g. "user:password@") from the etcd
+// endpoint 'endpoint', so that it can be logged. Endpoints may be URLs or bare
+// host:port pairs, and anything before the last '@' in the authority is
+// treated as userinfo, even if the endpoint isn't a valid URL.
+func sanitizeEndpoint(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.User != nil {
+		u.User = nil
+		return u.String()
+	}
+	scheme, rest := "", endpoint
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		scheme, rest = endpoint[:i+3], endpoint[i+3:]
+	}
+	authority := rest
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		authority = rest[:i]
+	}
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		rest = rest[i+1:]
+	}
+	return scheme + rest
+}
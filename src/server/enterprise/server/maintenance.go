@@ -0,0 +1,56 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/golang/protobuf/ptypes/any"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// maintenanceRetryAfter is how long callers rejected because the server is in
+// maintenance mode are told to wait before retrying
+const maintenanceRetryAfter = 30 * time.Second
+
+// SetMaintenance implements the APIServer interface
+func (a *apiServer) SetMaintenance(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&a.maintenance, v)
+}
+
+// checkMaintenance returns an Unavailable error, carrying an
+// ec.MaintenanceErrorDetails, if the server is in maintenance mode (or a plain
+// Unavailable error if it has been closed). RPCs that change the cluster's
+// token call it before doing anything else.
+func (a *apiServer) checkMaintenance() error {
+	if err := a.checkClosed(); err != nil {
+		return err
+	}
+	if atomic.LoadInt32(&a.maintenance) == 0 {
+		return nil
+	}
+	const message = "the enterprise server is in maintenance mode, and isn't " +
+		"accepting changes to the cluster's token; try again later"
+	details, err := (&ec.MaintenanceErrorDetails{
+		RetryAfter: types.DurationProto(maintenanceRetryAfter),
+	}).Marshal()
+	if err != nil {
+		return status.Error(codes.Unavailable, message)
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.Unavailable),
+		Message: message,
+		Details: []*any.Any{{
+			TypeUrl: ec.MaintenanceErrorDetailsTypeURL,
+			Value:   details,
+		}},
+	})
+}
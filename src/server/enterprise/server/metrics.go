@@ -0,0 +1,238 @@
+package server
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	logrus "github.com/sirupsen/logrus"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+var (
+	// watchLagSeconds measures how long it takes for a write to the
+	// enterprise token in etcd to be applied to the cache by the watch in
+	// watchEnterpriseToken
+	watchLagSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "watch_lag_seconds",
+		Help:      "Time between an enterprise token being written to etcd and being applied to the enterprise server's cache.",
+		// 1ms to ~16s
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+	})
+
+	// rpcDurationSeconds measures how long each enterprise RPC takes, by
+	// method and grpc status code
+	rpcDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "rpc_duration_seconds",
+		Help:      "Time taken to serve enterprise RPCs.",
+		// Activation code validation takes milliseconds, but RPCs that write
+		// to etcd may take much longer if etcd is slow or unavailable
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "status"})
+
+	// slowConsumersTotal counts the enterprise state subscribers that were
+	// dropped because they didn't keep up with state changes
+	slowConsumersTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "slow_consumers_total",
+		Help:      "Number of enterprise state subscribers dropped for not keeping up with state changes.",
+	})
+
+	// subscriberCount is the current number of enterprise state subscribers
+	subscriberCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "subscribers",
+		Help:      "Current number of enterprise state subscribers (e.g. WatchState callers).",
+	})
+
+	// signatureFailuresTotal counts the activation codes rejected because
+	// their signatures weren't valid for any trusted key
+	signatureFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "signature_failures_total",
+		Help:      "Number of activation codes rejected for having an invalid signature.",
+	})
+
+	// tokenAgeSeconds is how long ago the cluster's enterprise token was
+	// issued, so that clusters running on old tokens can be found
+	tokenAgeSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "token_age_seconds",
+		Help:      "Time since the cluster's enterprise token was issued (0 if there is no token or its issue time is unknown).",
+	})
+
+	// secondsSinceActivation is how long ago the cluster's enterprise token
+	// was activated
+	secondsSinceActivation = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "enterprise",
+		Name:      "seconds_since_activation",
+		Help:      "Time since the cluster's enterprise token was activated (0 if there is no token or its activation time is unknown).",
+	})
+)
+
+// updateTokenMetrics sets tokenAgeSeconds and secondsSinceActivation for the
+// token described by 'info', at time 'now'
+func updateTokenMetrics(info tokenInfo, now time.Time) {
+	age := func(t time.Time) float64 {
+		if t.IsZero() {
+			return 0
+		}
+		return now.Sub(t).Seconds()
+	}
+	tokenAgeSeconds.Set(age(info.issuedAt))
+	secondsSinceActivation.Set(age(info.activatedAt))
+}
+
+// MetricsSnapshot implements the MetricsSnapshot method of APIServer
+func (a *apiServer) MetricsSnapshot() ec.Metrics {
+	info := a.loadTokenInfo()
+	now := a.now()
+	cached, ok := a.enterpriseInfo.Load().(tokenInfo)
+	m := ec.Metrics{
+		Initialized:       ok && !cached.uninitialized,
+		State:             a.state(info, now),
+		Expires:           info.expiry,
+		WatchConnected:    atomic.LoadInt32(&a.watchConnected) > 0,
+		WatchStalled:      atomic.LoadInt32(&a.watchStalled) > 0,
+		SlowConsumers:     counterValue(slowConsumersTotal),
+		SignatureFailures: counterValue(signatureFailuresTotal),
+	}
+	if !info.expiry.IsZero() {
+		m.DaysRemaining = int64(math.Floor(info.expiry.Sub(now).Hours() / 24))
+	}
+	m.LastUpdated, _ = a.lastHealthy.Load().(time.Time)
+	a.subscribersMu.Lock()
+	m.Subscribers = int64(len(a.subscribers))
+	a.subscribersMu.Unlock()
+	return m
+}
+
+// counterValue returns the current value of 'c'
+func counterValue(c prometheus.Counter) uint64 {
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		return 0
+	}
+	return uint64(m.GetCounter().GetValue())
+}
+
+var (
+	initializedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "initialized"),
+		"Whether the enterprise server's cache has been primed from etcd (1) or not (0). Until it has, the state is reported as NONE.",
+		nil, nil)
+	stateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "state"),
+		"The cluster's enterprise state (1 for the current state, 0 for the others). It's NONE until pachyderm_enterprise_initialized is 1.",
+		[]string{"state"}, nil)
+	expiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "expiry_timestamp_seconds"),
+		"Unix time at which the cluster's enterprise token expires (0 if there is no token).",
+		nil, nil)
+	daysRemainingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "days_remaining"),
+		"Whole days until the cluster's enterprise token expires (negative once it has expired, 0 if there is no token).",
+		nil, nil)
+	watchConnectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "watch_connected"),
+		"Whether the enterprise server's watch on the token is connected (1) or not (0).",
+		nil, nil)
+	watchStalledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "watch_stalled"),
+		"Whether the enterprise server's last watch health check found that etcd had stopped delivering events (1) or not (0).",
+		nil, nil)
+	lastUpdatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("pachyderm", "enterprise", "last_updated_timestamp_seconds"),
+		"Unix time at which etcd was last known to be reachable by the enterprise server.",
+		nil, nil)
+)
+
+// snapshotCollector exports a server's MetricsSnapshot to Prometheus, so that
+// the two can't diverge. The snapshot's counters are already exported by the
+// package's registered counters, so it only exports the rest.
+type snapshotCollector struct {
+	a *apiServer
+}
+
+// Describe implements prometheus.Collector
+func (c *snapshotCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- initializedDesc
+	ch <- stateDesc
+	ch <- expiryDesc
+	ch <- daysRemainingDesc
+	ch <- watchConnectedDesc
+	ch <- watchStalledDesc
+	ch <- lastUpdatedDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *snapshotCollector) Collect(ch chan<- prometheus.Metric) {
+	m := c.a.MetricsSnapshot()
+	for value, name := range ec.State_name {
+		current := 0.0
+		if ec.State(value) == m.State {
+			current = 1
+		}
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, current, name)
+	}
+	timestamp := func(t time.Time) float64 {
+		if t.IsZero() {
+			return 0
+		}
+		return float64(t.UnixNano()) / float64(time.Second)
+	}
+	boolValue := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	ch <- prometheus.MustNewConstMetric(initializedDesc, prometheus.GaugeValue, boolValue(m.Initialized))
+	ch <- prometheus.MustNewConstMetric(expiryDesc, prometheus.GaugeValue, timestamp(m.Expires))
+	ch <- prometheus.MustNewConstMetric(daysRemainingDesc, prometheus.GaugeValue, float64(m.DaysRemaining))
+	ch <- prometheus.MustNewConstMetric(watchConnectedDesc, prometheus.GaugeValue, boolValue(m.WatchConnected))
+	ch <- prometheus.MustNewConstMetric(watchStalledDesc, prometheus.GaugeValue, boolValue(m.WatchStalled))
+	ch <- prometheus.MustNewConstMetric(lastUpdatedDesc, prometheus.GaugeValue, timestamp(m.LastUpdated))
+}
+
+// registerSnapshotCollector registers a snapshotCollector for the server with
+// Prometheus. Only one server per process can be registered (pachd only runs
+// one); if another already is, the server's snapshot isn't exported.
+func (a *apiServer) registerSnapshotCollector() {
+	collector := &snapshotCollector{a: a}
+	if err := prometheus.Register(collector); err != nil {
+		logrus.Debugf("not exporting enterprise server metrics snapshot: %v", err)
+		return
+	}
+	a.collector = collector
+}
+
+// unregisterSnapshotCollector undoes registerSnapshotCollector
+func (a *apiServer) unregisterSnapshotCollector() {
+	if a.collector != nil {
+		prometheus.Unregister(a.collector)
+		a.collector = nil
+	}
+}
+
+func init() {
+	prometheus.MustRegister(watchLagSeconds)
+	prometheus.MustRegister(rpcDurationSeconds)
+	prometheus.MustRegister(slowConsumersTotal)
+	prometheus.MustRegister(subscriberCount)
+	prometheus.MustRegister(signatureFailuresTotal)
+	prometheus.MustRegister(tokenAgeSeconds)
+	prometheus.MustRegister(secondsSinceActivation)
+}
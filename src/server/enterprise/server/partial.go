@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+const (
+	// maxActivationParts is the largest number of parts that an activation
+	// code may be split into
+	maxActivationParts = 64
+
+	// maxPartialActivationCodes is the largest number of uploads that
+	// ActivatePartial will track at once. Incomplete uploads are only
+	// discarded once they time out, so without a limit, clients could make
+	// pachd hold an unbounded number of them
+	maxPartialActivationCodes = 16
+
+	// defaultPartialActivationTimeout is the value of
+	// Options.PartialActivationTimeout used when none is set
+	defaultPartialActivationTimeout = 10 * time.Minute
+)
+
+// partialActivationCode is an activation code that ActivatePartial has
+// received some, but not all, of the parts of
+type partialActivationCode struct {
+	total    int64
+	parts    map[int64][]byte
+	size     int
+	deadline time.Time
+}
+
+// removeExpiredPartials discards partial activation codes whose parts didn't
+// all arrive in time. a.partialsMu must be held.
+func (a *apiServer) removeExpiredPartials(now time.Time) {
+	for uploadID, partial := range a.partials {
+		if now.After(partial.deadline) {
+			delete(a.partials, uploadID)
+		}
+	}
+}
+
+// ActivatePartial implements the ActivatePartial RPC
+func (a *apiServer) ActivatePartial(ctx context.Context, req *ec.ActivatePartialRequest) (resp *ec.ActivatePartialResponse, retErr error) {
+	if err := a.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	if req.UploadID == "" {
+		return nil, fmt.Errorf("invalid request: must set upload_id")
+	}
+	if req.Total <= 0 || req.Total > maxActivationParts {
+		return nil, fmt.Errorf("invalid request: total must be between 1 and %d, but was %d", maxActivationParts, req.Total)
+	}
+	if req.Index < 0 || req.Index >= req.Total {
+		return nil, fmt.Errorf("invalid request: index must be between 0 and %d, but was %d", req.Total-1, req.Index)
+	}
+
+	code, received, err := a.addPart(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return &ec.ActivatePartialResponse{Received: received}, nil
+	}
+	if _, err := a.activate(ctx, code, false); err != nil {
+		return nil, err
+	}
+	return &ec.ActivatePartialResponse{Received: received, Activated: true}, nil
+}
+
+// addPart records the part of an activation code in 'req'. If that was the
+// final part, addPart forgets the partial code and returns the assembled code.
+// Otherwise, it returns "" and the number of parts received so far.
+func (a *apiServer) addPart(req *ec.ActivatePartialRequest, now time.Time) (string, int64, error) {
+	a.partialsMu.Lock()
+	defer a.partialsMu.Unlock()
+	if a.partials[req.UploadID] != nil && now.After(a.partials[req.UploadID].deadline) {
+		delete(a.partials, req.UploadID)
+		return "", 0, fmt.Errorf("timed out waiting for the remaining parts of upload %q; "+
+			"all parts must be sent again", req.UploadID)
+	}
+	a.removeExpiredPartials(now)
+
+	partial, ok := a.partials[req.UploadID]
+	if !ok {
+		if len(a.partials) >= maxPartialActivationCodes {
+			return "", 0, grpc.Errorf(codes.ResourceExhausted, "too many activation code "+
+				"uploads are in progress (the maximum is %d); try again later", maxPartialActivationCodes)
+		}
+		partial = &partialActivationCode{
+			total:    req.Total,
+			parts:    make(map[int64][]byte),
+			deadline: now.Add(a.options.PartialActivationTimeout),
+		}
+		a.partials[req.UploadID] = partial
+	}
+	if partial.total != req.Total {
+		return "", 0, fmt.Errorf("invalid request: upload %q has %d parts, but this part "+
+			"claims that it has %d", req.UploadID, partial.total, req.Total)
+	}
+	if existing, ok := partial.parts[req.Index]; ok {
+		// Retrying a part is harmless, but two different parts with the same
+		// index means that the parts are corrupt
+		if !bytes.Equal(existing, req.Part) {
+			return "", 0, fmt.Errorf("invalid request: received two different versions "+
+				"of part %d of upload %q", req.Index, req.UploadID)
+		}
+		return "", int64(len(partial.parts)), nil
+	}
+	if partial.size+len(req.Part) > maxActivationCodeSize {
+		delete(a.partials, req.UploadID)
+		return "", 0, fmt.Errorf("upload %q is larger than the maximum activation code "+
+			"size (%d bytes)", req.UploadID, maxActivationCodeSize)
+	}
+	partial.parts[req.Index] = req.Part
+	partial.size += len(req.Part)
+	received := int64(len(partial.parts))
+	if received < partial.total {
+		return "", received, nil
+	}
+
+	// All parts have arrived
+	delete(a.partials, req.UploadID)
+	var code bytes.Buffer
+	for i := int64(0); i < partial.total; i++ {
+		code.Write(partial.parts[i])
+	}
+	return code.String(), received, nil
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// PrevalidateCode checks whether 'code' would validate against 'pemKeys', the
+// PEM-encoded public keys that another cluster (e.g. the target of a
+// migration or upgrade) trusts, without contacting that cluster. It returns
+// the record that activating the code would write if it would validate. If it
+// wouldn't, the error explains why, and ec.GetActivationErrorDetails returns
+// its reason. Only the code's signature and claims are checked: the target
+// cluster's environment, product and schema version requirements are not.
+func PrevalidateCode(code string, pemKeys []string) (*ec.EnterpriseRecord, error) {
+	if len(pemKeys) == 0 {
+		return nil, fmt.Errorf("must provide at least one public key to validate against")
+	}
+	keys := make([]*rsa.PublicKey, 0, len(pemKeys))
+	for i, pemKey := range pemKeys {
+		key, err := ec.ParsePublicKey(pemKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse public key %d: %s", i, err.Error())
+		}
+		keys = append(keys, key)
+	}
+	record, err := validateActivationCode(code, keys)
+	if err != nil {
+		return nil, toGRPCError(err, "activation code would not validate: ")
+	}
+	return record, nil
+}
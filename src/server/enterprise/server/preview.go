@@ -0,0 +1,100 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/status"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// PreviewCode implements the PreviewCode RPC. It doesn't write anything to
+// etcd.
+func (a *apiServer) PreviewCode(ctx context.Context, req *ec.PreviewCodeRequest) (resp *ec.PreviewCodeResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	proposed, err := a.validate(req.Code)
+	if err != nil {
+		return nil, err
+	}
+	resp = &ec.PreviewCodeResponse{Proposed: claims(proposed)}
+
+	// Read the current token from etcd, rather than the cache, as the cache
+	// doesn't contain all of its claims
+	var current ec.EnterpriseRecord
+	if err := a.conn().enterpriseToken.ReadOnly(ctx).Get(enterpriseTokenKey, &current); err != nil {
+		if _, ok := err.(col.ErrNotFound); !ok {
+			return nil, tokenReadError(err)
+		}
+		resp.AddedFeatures = proposed.Features
+		return resp, nil
+	}
+	resp.Current = claims(&current)
+	resp.AddedFeatures = difference(proposed.Features, current.Features)
+	resp.RemovedFeatures = difference(current.Features, proposed.Features)
+	return resp, nil
+}
+
+// ValidateCodeAsOf implements the ValidateCodeAsOf RPC. Codes that aren't
+// valid are reported in the response, rather than as an error, and aren't
+// counted as signature failures, as audits may check codes signed with keys
+// that have since been rotated out.
+func (a *apiServer) ValidateCodeAsOf(ctx context.Context, req *ec.ValidateCodeAsOfRequest) (resp *ec.ValidateCodeAsOfResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.AsOf == nil {
+		return nil, fmt.Errorf("invalid request: as_of must be set")
+	}
+	asOf, err := types.TimestampFromProto(req.AsOf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: could not parse as_of: %s", err.Error())
+	}
+	record, err := a.validateCodeAsOf(req.Code, asOf)
+	if err != nil {
+		details := ec.GetActivationErrorDetails(err)
+		if details == nil {
+			return nil, err // e.g. the trusted keys couldn't be retrieved
+		}
+		s, _ := status.FromError(err)
+		return &ec.ValidateCodeAsOfResponse{
+			Reason:  details.Reason,
+			Message: s.Message(),
+		}, nil
+	}
+	return &ec.ValidateCodeAsOfResponse{Valid: true, Claims: claims(record)}, nil
+}
+
+// claims returns the claims of the token in 'record'
+func claims(record *ec.EnterpriseRecord) *ec.TokenClaims {
+	return &ec.TokenClaims{
+		Expires:         record.Expires,
+		Features:        record.Features,
+		MaxNodes:        record.MaxNodes,
+		MaxPipelines:    record.MaxPipelines,
+		MaxStorageBytes: record.MaxStorageBytes,
+		Serial:          record.Serial,
+		Environment:     record.Environment,
+	}
+}
+
+// difference returns the elements of 'a' that aren't in 'b', sorted
+func difference(a, b []string) []string {
+	inB := make(map[string]bool)
+	for _, s := range b {
+		inB[s] = true
+	}
+	var result []string
+	for _, s := range a {
+		if !inB[s] {
+			result = append(result, s)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+const (
+	// defaultPullInterval is the value of Options.PullInterval used when none
+	// is set
+	defaultPullInterval = time.Hour
+
+	// defaultPullRetryInitial is the first delay of the default
+	// Options.PullRetry
+	defaultPullRetryInitial = 10 * time.Second
+)
+
+// errNotLater is returned by pullCode's precondition if the pulled code
+// doesn't expire later than the cluster's current token
+var errNotLater = errors.New("the activation code doesn't expire later than the cluster's token")
+
+// pullCodePeriodically calls pullCode when the server starts and then every
+// Options.PullInterval, until the server is closed. After a failed pull, the
+// next one is made after the next Options.PullRetry delay instead, if that's
+// sooner.
+func (a *apiServer) pullCodePeriodically() {
+	retry := a.options.PullRetry
+	retry.Reset()
+	for {
+		if !a.startAsync() {
+			return // Close has been called
+		}
+		_, err := a.pullCode(a.ctx)
+		a.async.Done()
+		wait := a.options.PullInterval
+		if err != nil {
+			if a.ctx.Err() != nil {
+				return // the pull failed because the server was closed
+			}
+			if d := retry.NextBackOff(); d != backoff.Stop && d < wait {
+				wait = d
+			}
+			logrus.Errorf("%v; retrying in %v", err, wait)
+		} else {
+			retry.Reset()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-a.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// pullCode fetches an activation code with Options.FetchCode and activates it
+// if it's valid and expires later than the cluster's current token. It
+// returns true if the code was activated. A code that doesn't expire later
+// (e.g. because the license server still has the code that the cluster was
+// last renewed with) isn't an error. Pulls are skipped while the server is in
+// maintenance mode.
+func (a *apiServer) pullCode(ctx context.Context) (bool, error) {
+	if err := a.checkMaintenance(); err != nil {
+		return false, nil
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, a.options.ActivationURLTimeout)
+	code, err := a.options.FetchCode(fetchCtx)
+	cancel()
+	if err != nil {
+		return false, fmt.Errorf("error fetching activation code from the license server: %v", err)
+	}
+	record, err := a.validate(code)
+	if err != nil {
+		return false, fmt.Errorf("the license server returned an invalid activation code: %v", err)
+	}
+	expires, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return false, fmt.Errorf("could not parse expiration timestamp: %s", err.Error())
+	}
+	// Compare the expiries in the same transaction as the write, so that a
+	// token activated concurrently is never replaced by an earlier-expiring one
+	err = a.writeRecordIf(ctx, record, false, false, func(current *ec.EnterpriseRecord) error {
+		currentExpires, err := types.TimestampFromProto(current.Expires)
+		if err == nil && !expires.After(currentExpires) {
+			return errNotLater
+		}
+		return nil
+	})
+	if err == errNotLater {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not activate the activation code from the license server: %v", err)
+	}
+	logrus.Printf("activated an enterprise token pulled from the license server, which expires at %s",
+		expires.Format(time.RFC3339))
+	return true, nil
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// Rebuild implements the Rebuild RPC
+func (a *apiServer) Rebuild(ctx context.Context, req *ec.RebuildRequest) (resp *ec.RebuildResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := a.rebuild(ctx); err != nil {
+		return nil, err
+	}
+	return &ec.RebuildResponse{State: a.cachedState()}, nil
+}
+
+// rebuild replaces everything that the server caches about the enterprise
+// token with what can be derived from etcd: the token itself, and the most
+// recent activation history record. Unlike refreshState, it doesn't trust any
+// part of the existing cache, so the result depends only on etcd's contents.
+func (a *apiServer) rebuild(ctx context.Context) error {
+	info := tokenInfo{}
+	var record ec.EnterpriseRecord
+	if err := a.conn().enterpriseToken.ReadOnly(ctx).Get(enterpriseTokenKey, &record); err != nil {
+		if _, ok := err.(col.ErrNotFound); !ok {
+			return tokenReadError(err)
+		}
+	} else {
+		if info, err = newTokenInfo(&record); err != nil {
+			return err
+		}
+		// Records written before activated_at was added don't say when they
+		// were activated, but their history record does
+		if info.activatedAt.IsZero() {
+			latest, err := a.latestHistoryRecord(ctx)
+			if err != nil {
+				return err
+			}
+			if latest != nil && latest.ActivationCodeFingerprint == CodeFingerprint(record.ActivationCode) {
+				if info.activatedAt, err = types.TimestampFromProto(latest.Activated); err != nil {
+					return fmt.Errorf("could not parse activation timestamp: %s", err.Error())
+				}
+			}
+		}
+	}
+	a.setTokenInfo(info)
+	// The warning inputs were collected for the old cache; recollect them for
+	// the rebuilt one
+	a.warningInputs.Store(warningInputs{nodeCount: -1})
+	a.refreshWarningInputs()
+	return nil
+}
+
+// latestHistoryRecord returns the most recently written activation history
+// record, or nil if the history is empty
+func (a *apiServer) latestHistoryRecord(ctx context.Context) (*ec.ActivationHistoryRecord, error) {
+	// List returns the most recently written records first
+	iter, err := a.conn().activationHistory.ReadOnly(ctx).List()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var key string
+	record := &ec.ActivationHistoryRecord{}
+	ok, err := iter.Next(&key, record)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+// watchHealthyPeriod is how long the token's watch must stay connected for
+// its next failure to count as the first after a healthy stretch (see
+// Options.ImmediateWatchReconnect)
+const watchHealthyPeriod = 10 * time.Second
+
+// defaultWatchRetryFloor is the value of Options.WatchRetryFloor used when none
+// is set
+const defaultWatchRetryFloor = 250 * time.Millisecond
+
+// reconnectBackOff is the backoff policy that watchEnterpriseToken
+// re-establishes its watch with. Normally it's just 'inner', but if
+// 'immediate' is set, the first retry after the watch was healthy (connected
+// for at least watchHealthyPeriod) is immediate, and 'inner' starts over for
+// any failures that follow. Other retries wait at least 'floor'.
+type reconnectBackOff struct {
+	inner     backoff.BackOff
+	immediate bool
+	floor     time.Duration
+	// connectedAt is when the current attempt's watch connected, or the zero
+	// time if it never did
+	connectedAt time.Time
+}
+
+// connected records that the current attempt's watch connected at 'now'
+func (b *reconnectBackOff) connected(now time.Time) {
+	b.connectedAt = now
+}
+
+// Reset implements backoff.BackOff
+func (b *reconnectBackOff) Reset() {
+	b.inner.Reset()
+	b.connectedAt = time.Time{}
+}
+
+// NextBackOff implements backoff.BackOff
+func (b *reconnectBackOff) NextBackOff() time.Duration {
+	healthy := !b.connectedAt.IsZero() && time.Since(b.connectedAt) >= watchHealthyPeriod
+	b.connectedAt = time.Time{}
+	if b.immediate && healthy {
+		b.inner.Reset()
+		return 0
+	}
+	d := b.inner.NextBackOff()
+	if d != backoff.Stop && d < b.floor {
+		return b.floor
+	}
+	return d
+}
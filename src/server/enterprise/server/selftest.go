@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/uuid"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+const (
+	// selfTestSuffix is appended to the enterprise etcd prefix to get the
+	// prefix of the collection that SelfTest writes its sentinel records to,
+	// so that they're never mistaken for the token
+	selfTestSuffix = "_selftest"
+
+	// selfTestTimeout bounds how long SelfTest waits for etcd
+	selfTestTimeout = 30 * time.Second
+
+	// selfTestCode is the activation code of SelfTest's sentinel records. It
+	// isn't a valid code, so a sentinel record can never be activated
+	selfTestCode = "enterprise-self-test"
+)
+
+// SelfTest implements the SelfTest RPC. Failures of the test itself are
+// reported in the response, rather than as an error.
+func (a *apiServer) SelfTest(ctx context.Context, req *ec.SelfTestRequest) (resp *ec.SelfTestResponse, retErr error) {
+	if err := a.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+	defer cancel()
+	conn := a.conn()
+	key := uuid.NewWithoutDashes()
+	resp = &ec.SelfTestResponse{}
+	fail := func(format string, args ...interface{}) (*ec.SelfTestResponse, error) {
+		resp.Error = fmt.Sprintf(format, args...)
+		logrus.Errorf("enterprise self-test failed: %s", resp.Error)
+		return resp, nil
+	}
+
+	// Watch before writing, so that the write can't be missed
+	watcher, err := conn.selfTest.ReadOnly(ctx).WatchOne(key)
+	if err != nil {
+		return fail("could not watch the sentinel record: %v", err)
+	}
+	defer watcher.Close()
+
+	start := time.Now()
+	if _, err := col.NewSTM(ctx, conn.etcdClient, func(stm col.STM) error {
+		return conn.selfTest.ReadWrite(stm).Put(key, &ec.EnterpriseRecord{ActivationCode: selfTestCode})
+	}); err != nil {
+		return fail("could not write the sentinel record: %v", err)
+	}
+	resp.WriteDuration = types.DurationProto(time.Since(start))
+	// Remove the sentinel record even if a later step fails
+	deleted := false
+	defer func() {
+		if !deleted {
+			if err := deleteSelfTestRecord(conn, key); err != nil {
+				logrus.Errorf("could not remove enterprise self-test record %s: %v", key, err)
+			}
+		}
+	}()
+
+	start = time.Now()
+	for observed := false; !observed; {
+		select {
+		case event, ok := <-watcher.Watch():
+			if !ok {
+				return fail("the watch ended before observing the sentinel record")
+			}
+			if event.Type == watch.EventError {
+				return fail("error watching the sentinel record: %v", event.Err)
+			}
+			observed = event.Type == watch.EventPut
+		case <-ctx.Done():
+			return fail("timed out waiting for the watch to observe the sentinel record")
+		}
+	}
+	resp.WatchDuration = types.DurationProto(time.Since(start))
+
+	start = time.Now()
+	var record ec.EnterpriseRecord
+	if err := conn.selfTest.ReadOnly(ctx).Get(key, &record); err != nil {
+		return fail("could not read the sentinel record: %v", err)
+	}
+	if record.ActivationCode != selfTestCode {
+		return fail("read back a different sentinel record than was written")
+	}
+	resp.ReadDuration = types.DurationProto(time.Since(start))
+
+	start = time.Now()
+	deleted = true
+	if err := deleteSelfTestRecord(conn, key); err != nil {
+		return fail("could not remove the sentinel record: %v", err)
+	}
+	resp.DeleteDuration = types.DurationProto(time.Since(start))
+	resp.Success = true
+	return resp, nil
+}
+
+// deleteSelfTestRecord removes the sentinel record 'key' written by SelfTest.
+// It uses its own context, so that the record is removed even if SelfTest's
+// has been canceled.
+func deleteSelfTestRecord(conn *etcdConn, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), selfTestTimeout)
+	defer cancel()
+	_, err := col.NewSTM(ctx, conn.etcdClient, func(stm col.STM) error {
+		return conn.selfTest.ReadWrite(stm).Delete(key)
+	})
+	return err
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultShutdownTimeout is the value of Options.ShutdownTimeout used when
+// none is set
+const defaultShutdownTimeout = 10 * time.Second
+
+// startAsync registers a background work item that Close must wait for. It
+// returns false if the server is closed, in which case the work must not be
+// done. Otherwise, the caller must call a.async.Done() once the work is done.
+func (a *apiServer) startAsync() bool {
+	a.asyncMu.Lock()
+	defer a.asyncMu.Unlock()
+	if a.closed {
+		return false
+	}
+	a.async.Add(1)
+	return true
+}
+
+// checkClosed returns an Unavailable error if the server has been closed.
+// RPCs that change the cluster's token call it (via checkMaintenance) before
+// doing anything else.
+func (a *apiServer) checkClosed() error {
+	a.asyncMu.Lock()
+	defer a.asyncMu.Unlock()
+	if a.closed {
+		return status.Error(codes.Unavailable, "the enterprise server is shutting down")
+	}
+	return nil
+}
+
+// Close implements the Close method of APIServer
+func (a *apiServer) Close() error {
+	a.asyncMu.Lock()
+	if a.closed {
+		a.asyncMu.Unlock()
+		return nil
+	}
+	a.closed = true
+	a.asyncMu.Unlock()
+
+	// No new work can start now, so wait for the work in flight. The
+	// background goroutines keep running until it's done, as it may depend on
+	// them (e.g. history compaction uses a.ctx).
+	drained := make(chan struct{})
+	go func() {
+		a.async.Wait()
+		close(drained)
+	}()
+	var retErr error
+	select {
+	case <-drained:
+	case <-time.After(a.options.ShutdownTimeout):
+		retErr = fmt.Errorf("timed out after %v waiting for the enterprise "+
+			"server's background work to finish", a.options.ShutdownTimeout)
+	}
+
+	a.cancel()
+	a.unregisterSnapshotCollector()
+	a.subscribersMu.Lock()
+	if a.emergencyTimer != nil {
+		a.emergencyTimer.Stop()
+	}
+	if a.injectionTimer != nil {
+		a.injectionTimer.Stop()
+	}
+	a.subscribersMu.Unlock()
+	if err := a.conn().close(); err != nil && retErr == nil {
+		retErr = err
+	}
+	return retErr
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultSignatureFailureWindow is the value of
+// Options.SignatureFailureWindow used when none is set
+const defaultSignatureFailureWindow = time.Minute
+
+// signatureFailureTracker keeps the times of the signature failures within
+// Options.SignatureFailureWindow, so that recordSignatureFailure can tell
+// when Options.SignatureFailureThreshold is exceeded
+type signatureFailureTracker struct {
+	mu sync.Mutex
+	// times are the failures within the window, oldest first. Only the
+	// newest threshold+1 are kept, which is enough to tell that the threshold
+	// is exceeded
+	times []time.Time
+	// alerted is set once OnSignatureFailures has been called for the current
+	// spike, and cleared once the failures drop back below the threshold
+	alerted bool
+}
+
+// recordSignatureFailure counts an activation code rejected at 'now' for
+// having an invalid signature, and calls Options.OnSignatureFailures if this
+// failure exceeds the threshold
+func (a *apiServer) recordSignatureFailure(now time.Time) {
+	signatureFailuresTotal.Inc()
+	if a.options.OnSignatureFailures == nil {
+		return
+	}
+	t := &a.signatureFailures
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cutoff := now.Add(-a.options.SignatureFailureWindow)
+	for len(t.times) > 0 && t.times[0].Before(cutoff) {
+		t.times = t.times[1:]
+	}
+	t.times = append(t.times, now)
+	if len(t.times) > a.options.SignatureFailureThreshold+1 {
+		t.times = t.times[1:]
+	}
+	if len(t.times) <= a.options.SignatureFailureThreshold {
+		t.alerted = false
+		return
+	}
+	if !t.alerted && a.startAsync() {
+		t.alerted = true
+		failures := len(t.times)
+		go func() {
+			defer a.async.Done()
+			a.options.OnSignatureFailures(failures, a.options.SignatureFailureWindow)
+		}()
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/uuid"
+)
+
+const (
+	// maxStagedActivations is the largest number of activation codes that
+	// StageActivation will hold at once. Like partial activation codes, staged
+	// codes are only discarded once they're committed or time out
+	maxStagedActivations = 16
+
+	// defaultStagedActivationTimeout is the value of
+	// Options.StagedActivationTimeout used when none is set
+	defaultStagedActivationTimeout = 10 * time.Minute
+)
+
+// stagedActivation is an activation code that StageActivation has validated,
+// and that CommitActivation may activate until 'deadline'
+type stagedActivation struct {
+	code     string
+	deadline time.Time
+}
+
+// removeExpiredStages discards staged activation codes that weren't committed
+// in time. a.stagedMu must be held.
+func (a *apiServer) removeExpiredStages(now time.Time) {
+	for stageID, staged := range a.staged {
+		if now.After(staged.deadline) {
+			delete(a.staged, stageID)
+		}
+	}
+}
+
+// StageActivation implements the StageActivation RPC
+func (a *apiServer) StageActivation(ctx context.Context, req *ec.StageActivationRequest) (resp *ec.StageActivationResponse, retErr error) {
+	if _, err := a.validate(req.ActivationCode); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	staged := &stagedActivation{
+		code:     req.ActivationCode,
+		deadline: now.Add(a.options.StagedActivationTimeout),
+	}
+	expires, err := types.TimestampProto(staged.deadline)
+	if err != nil {
+		return nil, err
+	}
+	stageID := uuid.NewWithoutDashes()
+
+	a.stagedMu.Lock()
+	defer a.stagedMu.Unlock()
+	a.removeExpiredStages(now)
+	if len(a.staged) >= maxStagedActivations {
+		return nil, grpc.Errorf(codes.ResourceExhausted, "too many activation codes "+
+			"are staged (the maximum is %d); try again later", maxStagedActivations)
+	}
+	a.staged[stageID] = staged
+	return &ec.StageActivationResponse{StageID: stageID, Expires: expires}, nil
+}
+
+// CommitActivation implements the CommitActivation RPC. The staged code is
+// validated again, in case e.g. the trusted keys have changed since it was
+// staged
+func (a *apiServer) CommitActivation(ctx context.Context, req *ec.CommitActivationRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.checkMaintenance(); err != nil {
+		return nil, err
+	}
+	staged, err := a.takeStage(req.StageID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	record, err := a.activate(ctx, staged.code, false)
+	if err != nil {
+		// Let the caller retry the commit, e.g. if etcd was unavailable
+		a.stagedMu.Lock()
+		a.staged[req.StageID] = staged
+		a.stagedMu.Unlock()
+		return nil, err
+	}
+	return a.activateResponse(record)
+}
+
+// takeStage removes and returns the staged activation code 'stageID', so that
+// concurrent commits of the same stage can't both activate it
+func (a *apiServer) takeStage(stageID string, now time.Time) (*stagedActivation, error) {
+	if stageID == "" {
+		return nil, fmt.Errorf("invalid request: must set stage_id")
+	}
+	a.stagedMu.Lock()
+	defer a.stagedMu.Unlock()
+	staged, ok := a.staged[stageID]
+	if !ok {
+		return nil, grpc.Errorf(codes.NotFound, "no activation code is staged with ID %q", stageID)
+	}
+	delete(a.staged, stageID)
+	if now.After(staged.deadline) {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "the activation code staged "+
+			"with ID %q expired at %s; it must be staged again", stageID,
+			staged.deadline.Format(time.RFC3339))
+	}
+	return staged, nil
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"time"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// stateEntry records that the cluster entered 'state' at 'at'
+type stateEntry struct {
+	state ec.State
+	at    time.Time
+}
+
+// recordStateEntered records that the cluster entered 'state' at 'at'. It's
+// called by setTokenInfo when an update to the token changes the state.
+func (a *apiServer) recordStateEntered(state ec.State, at time.Time) {
+	a.stateEnteredMu.Lock()
+	defer a.stateEnteredMu.Unlock()
+	a.stateEntered = stateEntry{state: state, at: at}
+}
+
+// stateEnteredAt returns when the cluster, whose token is described by
+// 'info', entered the state that it's in at 'now'. If the server didn't
+// observe the transition (because it happened before the server started, or
+// because the token expired rather than being updated), the time is derived
+// from the token: its activation time if it's ACTIVE, and the end of its
+// grace period if it's EXPIRED. Otherwise (or if the token doesn't record
+// when it was activated), 'now' is used, as the latest time that it could be.
+func (a *apiServer) stateEnteredAt(info tokenInfo, now time.Time) time.Time {
+	state := a.state(info, now)
+	a.stateEnteredMu.Lock()
+	defer a.stateEnteredMu.Unlock()
+	if a.stateEntered.state == state && !a.stateEntered.at.IsZero() {
+		return a.stateEntered.at
+	}
+	at := now
+	switch state {
+	case ec.State_ACTIVE:
+		if !info.activatedAt.IsZero() && info.activatedAt.Before(now) {
+			at = info.activatedAt
+		}
+	case ec.State_EXPIRED:
+		if end := a.effectiveExpiry(info).Add(a.options.GracePeriod); end.Before(now) {
+			at = end
+		}
+	}
+	a.stateEntered = stateEntry{state: state, at: at}
+	return at
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// trialActivationTimeout bounds each attempt by maybeActivateTrial to
+// activate a trial token
+const trialActivationTimeout = 30 * time.Second
+
+// maybeActivateTrial activates a trial token generated by
+// Options.TrialGenerator, if trials are enabled and the cluster has no token.
+// It's called by intercept and interceptStream before every enterprise RPC,
+// but stops doing anything once a trial has been stored or the cluster is
+// seen to have a token; concurrent callers wait for the attempt in progress
+// to finish, so that they all observe the trial. Failures are logged, rather
+// than failing the RPC, and the next RPC tries again. Attempts run under the
+// server's context rather than the RPC's, so that a canceled RPC doesn't
+// abandon one.
+func (a *apiServer) maybeActivateTrial() {
+	if !a.options.EnableTrials || atomic.LoadInt32(&a.trialDone) != 0 {
+		return
+	}
+	a.trialMu.Lock()
+	defer a.trialMu.Unlock()
+	if atomic.LoadInt32(&a.trialDone) != 0 {
+		return // activated by the attempt that we waited for
+	}
+	if a.checkMaintenance() != nil {
+		return // try again once the server accepts changes to the token
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, trialActivationTimeout)
+	defer cancel()
+	info, err := a.cachedTokenInfo(ctx)
+	if err != nil {
+		logrus.Errorf("could not check whether to activate a trial enterprise token: %v", err)
+		return
+	}
+	if a.state(info, a.now()) != ec.State_NONE {
+		atomic.StoreInt32(&a.trialDone, 1)
+		return
+	}
+	if err := a.activateTrial(ctx); err != nil {
+		logrus.Errorf("could not activate a trial enterprise token: %v", err)
+		return
+	}
+	atomic.StoreInt32(&a.trialDone, 1)
+}
+
+// activateTrial generates a trial activation code, validates it like any
+// other code, and stores it, unless the cluster has acquired a token in the
+// meantime. Like other changes to the token, it fails if the server is in
+// maintenance mode or closed.
+func (a *apiServer) activateTrial(ctx context.Context) error {
+	if err := a.checkMaintenance(); err != nil {
+		return err
+	}
+	code, err := a.options.TrialGenerator()
+	if err != nil {
+		return fmt.Errorf("error generating trial activation code: %v", err)
+	}
+	record, err := a.validate(code)
+	if err != nil {
+		return err
+	}
+	conn := a.conn()
+	created := false
+	a.mutateMu.Lock()
+	_, err = col.NewSTM(ctx, conn.etcdClient, func(stm col.STM) error {
+		e := conn.enterpriseToken.ReadWrite(stm)
+		created = false
+		var current ec.EnterpriseRecord
+		if err := e.Get(enterpriseTokenKey, &current); err == nil {
+			return nil // never replace a real token with a trial
+		} else if _, ok := err.(col.ErrNotFound); !ok {
+			return tokenReadError(err)
+		}
+		now := time.Now()
+		written, err := types.TimestampProto(now)
+		if err != nil {
+			return err
+		}
+		record.Written = written
+		record.ActivatedAt = written
+		if err := a.checkRecordSize(conn.codec, record); err != nil {
+			return err
+		}
+		if err := e.Create(enterpriseTokenKey, record); err != nil {
+			return err
+		}
+		created = true
+		return a.putHistoryRecord(stm, now, &ec.ActivationHistoryRecord{
+			Activated:                 written,
+			Expires:                   record.Expires,
+			ActivationCodeFingerprint: CodeFingerprint(record.ActivationCode),
+			Features:                  record.Features,
+		})
+	})
+	a.mutateMu.Unlock()
+	if err != nil {
+		return storeError(err)
+	}
+	if created {
+		a.audit(ctx, AuditActivate, record.ActivationCode)
+		expires, err := types.TimestampFromProto(record.Expires)
+		if err != nil {
+			return err
+		}
+		logrus.Printf("activated a trial enterprise token for the cluster, which expires at %s",
+			expires.Format(time.RFC3339))
+	}
+	// Don't wait for the watch, so that the RPC that triggered the trial
+	// observes the new token (whether it's the trial or not)
+	return a.refreshState(ctx)
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// A warningEvaluator examines the cluster's cached enterprise token and
+// returns a warning for the user, or "" if it has nothing to report.
+// Evaluators are independent of each other and of cachedState(), so GetState
+// may return any combination of their warnings alongside its State.
+type warningEvaluator func(a *apiServer, info tokenInfo, now time.Time) (string, error)
+
+// warningEvaluators are run, in order, by every GetState call
+var warningEvaluators = []warningEvaluator{
+	expiryWindowWarning,
+	gracePeriodWarning,
+	nodeLimitWarning,
+	revocationWarning,
+	emergencyOverrideWarning,
+	clockWarning,
+}
+
+// maxClockSkew is how far the server's clock may be behind the issuer's
+// (i.e. how long before the token's IssuedAt the server may think it is)
+// before clockWarning reports that the clock may be incorrect
+const maxClockSkew = 5 * time.Minute
+
+// warningInputs are the results of the Options callbacks that the
+// warningEvaluators depend on. They're collected in the background by
+// refreshWarningInputs, as the callbacks may be slow (e.g. they may call the
+// kubernetes API), and GetState must not wait for them.
+type warningInputs struct {
+	// nodeCount is the number of nodes in the cluster, or -1 if it's unknown
+	nodeCount int64
+	// revokedCode is the activation code that 'revoked' applies to
+	revokedCode string
+	revoked     bool
+}
+
+// refreshWarningInputs runs Options.NodeCount and Options.IsRevoked, and
+// caches their results for the warningEvaluators. A callback that fails is
+// logged, and its previous result is kept.
+func (a *apiServer) refreshWarningInputs() {
+	inputs, ok := a.warningInputs.Load().(warningInputs)
+	if !ok {
+		inputs = warningInputs{nodeCount: -1}
+	}
+	if a.options.NodeCount != nil {
+		nodes, err := a.options.NodeCount()
+		if err != nil {
+			logrus.Errorf("could not count nodes: %v", err)
+		} else {
+			inputs.nodeCount = nodes
+		}
+	}
+	info := a.loadTokenInfo()
+	if a.options.IsRevoked != nil && info.activationCode != "" {
+		revoked, err := a.options.IsRevoked(info.activationCode)
+		if err != nil {
+			logrus.Errorf("could not check whether the activation code was revoked: %v", err)
+		} else {
+			inputs.revokedCode, inputs.revoked = info.activationCode, revoked
+		}
+	}
+	a.warningInputs.Store(inputs)
+}
+
+// watchWarningInputs calls refreshWarningInputs every
+// Options.WarningInputsInterval, and whenever the token changes
+func (a *apiServer) watchWarningInputs() {
+	ticker := time.NewTicker(a.options.WarningInputsInterval)
+	defer ticker.Stop()
+	for {
+		a.refreshWarningInputs()
+		select {
+		case <-ticker.C:
+		case <-a.inputsStale:
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// warnings runs all warningEvaluators against the cached tokenInfo and
+// warningInputs. An evaluator that fails is logged and skipped, so that
+// GetState still succeeds if e.g. the cluster's nodes couldn't be counted.
+func (a *apiServer) warnings() []string {
+	var warnings []string
+	if warning := stateInjectionWarning(a); warning != "" {
+		warnings = append(warnings, warning)
+	}
+	info := a.loadTokenInfo()
+	if info.expiry.IsZero() {
+		return warnings
+	}
+	now := a.now()
+	for _, evaluate := range warningEvaluators {
+		warning, err := evaluate(a, info, now)
+		if err != nil {
+			logrus.Errorf("could not evaluate enterprise warning: %v", err)
+			continue
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+// expiryWindowWarning warns that the token expires within the configured
+// ExpiryWarningWindow
+func expiryWindowWarning(a *apiServer, info tokenInfo, now time.Time) (string, error) {
+	expiry := a.effectiveExpiry(info)
+	if now.After(expiry) || expiry.Sub(now) > a.options.ExpiryWarningWindow {
+		return "", nil
+	}
+	return fmt.Sprintf("the Pachyderm Enterprise token expires soon, at %s",
+		expiry.Format(time.RFC3339)), nil
+}
+
+// gracePeriodWarning warns that the token has expired, but that enterprise
+// features remain enabled until the grace period ends
+func gracePeriodWarning(a *apiServer, info tokenInfo, now time.Time) (string, error) {
+	expiry := a.effectiveExpiry(info)
+	end := expiry.Add(a.options.GracePeriod)
+	if !now.After(expiry) || now.After(end) {
+		return "", nil
+	}
+	return fmt.Sprintf("the Pachyderm Enterprise token expired at %s; enterprise "+
+		"features will be disabled when the grace period ends at %s",
+		expiry.Format(time.RFC3339), end.Format(time.RFC3339)), nil
+}
+
+// nodeLimitWarning warns that the cluster has more nodes than the token allows
+func nodeLimitWarning(a *apiServer, info tokenInfo, now time.Time) (string, error) {
+	inputs, ok := a.warningInputs.Load().(warningInputs)
+	if !ok {
+		return "", fmt.Errorf("could not retrieve cached node count")
+	}
+	nodes := inputs.nodeCount
+	if info.maxNodes == 0 || nodes < 0 || nodes <= info.maxNodes {
+		return "", nil
+	}
+	return fmt.Sprintf("the cluster has %d nodes, but the Pachyderm Enterprise "+
+		"token only permits %d", nodes, info.maxNodes), nil
+}
+
+// revocationWarning warns that the token has been revoked
+func revocationWarning(a *apiServer, info tokenInfo, now time.Time) (string, error) {
+	inputs, ok := a.warningInputs.Load().(warningInputs)
+	if !ok {
+		return "", fmt.Errorf("could not retrieve cached revocation status")
+	}
+	// If the token has changed since the last refresh, its revocation status
+	// isn't known yet
+	if inputs.revokedCode != info.activationCode || !inputs.revoked {
+		return "", nil
+	}
+	return "the Pachyderm Enterprise token has been revoked; please contact " +
+		"Pachyderm for a new activation code", nil
+}
+
+// clockWarning warns that the server's clock is well before the token's
+// IssuedAt, in which case it's probably wrong, and so are any decisions based
+// on the token's expiry. The first warning for each token is also logged.
+func clockWarning(a *apiServer, info tokenInfo, now time.Time) (string, error) {
+	if info.issuedAt.IsZero() || !now.Before(info.issuedAt.Add(-maxClockSkew)) {
+		a.clockWarnedFor.Store(time.Time{})
+		return "", nil
+	}
+	warning := fmt.Sprintf("the system clock may be incorrect: it reads %s, but "+
+		"the Pachyderm Enterprise token was issued at %s, so expiry decisions may "+
+		"be wrong", now.Format(time.RFC3339), info.issuedAt.Format(time.RFC3339))
+	if warned, _ := a.clockWarnedFor.Load().(time.Time); !warned.Equal(info.issuedAt) {
+		a.clockWarnedFor.Store(info.issuedAt)
+		logrus.Warnf("%s", warning)
+	}
+	return warning, nil
+}
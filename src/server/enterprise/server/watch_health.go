@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/uuid"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+// watchHealthKeyPrefix prefixes the keys of the sentinel records written by
+// the watch health check, in the self-test collection. Each server writes
+// its own, so that servers sharing an etcd prefix don't see each other's
+const watchHealthKeyPrefix = "watch-health-"
+
+// checkWatchHealthPeriodically calls checkWatchHealth every
+// Options.WatchHealthCheckInterval, until the server is closed. It keeps one
+// watch on its sentinel record open throughout, as the watches that it vouches
+// for are long-lived, and only replaces it if a check fails or ReconnectEtcd
+// replaces the server's clients.
+func (a *apiServer) checkWatchHealthPeriodically() {
+	key := watchHealthKeyPrefix + uuid.NewWithoutDashes()
+	ticker := time.NewTicker(a.options.WatchHealthCheckInterval)
+	defer ticker.Stop()
+	var conn *etcdConn
+	var watcher watch.Watcher
+	defer func() {
+		if watcher != nil {
+			watcher.Close()
+		}
+	}()
+	var seq int64
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.ctx.Done():
+			return
+		}
+		if watcher == nil || conn != a.conn() {
+			if watcher != nil {
+				watcher.Close()
+			}
+			conn = a.conn()
+			var err error
+			if watcher, err = conn.selfTest.ReadOnly(a.ctx).WatchOne(key); err != nil {
+				watcher = nil
+				a.watchHealthFailed(fmt.Errorf("could not watch the sentinel record: %v", err))
+				continue
+			}
+		}
+		seq++
+		checked := time.Now()
+		if err := a.checkWatchHealth(conn, watcher, key, seq); err != nil {
+			watcher.Close()
+			watcher = nil
+			a.watchHealthFailed(err)
+			continue
+		}
+		a.lastRoundTrip.Store(checked)
+		if atomic.SwapInt32(&a.watchStalled, 0) > 0 {
+			logrus.Printf("enterprise watch health check succeeded; etcd is delivering events again")
+		}
+	}
+}
+
+// checkWatchHealth writes the sentinel record 'key', with serial 'seq', and
+// returns an error unless 'watcher' observes it within
+// Options.WatchHealthCheckInterval
+func (a *apiServer) checkWatchHealth(conn *etcdConn, watcher watch.Watcher, key string, seq int64) error {
+	interval := a.options.WatchHealthCheckInterval
+	ctx, cancel := context.WithTimeout(a.ctx, interval)
+	defer cancel()
+	if _, err := col.NewSTM(ctx, conn.etcdClient, func(stm col.STM) error {
+		return conn.selfTest.ReadWrite(stm).PutTTL(key,
+			&ec.EnterpriseRecord{ActivationCode: selfTestCode, Serial: seq},
+			leaseSeconds(3*interval))
+	}); err != nil {
+		return fmt.Errorf("could not write the sentinel record: %v", err)
+	}
+	for {
+		select {
+		case event, ok := <-watcher.Watch():
+			if !ok {
+				return fmt.Errorf("the watch on the sentinel record ended")
+			}
+			if event.Type == watch.EventError {
+				return fmt.Errorf("error watching the sentinel record: %v", event.Err)
+			}
+			if event.Type != watch.EventPut {
+				continue
+			}
+			// Skip any earlier writes that are still being delivered
+			var record ec.EnterpriseRecord
+			if err := conn.codec.Unmarshal(event.Value, &record); err == nil && record.Serial >= seq {
+				return nil
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("the watch did not observe the sentinel record within %v", interval)
+		}
+	}
+}
+
+// watchHealthFailed records that a watch health check failed with 'err'
+func (a *apiServer) watchHealthFailed(err error) {
+	a.lastError.Store(fmt.Sprintf("enterprise watch health check failed: %v", err))
+	if atomic.SwapInt32(&a.watchStalled, 1) == 0 {
+		logrus.Errorf("enterprise watch health check failed: %v; GetState will "+
+			"report that its state is stale until a check succeeds", err)
+	}
+}
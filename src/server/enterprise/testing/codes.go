@@ -0,0 +1,68 @@
+package testing
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// GenerateTestCode returns an activation code whose token makes 'claims'
+// (except KeyID, which is derived from the key), signed with 'priv'. A server
+// that trusts priv's public key accepts it. Times are encoded with one-second
+// precision, as in real tokens. The same arguments always produce the same
+// code. It's meant for tests only, and panics if the code can't be generated.
+func GenerateTestCode(priv *rsa.PrivateKey, claims ec.Claims) string {
+	token := ec.Token{
+		Expiry:             claims.Expires.Format(time.RFC3339),
+		MaxNodes:           claims.MaxNodes,
+		MaxPipelines:       claims.MaxPipelines,
+		MaxStorageBytes:    claims.MaxStorageBytes,
+		Serial:             claims.Serial,
+		Env:                claims.Environment,
+		SchemaVersion:      claims.SchemaVersion,
+		SLA:                claims.SLA,
+		Product:            claims.Product,
+		DeprecatedFeatures: claims.DeprecatedFeatures,
+		MinVersion:         claims.MinVersion,
+		MaxVersion:         claims.MaxVersion,
+	}
+	if !claims.IssuedAt.IsZero() {
+		token.IssuedAt = claims.IssuedAt.Format(time.RFC3339)
+	}
+	if len(claims.FeatureExpiries) > 0 {
+		token.FeatureExpiries = make(map[string]string)
+		for feature, expiry := range claims.FeatureExpiries {
+			token.FeatureExpiries[feature] = expiry.Format(time.RFC3339)
+		}
+	}
+	if len(claims.Features) > 0 {
+		token.Scopes = make(map[string]bool)
+		for _, feature := range claims.Features {
+			token.Scopes[feature] = true
+		}
+	}
+	tokenJSON, err := json.Marshal(token)
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal test token: %v", err))
+	}
+	// PKCS #1 v1.5 signatures are deterministic, so no randomness is needed
+	hashedToken := sha256.Sum256(tokenJSON)
+	signature, err := rsa.SignPKCS1v15(nil, priv, crypto.SHA256, hashedToken[:])
+	if err != nil {
+		panic(fmt.Sprintf("could not sign test token: %v", err))
+	}
+	code, err := json.Marshal(ec.ActivationCode{
+		Token:     string(tokenJSON),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	})
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal test activation code: %v", err))
+	}
+	return base64.StdEncoding.EncodeToString(code)
+}
@@ -0,0 +1,257 @@
+package testing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+)
+
+// FakeAPIServer (in the enterprise/testing package) is an implementation of
+// the pachyderm enterprise api whose state is set programmatically, rather
+// than by activation codes stored in etcd. This is meant to be used to test
+// services that depend on the enterprise state, and should never be used in a
+// real Pachyderm cluster
+type FakeAPIServer struct {
+	mu sync.Mutex
+	// state is the state set by SetState, Activate or Deactivate
+	state ec.State
+	// expiry is set by SetExpiry, and if set, determines the state instead
+	expiry time.Time
+}
+
+// NewFakeAPIServer returns a FakeAPIServer whose state is NONE
+func NewFakeAPIServer() *FakeAPIServer {
+	return &FakeAPIServer{}
+}
+
+// SetState sets the state that a returns from GetState
+func (a *FakeAPIServer) SetState(state ec.State) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.state = state
+	a.expiry = time.Time{}
+}
+
+// SetExpiry causes a to return ACTIVE from GetState until 'expiry', and
+// EXPIRED afterwards
+func (a *FakeAPIServer) SetExpiry(expiry time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.state = ec.State_ACTIVE
+	a.expiry = expiry
+}
+
+func (a *FakeAPIServer) getState() ec.State {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.expiry.IsZero() && time.Now().After(a.expiry) {
+		return ec.State_EXPIRED
+	}
+	return a.state
+}
+
+// activate sets a's state to ACTIVE (with no expiration) if 'code' is set
+func (a *FakeAPIServer) activate(code string) error {
+	if code == "" {
+		return fmt.Errorf("error validating activation code: activation code is empty")
+	}
+	a.SetState(ec.State_ACTIVE)
+	return nil
+}
+
+// Activate implements the Activate RPC. Any non-empty activation code
+// activates a, with no expiration
+func (a *FakeAPIServer) Activate(ctx context.Context, req *ec.ActivateRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.activate(req.ActivationCode); err != nil {
+		return nil, err
+	}
+	return &ec.ActivateResponse{State: ec.State_ACTIVE}, nil
+}
+
+// ActivateFromURL implements the ActivateFromURL RPC. Like Activate, any
+// non-empty URL activates a; nothing is downloaded
+func (a *FakeAPIServer) ActivateFromURL(ctx context.Context, req *ec.ActivateFromURLRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.activate(req.URL); err != nil {
+		return nil, err
+	}
+	return &ec.ActivateResponse{State: ec.State_ACTIVE}, nil
+}
+
+// ActivateDetached implements the ActivateDetached RPC. Like Activate, any
+// non-empty token activates a; the signature isn't checked
+func (a *FakeAPIServer) ActivateDetached(ctx context.Context, req *ec.ActivateDetachedRequest) (resp *ec.ActivateResponse, retErr error) {
+	if err := a.activate(req.Token); err != nil {
+		return nil, err
+	}
+	return &ec.ActivateResponse{State: ec.State_ACTIVE}, nil
+}
+
+// ActivatePartial implements the ActivatePartial RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) ActivatePartial(ctx context.Context, req *ec.ActivatePartialRequest) (resp *ec.ActivatePartialResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement ActivatePartial")
+}
+
+// StageActivation implements the StageActivation RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) StageActivation(ctx context.Context, req *ec.StageActivationRequest) (resp *ec.StageActivationResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement StageActivation")
+}
+
+// CommitActivation implements the CommitActivation RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) CommitActivation(ctx context.Context, req *ec.CommitActivationRequest) (resp *ec.ActivateResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement CommitActivation")
+}
+
+// GetState implements the GetState RPC. FakeAPIServer has no grace period or
+// emergency override, so the effective state is always the same as the state
+func (a *FakeAPIServer) GetState(ctx context.Context, req *ec.GetStateRequest) (resp *ec.GetStateResponse, retErr error) {
+	resp = &ec.GetStateResponse{State: a.getState()}
+	if req.IncludeEffectiveState {
+		resp.EffectiveState = &ec.EffectiveState{State: resp.State}
+	}
+	return resp, nil
+}
+
+// WatchState implements the WatchState RPC, but just returns an Unimplemented
+// error
+func (a *FakeAPIServer) WatchState(req *ec.WatchStateRequest, server ec.API_WatchStateServer) error {
+	return grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement WatchState")
+}
+
+// Deactivate implements the Deactivate RPC, setting a's state to NONE
+func (a *FakeAPIServer) Deactivate(ctx context.Context, req *ec.DeactivateRequest) (resp *ec.DeactivateResponse, retErr error) {
+	alreadyInactive := a.getState() == ec.State_NONE
+	a.SetState(ec.State_NONE)
+	return &ec.DeactivateResponse{AlreadyInactive: alreadyInactive}, nil
+}
+
+// RefreshState implements the RefreshState RPC. It's equivalent to GetState
+func (a *FakeAPIServer) RefreshState(ctx context.Context, req *ec.RefreshStateRequest) (resp *ec.RefreshStateResponse, retErr error) {
+	return &ec.RefreshStateResponse{State: a.getState()}, nil
+}
+
+// GetActivationInfo implements the GetActivationInfo RPC. The fake's tokens
+// aren't signed, so no key ID is returned
+func (a *FakeAPIServer) GetActivationInfo(ctx context.Context, req *ec.GetActivationInfoRequest) (resp *ec.GetActivationInfoResponse, retErr error) {
+	resp = &ec.GetActivationInfoResponse{State: a.getState()}
+	a.mu.Lock()
+	expiry := a.expiry
+	a.mu.Unlock()
+	if !expiry.IsZero() {
+		var err error
+		if resp.Expires, err = types.TimestampProto(expiry); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Rebuild implements the Rebuild RPC. The fake has no cache, so it's
+// equivalent to GetState
+func (a *FakeAPIServer) Rebuild(ctx context.Context, req *ec.RebuildRequest) (resp *ec.RebuildResponse, retErr error) {
+	return &ec.RebuildResponse{State: a.getState()}, nil
+}
+
+// GetQuota implements the GetQuota RPC. The fake's tokens don't impose any
+// limits
+func (a *FakeAPIServer) GetQuota(ctx context.Context, req *ec.GetQuotaRequest) (resp *ec.GetQuotaResponse, retErr error) {
+	return &ec.GetQuotaResponse{State: a.getState()}, nil
+}
+
+// CheckFeatures implements the CheckFeatures RPC. The fake's tokens enable
+// every feature, so all features are entitled while a is ACTIVE
+func (a *FakeAPIServer) CheckFeatures(ctx context.Context, req *ec.CheckFeaturesRequest) (resp *ec.CheckFeaturesResponse, retErr error) {
+	active := a.getState() == ec.State_ACTIVE
+	resp = &ec.CheckFeaturesResponse{AllEntitled: active}
+	for _, name := range req.Names {
+		resp.Features = append(resp.Features, &ec.FeatureEntitlement{Name: name, Entitled: active})
+	}
+	return resp, nil
+}
+
+// PreviewCode implements the PreviewCode RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) PreviewCode(ctx context.Context, req *ec.PreviewCodeRequest) (resp *ec.PreviewCodeResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement PreviewCode")
+}
+
+// ExportHistory implements the ExportHistory RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) ExportHistory(req *ec.ExportHistoryRequest, server ec.API_ExportHistoryServer) error {
+	return grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement ExportHistory")
+}
+
+// SetTrustedKeys implements the SetTrustedKeys RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) SetTrustedKeys(ctx context.Context, req *ec.SetTrustedKeysRequest) (resp *ec.SetTrustedKeysResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement SetTrustedKeys")
+}
+
+// ListTrustedKeys implements the ListTrustedKeys RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) ListTrustedKeys(ctx context.Context, req *ec.ListTrustedKeysRequest) (resp *ec.ListTrustedKeysResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement ListTrustedKeys")
+}
+
+// SetEmergencyOverride implements the SetEmergencyOverride RPC, but just
+// returns an Unimplemented error
+func (a *FakeAPIServer) SetEmergencyOverride(ctx context.Context, req *ec.SetEmergencyOverrideRequest) (resp *ec.SetEmergencyOverrideResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement SetEmergencyOverride")
+}
+
+// ServerTime implements the ServerTime RPC, returning the real time
+func (a *FakeAPIServer) ServerTime(ctx context.Context, req *ec.ServerTimeRequest) (resp *ec.ServerTimeResponse, retErr error) {
+	now, err := types.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &ec.ServerTimeResponse{Time: now}, nil
+}
+
+// GetConfig implements the GetConfig RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) GetConfig(ctx context.Context, req *ec.GetConfigRequest) (resp *ec.GetConfigResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement GetConfig")
+}
+
+// DebugDump implements the DebugDump RPC, returning only a's state and expiry
+func (a *FakeAPIServer) DebugDump(ctx context.Context, req *ec.DebugDumpRequest) (resp *ec.DebugDumpResponse, retErr error) {
+	resp = &ec.DebugDumpResponse{State: a.getState()}
+	a.mu.Lock()
+	expiry := a.expiry
+	a.mu.Unlock()
+	if !expiry.IsZero() {
+		var err error
+		if resp.Expires, err = types.TimestampProto(expiry); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// InjectState implements the InjectState RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) InjectState(ctx context.Context, req *ec.InjectStateRequest) (resp *ec.InjectStateResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement InjectState")
+}
+
+// SelfTest implements the SelfTest RPC, but just returns an Unimplemented
+// error
+func (a *FakeAPIServer) SelfTest(ctx context.Context, req *ec.SelfTestRequest) (resp *ec.SelfTestResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement SelfTest")
+}
+
+// ValidateCodeAsOf implements the ValidateCodeAsOf RPC, but just returns an
+// Unimplemented error
+func (a *FakeAPIServer) ValidateCodeAsOf(ctx context.Context, req *ec.ValidateCodeAsOfRequest) (resp *ec.ValidateCodeAsOfResponse, retErr error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "FakeAPIServer does not implement ValidateCodeAsOf")
+}
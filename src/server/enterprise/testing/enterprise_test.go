@@ -0,0 +1,127 @@
+package testing
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+var _ ec.APIServer = &FakeAPIServer{}
+
+// requireActive returns an interceptor like one that a downstream service
+// might use, which rejects all RPCs unless the cluster's enterprise state is
+// ACTIVE
+func requireActive(enterpriseClient ec.APIClient) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := enterpriseClient.GetState(ctx, &ec.GetStateRequest{})
+		if err != nil {
+			return nil, err
+		}
+		if resp.State != ec.State_ACTIVE {
+			return nil, grpc.Errorf(codes.FailedPrecondition, "enterprise features are %v", resp.State)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func TestFakeAPIServerDrivesGuard(t *testing.T) {
+	// Serve the fake over a real grpc connection, as a downstream service's
+	// tests would
+	fake := NewFakeAPIServer()
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	ec.RegisterAPIServer(server, fake)
+	go server.Serve(listener)
+	defer server.Stop()
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	guard := requireActive(ec.NewAPIClient(conn))
+	info := &grpc.UnaryServerInfo{FullMethod: "/downstream.API/EnterpriseOnly"}
+	call := func() error {
+		_, err := guard(context.Background(), nil, info,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		return err
+	}
+
+	require.Equal(t, codes.FailedPrecondition, grpc.Code(call()))
+	fake.SetState(ec.State_ACTIVE)
+	require.NoError(t, call())
+	fake.SetState(ec.State_EXPIRED)
+	require.Equal(t, codes.FailedPrecondition, grpc.Code(call()))
+
+	// SetExpiry makes the fake expire on its own
+	fake.SetExpiry(time.Now().Add(100 * time.Millisecond))
+	require.NoError(t, call())
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, codes.FailedPrecondition, grpc.Code(call()))
+}
+
+func TestFakeAPIServerActivate(t *testing.T) {
+	fake := NewFakeAPIServer()
+	ctx := context.Background()
+	_, err := fake.Activate(ctx, &ec.ActivateRequest{})
+	require.YesError(t, err)
+	_, err = fake.Activate(ctx, &ec.ActivateRequest{ActivationCode: "any code"})
+	require.NoError(t, err)
+	state, err := fake.GetState(ctx, &ec.GetStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, ec.State_ACTIVE, state.State)
+
+	resp, err := fake.Deactivate(ctx, &ec.DeactivateRequest{})
+	require.NoError(t, err)
+	require.False(t, resp.AlreadyInactive)
+	resp, err = fake.Deactivate(ctx, &ec.DeactivateRequest{})
+	require.NoError(t, err)
+	require.True(t, resp.AlreadyInactive)
+	state, err = fake.GetState(ctx, &ec.GetStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, ec.State_NONE, state.State)
+}
+
+func TestGenerateTestCode(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	claims := ec.Claims{
+		Expires:         time.Now().Add(time.Hour).Truncate(time.Second),
+		IssuedAt:        time.Now().Add(-time.Hour).Truncate(time.Second),
+		MaxNodes:        3,
+		MaxPipelines:    4,
+		MaxStorageBytes: 5,
+		Serial:          6,
+		Environment:     "staging",
+		Features:        []string{"auth", "pfs"},
+		KeyID:           ec.KeyID(&key.PublicKey),
+		SchemaVersion:   2,
+		SLA:             "24x7",
+	}
+	code := GenerateTestCode(key, claims)
+	require.Equal(t, code, GenerateTestCode(key, claims))
+
+	// Generated codes verify, with the claims they were generated with
+	verified, err := ec.VerifyCode(code, []*rsa.PublicKey{&key.PublicKey})
+	require.NoError(t, err)
+	require.True(t, claims.Expires.Equal(verified.Expires))
+	require.True(t, claims.IssuedAt.Equal(verified.IssuedAt))
+	verified.Expires, verified.IssuedAt = claims.Expires, claims.IssuedAt
+	require.Equal(t, claims, verified)
+
+	// ...but only with the key that signed them
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = ec.VerifyCode(code, []*rsa.PublicKey{&otherKey.PublicKey})
+	require.YesError(t, err)
+}
@@ -156,6 +156,22 @@ func New10sBackOff() *ExponentialBackOff {
 	return b.withCanonicalRandomizationFactor().withReset()
 }
 
+// NewCappedBackOff returns an ExponentialBackOff whose interval starts at
+// 'initial' and grows exponentially until it reaches 'max', after which it
+// stays at 'max'. The backoff stops once 'maxElapsed' has passed (or never, if
+// 'maxElapsed' is 0).
+func NewCappedBackOff(initial, max, maxElapsed time.Duration) *ExponentialBackOff {
+	b := &ExponentialBackOff{
+		InitialInterval:     initial,
+		RandomizationFactor: DefaultRandomizationFactor,
+		Multiplier:          DefaultMultiplier,
+		MaxInterval:         max,
+		MaxElapsedTime:      maxElapsed,
+		Clock:               SystemClock,
+	}
+	return b.withCanonicalRandomizationFactor().withReset()
+}
+
 type systemClock struct{}
 
 func (t systemClock) Now() time.Time {
@@ -1,6 +1,9 @@
 package backoff
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // An Operation is executing by Retry() or RetryNotify().
 // The operation will be retried using a backoff policy if it returns an error.
@@ -27,11 +30,22 @@ func Retry(o Operation, b BackOff) error { return RetryNotify(o, b, nil) }
 // RetryNotify calls notify function with the error and wait duration
 // for each failed attempt before sleep.
 func RetryNotify(operation Operation, b BackOff, notify Notify) error {
+	return RetryNotifyCtx(context.Background(), operation, b, notify)
+}
+
+// RetryNotifyCtx is like RetryNotify, but stops retrying as soon as ctx is
+// canceled, even if it's sleeping between attempts, and returns ctx.Err().
+// An operation that is already running when ctx is canceled isn't
+// interrupted, unless it also observes ctx.
+func RetryNotifyCtx(ctx context.Context, operation Operation, b BackOff, notify Notify) error {
 	var err error
 	var next time.Duration
 
 	b.Reset()
 	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err = operation(); err == nil {
 			return nil
 		}
@@ -46,6 +60,12 @@ func RetryNotify(operation Operation, b BackOff, notify Notify) error {
 			}
 		}
 
-		time.Sleep(next)
+		timer := time.NewTimer(next)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
 	}
 }
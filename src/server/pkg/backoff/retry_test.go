@@ -1,9 +1,11 @@
 package backoff
 
 import (
+	"context"
 	"errors"
 	"log"
 	"testing"
+	"time"
 )
 
 func TestRetry(t *testing.T) {
@@ -32,3 +34,37 @@ func TestRetry(t *testing.T) {
 		t.Errorf("invalid number of retries: %d", i)
 	}
 }
+
+func TestRetryNotifyCtxCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	f := func() error {
+		calls++
+		return errors.New("error")
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	// Cancellation interrupts the (hour-long) sleep after the first attempt
+	start := time.Now()
+	err := RetryNotifyCtx(ctx, f, NewConstantBackOff(time.Hour), nil)
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, but got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RetryNotifyCtx took %v to return after cancellation", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("invalid number of calls: %d", calls)
+	}
+
+	// A canceled context stops RetryNotifyCtx before the operation runs
+	if err := RetryNotifyCtx(ctx, f, NewConstantBackOff(time.Hour), nil); err != context.Canceled {
+		t.Errorf("expected context.Canceled, but got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("invalid number of calls: %d", calls)
+	}
+}
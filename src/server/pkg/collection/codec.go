@@ -0,0 +1,225 @@
+package collection
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Codec determines how a collection serializes the values that it stores in
+// etcd. All of the codecs below can decode values written by any of them, so
+// that a collection's codec can be changed without migrating the values that
+// it already holds.
+type Codec interface {
+	Marshal(val proto.Marshaler) ([]byte, error)
+	Unmarshal(data []byte, val proto.Unmarshaler) error
+}
+
+var (
+	// ProtoCodec stores values in etcd in the protobuf wire format. This is the
+	// default codec.
+	ProtoCodec Codec = protoCodec{}
+	// JSONCodec stores values in etcd as JSON, so that they can be read by
+	// humans (e.g. with etcdctl). Collections using JSONCodec must be created
+	// with a non-nil template, which is used to decode values for watchers.
+	JSONCodec Codec = jsonCodec{}
+)
+
+// GzipCodec returns a codec that encodes values with 'base', and then
+// compresses those whose encoding is at least 'threshold' bytes with gzip
+// (unless that doesn't make them smaller), to save space in etcd. Smaller
+// values are stored as 'base' stores them. Like JSONCodec, collections using
+// it must be created with a non-nil template.
+func GzipCodec(threshold int, base Codec) Codec {
+	return gzipCodec{threshold: threshold, base: base}
+}
+
+type gzipCodec struct {
+	threshold int
+	base      Codec
+}
+
+func (c gzipCodec) Marshal(val proto.Marshaler) ([]byte, error) {
+	data, err := c.base.Marshal(val)
+	if err != nil || len(data) < c.threshold {
+		return data, err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() >= len(data) {
+		return data, nil
+	}
+	return buf.Bytes(), nil
+}
+
+func (c gzipCodec) Unmarshal(data []byte, val proto.Unmarshaler) error {
+	return c.base.Unmarshal(data, val)
+}
+
+type protoCodec struct{}
+
+func (protoCodec) Marshal(val proto.Marshaler) ([]byte, error) {
+	return val.Marshal()
+}
+
+func (protoCodec) Unmarshal(data []byte, val proto.Unmarshaler) error {
+	data, err := gunzip(data)
+	if err != nil {
+		return err
+	}
+	if isJSON(data) {
+		return jsonCodec{}.Unmarshal(data, val)
+	}
+	return unmarshalProto(data, val)
+}
+
+// unmarshalProto decodes the protobuf 'data' into 'val', replacing its
+// contents rather than merging into them (as val.Unmarshal alone would), so
+// that messages reused across reads don't keep fields from earlier ones
+func unmarshalProto(data []byte, val proto.Unmarshaler) error {
+	if msg, ok := val.(proto.Message); ok {
+		msg.Reset()
+	}
+	return val.Unmarshal(data)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(val proto.Marshaler) ([]byte, error) {
+	msg, ok := val.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal %T as JSON: not a proto.Message", val)
+	}
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (jsonCodec) Unmarshal(data []byte, val proto.Unmarshaler) error {
+	data, err := gunzip(data)
+	if err != nil {
+		return err
+	}
+	if !isJSON(data) {
+		return unmarshalProto(data, val)
+	}
+	msg, ok := val.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal JSON into %T: not a proto.Message", val)
+	}
+	// Like unmarshalProto, replace the message's contents
+	msg.Reset()
+	return jsonpb.Unmarshal(bytes.NewReader(data), msg)
+}
+
+// isJSON returns true if 'data' was written by jsonCodec rather than
+// protoCodec. JSON-encoded messages always start with '{', which as the first
+// byte of a protobuf would start a (deprecated) group with field number 15.
+func isJSON(data []byte) bool {
+	return len(data) > 0 && data[0] == '{'
+}
+
+// isGzip returns true if 'data' was compressed by gzipCodec. gzip streams
+// always start with the bytes 0x1f 0x8b, and 0x1f can't start a protobuf, as
+// it has an invalid wire type (7), or JSON.
+func isGzip(data []byte) bool {
+	return len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// gunzip decompresses 'data' if it was compressed by gzipCodec, and
+// otherwise returns it unchanged
+func gunzip(data []byte) ([]byte, error) {
+	if !isGzip(data) {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress value: %v", err)
+	}
+	defer r.Close()
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress value: %v", err)
+	}
+	return decompressed, nil
+}
+
+// transcode converts a value stored with this collection's codec into the
+// protobuf wire format, which is what watch.Event.Unmarshal expects
+func (c *collection) transcode(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	if c.template == nil {
+		return nil, fmt.Errorf("collection %s has no template; cannot decode watch events", c.prefix)
+	}
+	val := proto.Clone(c.template)
+	if err := c.codec.Unmarshal(data, val.(proto.Unmarshaler)); err != nil {
+		return nil, err
+	}
+	return val.(proto.Marshaler).Marshal()
+}
+
+func (c *collection) transcodeEvent(ev *watch.Event) error {
+	value, err := c.transcode(ev.Value)
+	if err != nil {
+		return err
+	}
+	prevValue, err := c.transcode(ev.PrevValue)
+	if err != nil {
+		return err
+	}
+	ev.Value, ev.PrevValue = value, prevValue
+	return nil
+}
+
+// decodeWatcher wraps 'watcher' so that the values in the events it delivers
+// are in the protobuf wire format, regardless of this collection's codec
+func (c *collection) decodeWatcher(watcher watch.Watcher) watch.Watcher {
+	if c.codec == ProtoCodec {
+		return watcher
+	}
+	eventCh := make(chan *watch.Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(eventCh)
+		defer watcher.Close()
+		for {
+			var ev *watch.Event
+			var ok bool
+			select {
+			case ev, ok = <-watcher.Watch():
+			case <-done:
+				return
+			}
+			if !ok {
+				return
+			}
+			if ev.Type != watch.EventError {
+				if err := c.transcodeEvent(ev); err != nil {
+					ev = &watch.Event{Type: watch.EventError, Err: err}
+				}
+			}
+			select {
+			case eventCh <- ev:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return watch.MakeWatcher(eventCh, done)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/gogo/protobuf/proto"
 )
 
@@ -28,10 +30,18 @@ type collection struct {
 	// keyCheck is a function that checks if a key is valid.  Invalid keys
 	// cannot be created.
 	keyCheck func(string) error
+	// codec determines how values are serialized in etcd
+	codec Codec
 }
 
-// NewCollection creates a new collection.
+// NewCollection creates a new collection whose values are stored as protobufs.
 func NewCollection(etcdClient *etcd.Client, prefix string, indexes []Index, template proto.Message, keyCheck func(string) error) Collection {
+	return NewCollectionWithCodec(etcdClient, prefix, indexes, template, keyCheck, ProtoCodec)
+}
+
+// NewCollectionWithCodec is like NewCollection, except that values are
+// serialized using 'codec'. A nil codec is the same as ProtoCodec.
+func NewCollectionWithCodec(etcdClient *etcd.Client, prefix string, indexes []Index, template proto.Message, keyCheck func(string) error, codec Codec) Collection {
 	// We want to ensure that the prefix always ends with a trailing
 	// slash.  Otherwise, when you list the items under a collection
 	// such as `foo`, you might end up listing items under `foobar`
@@ -39,6 +49,9 @@ func NewCollection(etcdClient *etcd.Client, prefix string, indexes []Index, temp
 	if len(prefix) > 0 && prefix[len(prefix)-1] != '/' {
 		prefix = prefix + "/"
 	}
+	if codec == nil {
+		codec = ProtoCodec
+	}
 
 	return &collection{
 		prefix:     prefix,
@@ -46,6 +59,7 @@ func NewCollection(etcdClient *etcd.Client, prefix string, indexes []Index, temp
 		indexes:    indexes,
 		template:   template,
 		keyCheck:   keyCheck,
+		codec:      codec,
 	}
 }
 
@@ -98,7 +112,10 @@ func (c *readWriteCollection) Get(key string, val proto.Unmarshaler) error {
 	if valStr == "" {
 		return ErrNotFound{c.prefix, key}
 	}
-	return val.Unmarshal([]byte(valStr))
+	if err := c.codec.Unmarshal([]byte(valStr), val); err != nil {
+		return ErrDecode{c.prefix, key, err}
+	}
+	return nil
 }
 
 func cloneProtoMsg(original proto.Marshaler) proto.Unmarshaler {
@@ -196,7 +213,10 @@ func (c *readWriteCollection) PutTTL(key string, val proto.Marshaler, ttl int64)
 			}
 		}
 	}
-	bytes, _ := val.Marshal()
+	bytes, err := c.codec.Marshal(val)
+	if err != nil {
+		return err
+	}
 	c.stm.Put(c.Path(key), string(bytes), options...)
 	return nil
 }
@@ -236,6 +256,38 @@ func (c *readWriteCollection) Delete(key string) error {
 	return nil
 }
 
+func (c *readWriteCollection) DeleteIfExists(key string) (bool, error) {
+	if err := c.Delete(key); err != nil {
+		if _, ok := err.(ErrNotFound); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *readWriteCollection) CompareAndDelete(key string, expected proto.Message) (bool, error) {
+	current := proto.Clone(expected)
+	current.Reset()
+	unmarshaler, ok := current.(proto.Unmarshaler)
+	if !ok {
+		return false, fmt.Errorf("cannot compare %T: not a proto.Unmarshaler", expected)
+	}
+	if err := c.Get(key, unmarshaler); err != nil {
+		if _, ok := err.(ErrNotFound); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	if !proto.Equal(current, expected) {
+		return false, nil
+	}
+	if err := c.Delete(key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (c *readWriteCollection) DeleteAll() {
 	for _, index := range c.indexes {
 		// Delete indexes
@@ -329,7 +381,10 @@ func (c *readonlyCollection) Get(key string, val proto.Unmarshaler) error {
 		return ErrNotFound{c.prefix, key}
 	}
 
-	return val.Unmarshal(resp.Kvs[0].Value)
+	if err := c.codec.Unmarshal(resp.Kvs[0].Value, val); err != nil {
+		return ErrDecode{c.prefix, key, err}
+	}
+	return nil
 }
 
 // an indirect iterator goes through a list of keys and retrieve those
@@ -355,6 +410,11 @@ func (i *indirectIterator) Next(key *string, val proto.Unmarshaler) (ok bool, re
 	return false, nil
 }
 
+func (i *indirectIterator) Close() error {
+	i.index = len(i.resp.Kvs)
+	return nil
+}
+
 func (c *readonlyCollection) GetByIndex(index Index, val interface{}) (Iterator, error) {
 	valStr := fmt.Sprintf("%s", val)
 	resp, err := c.etcdClient.Get(c.ctx, c.indexDir(index, valStr), etcd.WithPrefix(), etcd.WithSort(etcd.SortByModRevision, etcd.SortDescend))
@@ -376,13 +436,15 @@ func (c *readonlyCollection) List() (Iterator, error) {
 		return nil, err
 	}
 	return &iterator{
-		resp: resp,
+		resp:  resp,
+		codec: c.codec,
 	}, nil
 }
 
 type iterator struct {
 	index int
 	resp  *etcd.GetResponse
+	codec Codec
 }
 
 func (c *readonlyCollection) Count() (int64, error) {
@@ -399,7 +461,7 @@ func (i *iterator) Next(key *string, val proto.Unmarshaler) (ok bool, retErr err
 		i.index++
 
 		*key = path.Base(string(kv.Key))
-		if err := val.Unmarshal(kv.Value); err != nil {
+		if err := i.codec.Unmarshal(kv.Value, val); err != nil {
 			return false, err
 		}
 
@@ -408,14 +470,122 @@ func (i *iterator) Next(key *string, val proto.Unmarshaler) (ok bool, retErr err
 	return false, nil
 }
 
+func (i *iterator) Close() error {
+	i.index = len(i.resp.Kvs)
+	return nil
+}
+
+func (c *readonlyCollection) ListPaged(pageSize int64) (Iterator, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive, but was %d", pageSize)
+	}
+	return &pagedIterator{
+		col:      c,
+		pageSize: pageSize,
+		nextKey:  c.prefix,
+		more:     true,
+	}, nil
+}
+
+// pagedIterator is the iterator returned by ListPaged. It reads the next page
+// of the collection from etcd whenever the current page is exhausted. All
+// pages are read at the revision of the first page, so that the iterator
+// sees a consistent snapshot of the collection, unless that revision is
+// compacted during iteration.
+type pagedIterator struct {
+	col      *readonlyCollection
+	pageSize int64
+	page     []*mvccpb.KeyValue
+	// nextKey is the key at which the next page starts, and more is false once
+	// the last page has been read
+	nextKey  string
+	more     bool
+	revision int64
+	closed   bool
+}
+
+func (i *pagedIterator) Next(key *string, val proto.Unmarshaler) (ok bool, retErr error) {
+	if i.closed {
+		return false, nil
+	}
+	if err := i.col.ctx.Err(); err != nil {
+		return false, err
+	}
+	if len(i.page) == 0 {
+		if !i.more {
+			return false, nil
+		}
+		if err := i.readPage(); err != nil {
+			return false, err
+		}
+		if len(i.page) == 0 {
+			return false, nil
+		}
+	}
+	kv := i.page[0]
+	i.page = i.page[1:]
+	*key = path.Base(string(kv.Key))
+	if err := i.col.codec.Unmarshal(kv.Value, val); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readPage reads the next page of the collection into i.page
+func (i *pagedIterator) readPage() error {
+	opts := []etcd.OpOption{
+		etcd.WithRange(etcd.GetPrefixRangeEnd(i.col.prefix)),
+		etcd.WithLimit(i.pageSize),
+	}
+	if i.revision != 0 {
+		opts = append(opts, etcd.WithRev(i.revision))
+	}
+	resp, err := i.col.etcdClient.Get(i.col.ctx, i.nextKey, opts...)
+	if err == rpctypes.ErrCompacted && i.revision != 0 {
+		// The revision that the earlier pages were read at has been compacted,
+		// so the snapshot is gone. Continue from the latest revision instead;
+		// items aren't repeated or skipped, as pages are read in key order, but
+		// items written since the first page may be returned.
+		i.revision = 0
+		return i.readPage()
+	}
+	if err != nil {
+		return err
+	}
+	if i.revision == 0 {
+		i.revision = resp.Header.Revision
+	}
+	i.page = resp.Kvs
+	i.more = resp.More
+	if len(resp.Kvs) > 0 {
+		// The smallest key after the last key in this page
+		i.nextKey = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+	return nil
+}
+
+func (i *pagedIterator) Close() error {
+	i.closed = true
+	i.page = nil
+	return nil
+}
+
 // Watch a collection, returning the current content of the collection as
 // well as any future additions.
 func (c *readonlyCollection) Watch() (watch.Watcher, error) {
-	return watch.NewWatcher(c.ctx, c.etcdClient, c.prefix)
+	watcher, err := watch.NewWatcher(c.ctx, c.etcdClient, c.prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeWatcher(watcher), nil
 }
 
 func (c *readonlyCollection) WatchWithPrev() (watch.Watcher, error) {
-	return watch.NewWatcherWithPrev(c.ctx, c.etcdClient, c.prefix)
+	watcher, err := watch.NewWatcherWithPrev(c.ctx, c.etcdClient, c.prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeWatcher(watcher), nil
 }
 
 // WatchByIndex watches items in a collection that match a particular index
@@ -480,11 +650,15 @@ func (c *readonlyCollection) WatchByIndex(index Index, val interface{}) (watch.W
 			eventCh <- directEv
 		}
 	}()
-	return watch.MakeWatcher(eventCh, done), nil
+	return c.decodeWatcher(watch.MakeWatcher(eventCh, done)), nil
 }
 
 // WatchOne watches a given item.  The first value returned from the watch
 // will be the current value of the item.
 func (c *readonlyCollection) WatchOne(key string) (watch.Watcher, error) {
-	return watch.NewWatcher(c.ctx, c.etcdClient, c.Path(key))
+	watcher, err := watch.NewWatcher(c.ctx, c.etcdClient, c.Path(key))
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeWatcher(watcher), nil
 }
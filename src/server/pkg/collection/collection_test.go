@@ -2,6 +2,9 @@ package collection
 
 import (
 	"context"
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/client/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
 
 	etcd "github.com/coreos/etcd/clientv3"
@@ -24,7 +28,7 @@ func TestIndex(t *testing.T) {
 	require.NoError(t, err)
 	uuidPrefix := uuid.NewWithoutDashes()
 
-	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{pipelineIndex}, &pps.JobInfo{})
+	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{pipelineIndex}, &pps.JobInfo{}, nil)
 
 	j1 := &pps.JobInfo{
 		Job:      &pps.Job{"j1"},
@@ -84,7 +88,7 @@ func TestIndexWatch(t *testing.T) {
 	require.NoError(t, err)
 	uuidPrefix := uuid.NewWithoutDashes()
 
-	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{pipelineIndex}, &pps.JobInfo{})
+	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{pipelineIndex}, &pps.JobInfo{}, nil)
 
 	j1 := &pps.JobInfo{
 		Job:      &pps.Job{"j1"},
@@ -180,7 +184,7 @@ func TestMultiIndex(t *testing.T) {
 	require.NoError(t, err)
 	uuidPrefix := uuid.NewWithoutDashes()
 
-	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{inputsMultiIndex}, &pps.JobInfo{})
+	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{inputsMultiIndex}, &pps.JobInfo{}, nil)
 
 	j1 := &pps.JobInfo{
 		Job: &pps.Job{"j1"},
@@ -285,6 +289,490 @@ func TestMultiIndex(t *testing.T) {
 	require.Equal(t, j2, job)
 }
 
+func TestCodecs(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	for _, codec := range []Codec{ProtoCodec, JSONCodec, GzipCodec(0, ProtoCodec)} {
+		uuidPrefix := uuid.NewWithoutDashes()
+		jobInfos := NewCollectionWithCodec(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil, codec)
+		jobInfosReadonly := jobInfos.ReadOnly(context.Background())
+		watcher, err := jobInfosReadonly.Watch()
+		require.NoError(t, err)
+
+		j1 := &pps.JobInfo{
+			Job:      &pps.Job{ID: "j1"},
+			Pipeline: &pps.Pipeline{Name: "p1"},
+		}
+		_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			return jobInfos.ReadWrite(stm).Put(j1.Job.ID, j1)
+		})
+		require.NoError(t, err)
+
+		// Check the raw value in etcd
+		resp, err := etcdClient.Get(context.Background(), jobInfos.Path(j1.Job.ID))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Kvs))
+		expected, err := codec.Marshal(j1)
+		require.NoError(t, err)
+		require.Equal(t, expected, resp.Kvs[0].Value)
+		if codec == JSONCodec {
+			require.Matches(t, `"pipeline":{"name":"p1"}`, string(resp.Kvs[0].Value))
+		}
+
+		// Read through the collection
+		job := new(pps.JobInfo)
+		require.NoError(t, jobInfosReadonly.Get(j1.Job.ID, job))
+		require.Equal(t, j1, job)
+		_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			return jobInfos.ReadWrite(stm).Get(j1.Job.ID, job)
+		})
+		require.NoError(t, err)
+		require.Equal(t, j1, job)
+		iter, err := jobInfosReadonly.List()
+		require.NoError(t, err)
+		var ID string
+		ok, err := iter.Next(&ID, job)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, j1, job)
+
+		// Watch events are always decoded with event.Unmarshal
+		event := <-watcher.Watch()
+		require.NoError(t, event.Err)
+		require.Equal(t, watch.EventPut, event.Type)
+		require.NoError(t, event.Unmarshal(&ID, job))
+		require.Equal(t, j1, job)
+		watcher.Close()
+	}
+}
+
+func TestCodecsReplaceMessages(t *testing.T) {
+	for _, codec := range []Codec{ProtoCodec, JSONCodec, GzipCodec(0, ProtoCodec)} {
+		data, err := codec.Marshal(&pps.JobInfo{
+			Job:    &pps.Job{ID: "j2"},
+			Inputs: []*pps.JobInput{{Name: "b"}},
+		})
+		require.NoError(t, err)
+
+		// Decoding into a message that's been used before replaces its
+		// contents, rather than merging into them
+		job := &pps.JobInfo{
+			Job:      &pps.Job{ID: "j1"},
+			Pipeline: &pps.Pipeline{Name: "p1"},
+			Inputs:   []*pps.JobInput{{Name: "a"}},
+		}
+		require.NoError(t, codec.Unmarshal(data, job))
+		require.Equal(t, &pps.JobInfo{
+			Job:    &pps.Job{ID: "j2"},
+			Inputs: []*pps.JobInput{{Name: "b"}},
+		}, job)
+	}
+}
+
+func TestChangeCodec(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	j1 := &pps.JobInfo{
+		Job:      &pps.Job{ID: "j1"},
+		Pipeline: &pps.Pipeline{Name: "p1"},
+	}
+	gzipCodec := GzipCodec(0, ProtoCodec)
+	for _, codecs := range [][2]Codec{
+		{ProtoCodec, JSONCodec}, {JSONCodec, ProtoCodec},
+		{gzipCodec, ProtoCodec}, {gzipCodec, JSONCodec}, {ProtoCodec, gzipCodec},
+	} {
+		uuidPrefix := uuid.NewWithoutDashes()
+		before := NewCollectionWithCodec(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil, codecs[0])
+		after := NewCollectionWithCodec(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil, codecs[1])
+		_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			return before.ReadWrite(stm).Put(j1.Job.ID, j1)
+		})
+		require.NoError(t, err)
+
+		// Values written with the old codec can be read with the new one
+		job := new(pps.JobInfo)
+		require.NoError(t, after.ReadOnly(context.Background()).Get(j1.Job.ID, job))
+		require.Equal(t, j1, job)
+		_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			return after.ReadWrite(stm).Get(j1.Job.ID, job)
+		})
+		require.NoError(t, err)
+		require.Equal(t, j1, job)
+	}
+}
+
+func TestGzipCodec(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	small := &pps.JobInfo{Job: &pps.Job{ID: "small"}}
+	large := &pps.JobInfo{Job: &pps.Job{ID: "large"}, Pipeline: &pps.Pipeline{Name: strings.Repeat("p", 4096)}}
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollectionWithCodec(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil, GzipCodec(1024, ProtoCodec))
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		if err := jobInfos.ReadWrite(stm).Put(small.Job.ID, small); err != nil {
+			return err
+		}
+		return jobInfos.ReadWrite(stm).Put(large.Job.ID, large)
+	})
+	require.NoError(t, err)
+
+	// Only values over the threshold are compressed
+	stored := func(key string) []byte {
+		resp, err := etcdClient.Get(context.Background(), jobInfos.Path(key))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Kvs))
+		return resp.Kvs[0].Value
+	}
+	smallData, err := small.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, smallData, stored(small.Job.ID))
+	largeData, err := large.Marshal()
+	require.NoError(t, err)
+	require.True(t, isGzip(stored(large.Job.ID)))
+	require.True(t, len(stored(large.Job.ID)) < len(largeData))
+
+	// Compressed values are decompressed transparently, by any codec
+	job := new(pps.JobInfo)
+	require.NoError(t, jobInfos.ReadOnly(context.Background()).Get(large.Job.ID, job))
+	require.Equal(t, large, job)
+	plain := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil)
+	require.NoError(t, plain.ReadOnly(context.Background()).Get(large.Job.ID, job))
+	require.Equal(t, large, job)
+}
+
+func TestSTMPanic(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+
+	// A panic in 'apply' can be recovered by NewSTM's caller
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			panic("apply bug")
+		})
+	}()
+	require.Equal(t, "apply bug", recovered)
+}
+
+func TestSTMAbortReason(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	key := uuid.NewWithoutDashes()
+
+	// A transaction whose reads are invalidated on every attempt gives up, and
+	// says why
+	var attempts int
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		attempts++
+		stm.Get(key)
+		_, err := etcdClient.Put(context.Background(), key, fmt.Sprintf("%d", attempts))
+		require.NoError(t, err)
+		stm.Put(key, "stm")
+		return nil
+	})
+	require.YesError(t, err)
+	abort, ok := err.(ErrTransactionAborted)
+	require.True(t, ok, "unexpected error type %T: %v", err, err)
+	require.Equal(t, AbortConflict, abort.Reason)
+	require.Equal(t, maxSTMConflicts, abort.Attempts)
+	require.Equal(t, maxSTMConflicts, attempts)
+	resp, err := etcdClient.Get(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%d", attempts), string(resp.Kvs[0].Value))
+
+	// A transaction that uses a lease that doesn't exist is rejected by etcd
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		stm.Put(key, "stm", etcd.WithLease(etcd.LeaseID(1<<62)))
+		return nil
+	})
+	require.YesError(t, err)
+	abort, ok = err.(ErrTransactionAborted)
+	require.True(t, ok, "unexpected error type %T: %v", err, err)
+	require.Equal(t, AbortLease, abort.Reason)
+	require.Equal(t, 1, abort.Attempts)
+	require.Matches(t, "lease error", err.Error())
+}
+
+func TestDeleteIfExists(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil)
+
+	j1 := &pps.JobInfo{Job: &pps.Job{ID: "j1"}}
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		return jobInfos.ReadWrite(stm).Put(j1.Job.ID, j1)
+	})
+	require.NoError(t, err)
+
+	var existed bool
+	deleteJ1 := func(stm STM) error {
+		var err error
+		existed, err = jobInfos.ReadWrite(stm).DeleteIfExists(j1.Job.ID)
+		return err
+	}
+	_, err = NewSTM(context.Background(), etcdClient, deleteJ1)
+	require.NoError(t, err)
+	require.True(t, existed)
+	count, err := jobInfos.ReadOnly(context.Background()).Count()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+
+	// Deleting j1 again succeeds, but reports that there was nothing to delete
+	_, err = NewSTM(context.Background(), etcdClient, deleteJ1)
+	require.NoError(t, err)
+	require.False(t, existed)
+}
+
+func TestGetErrors(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil)
+	getRW := func(key string) error {
+		var getErr error
+		_, err := NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			getErr = jobInfos.ReadWrite(stm).Get(key, &pps.JobInfo{})
+			return nil
+		})
+		require.NoError(t, err)
+		return getErr
+	}
+
+	// A missing key is reported as not found
+	err = jobInfos.ReadOnly(context.Background()).Get("missing", &pps.JobInfo{})
+	require.YesError(t, err)
+	_, ok := err.(ErrNotFound)
+	require.True(t, ok, "expected ErrNotFound, got %T: %v", err, err)
+	_, ok = getRW("missing").(ErrNotFound)
+	require.True(t, ok)
+
+	// A key whose value can't be decoded is reported as such, not as missing
+	_, err = etcdClient.Put(context.Background(), jobInfos.Path("corrupt"), "\xff\xff\xff")
+	require.NoError(t, err)
+	err = jobInfos.ReadOnly(context.Background()).Get("corrupt", &pps.JobInfo{})
+	require.YesError(t, err)
+	decodeErr, ok := err.(ErrDecode)
+	require.True(t, ok, "expected ErrDecode, got %T: %v", err, err)
+	require.Equal(t, "corrupt", decodeErr.Key)
+	_, ok = getRW("corrupt").(ErrDecode)
+	require.True(t, ok)
+}
+
+func TestCompareAndDelete(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollection(etcdClient, uuidPrefix, []Index{pipelineIndex}, &pps.JobInfo{}, nil)
+
+	j1 := &pps.JobInfo{
+		Job:      &pps.Job{ID: "j1"},
+		Pipeline: &pps.Pipeline{Name: "p1"},
+	}
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		return jobInfos.ReadWrite(stm).Put(j1.Job.ID, j1)
+	})
+	require.NoError(t, err)
+
+	var deleted bool
+	compareAndDelete := func(expected *pps.JobInfo) error {
+		_, err := NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			var err error
+			deleted, err = jobInfos.ReadWrite(stm).CompareAndDelete(j1.Job.ID, expected)
+			return err
+		})
+		return err
+	}
+
+	// A value that doesn't match isn't deleted
+	require.NoError(t, compareAndDelete(&pps.JobInfo{
+		Job:      &pps.Job{ID: "j1"},
+		Pipeline: &pps.Pipeline{Name: "p2"},
+	}))
+	require.False(t, deleted)
+	count, err := jobInfos.ReadOnly(context.Background()).Count()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// A value that matches is deleted, along with its index entries
+	require.NoError(t, compareAndDelete(j1))
+	require.True(t, deleted)
+	count, err = jobInfos.ReadOnly(context.Background()).Count()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+	iter, err := jobInfos.ReadOnly(context.Background()).GetByIndex(pipelineIndex, j1.Pipeline)
+	require.NoError(t, err)
+	var key string
+	ok, err := iter.Next(&key, &pps.JobInfo{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Once the value is gone, there is nothing to delete
+	require.NoError(t, compareAndDelete(j1))
+	require.False(t, deleted)
+}
+
+func TestListPaged(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil)
+	// Another collection whose prefix starts with this one's must not be listed
+	others := NewCollection(etcdClient, uuidPrefix+"other", nil, &pps.JobInfo{}, nil)
+
+	numJobs := 10
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		for i := 0; i < numJobs; i++ {
+			id := fmt.Sprintf("j%02d", i)
+			if err := jobInfos.ReadWrite(stm).Put(id, &pps.JobInfo{Job: &pps.Job{ID: id}}); err != nil {
+				return err
+			}
+		}
+		return others.ReadWrite(stm).Put("other", &pps.JobInfo{Job: &pps.Job{ID: "other"}})
+	})
+	require.NoError(t, err)
+
+	// Every item is returned, in key order, whatever the page size
+	for _, pageSize := range []int64{1, 3, int64(numJobs), 100} {
+		iter, err := jobInfos.ReadOnly(context.Background()).ListPaged(pageSize)
+		require.NoError(t, err)
+		var key string
+		jobInfo := &pps.JobInfo{}
+		for i := 0; i < numJobs; i++ {
+			ok, err := iter.Next(&key, jobInfo)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, fmt.Sprintf("j%02d", i), key)
+			require.Equal(t, key, jobInfo.Job.ID)
+		}
+		ok, err := iter.Next(&key, jobInfo)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NoError(t, iter.Close())
+	}
+
+	// Items written during iteration aren't returned
+	iter, err := jobInfos.ReadOnly(context.Background()).ListPaged(3)
+	require.NoError(t, err)
+	var key string
+	ok, err := iter.Next(&key, &pps.JobInfo{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		return jobInfos.ReadWrite(stm).Put("j99", &pps.JobInfo{Job: &pps.Job{ID: "j99"}})
+	})
+	require.NoError(t, err)
+	n := 1
+	for {
+		ok, err := iter.Next(&key, &pps.JobInfo{})
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		n++
+	}
+	require.Equal(t, numJobs, n)
+
+	_, err = jobInfos.ReadOnly(context.Background()).ListPaged(0)
+	require.YesError(t, err)
+}
+
+func TestListPagedCompacted(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil)
+	put := func(id string) {
+		_, err := NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			return jobInfos.ReadWrite(stm).Put(id, &pps.JobInfo{Job: &pps.Job{ID: id}})
+		})
+		require.NoError(t, err)
+	}
+	for i := 0; i < 6; i++ {
+		put(fmt.Sprintf("j%02d", i))
+	}
+
+	iter, err := jobInfos.ReadOnly(context.Background()).ListPaged(2)
+	require.NoError(t, err)
+	var keys []string
+	var key string
+	for i := 0; i < 2; i++ {
+		ok, err := iter.Next(&key, &pps.JobInfo{})
+		require.NoError(t, err)
+		require.True(t, ok)
+		keys = append(keys, key)
+	}
+	// Compact away the revision that the first page was read at
+	put("j99")
+	resp, err := etcdClient.Get(context.Background(), "/")
+	require.NoError(t, err)
+	_, err = etcdClient.Compact(context.Background(), resp.Header.Revision)
+	require.NoError(t, err)
+
+	// Iteration continues where it left off
+	for {
+		ok, err := iter.Next(&key, &pps.JobInfo{})
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		keys = append(keys, key)
+	}
+	require.Equal(t, []string{"j00", "j01", "j02", "j03", "j04", "j05", "j99"}, keys)
+}
+
+func TestListPagedCanceled(t *testing.T) {
+	etcdClient, err := getEtcdClient()
+	require.NoError(t, err)
+	uuidPrefix := uuid.NewWithoutDashes()
+	jobInfos := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil)
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		for i := 0; i < 10; i++ {
+			id := fmt.Sprintf("j%02d", i)
+			if err := jobInfos.ReadWrite(stm).Put(id, &pps.JobInfo{Job: &pps.Job{ID: id}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	goroutines := runtime.NumGoroutine()
+
+	// Once the context is canceled, iteration stops, even mid-page
+	ctx, cancel := context.WithCancel(context.Background())
+	iter, err := jobInfos.ReadOnly(ctx).ListPaged(3)
+	require.NoError(t, err)
+	var key string
+	ok, err := iter.Next(&key, &pps.JobInfo{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	cancel()
+	_, err = iter.Next(&key, &pps.JobInfo{})
+	require.YesError(t, err)
+	require.Equal(t, context.Canceled, err)
+	require.NoError(t, iter.Close())
+
+	// Closing an iterator early stops it too
+	iter, err = jobInfos.ReadOnly(context.Background()).ListPaged(3)
+	require.NoError(t, err)
+	ok, err = iter.Next(&key, &pps.JobInfo{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, iter.Close())
+	ok, err = iter.Next(&key, &pps.JobInfo{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Iterators don't leave anything running
+	require.NoError(t, backoff.Retry(func() error {
+		if n := runtime.NumGoroutine(); n > goroutines {
+			return fmt.Errorf("%d goroutines are running, but only %d were before", n, goroutines)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+}
+
 func getEtcdClient() (*etcd.Client, error) {
 	etcdClient, err := etcd.New(etcd.Config{
 		Endpoints:   []string{"localhost:2379"},
@@ -24,6 +24,19 @@ func (e ErrExists) Error() string {
 	return fmt.Sprintf("%s %s already exists", e.Type, e.Key)
 }
 
+// ErrDecode indicates that a key exists, but its value couldn't be decoded
+// (e.g. because it's corrupt). Get returns it rather than ErrNotFound, so
+// that callers can tell a missing value from a corrupt one.
+type ErrDecode struct {
+	Type string
+	Key  string
+	Err  error
+}
+
+func (e ErrDecode) Error() string {
+	return fmt.Sprintf("could not decode %s %s: %v", e.Type, e.Key, e.Err)
+}
+
 // ErrMalformedValue indicates that a value was malformed, such as when it was
 // supposed to be parseable as an int but wasn't.
 type ErrMalformedValue struct {
@@ -35,3 +48,49 @@ type ErrMalformedValue struct {
 func (e ErrMalformedValue) Error() string {
 	return fmt.Sprintf("malformed value at %s/%s: %s", e.Type, e.Key, e.Val)
 }
+
+// AbortReason is the reason that NewSTM gave up on a transaction
+type AbortReason int
+
+const (
+	// AbortConflict indicates that the keys read by the transaction were
+	// modified concurrently on every attempt, up to maxSTMConflicts times
+	AbortConflict AbortReason = iota
+	// AbortLease indicates that etcd rejected the transaction because a lease
+	// that it used had expired or didn't exist
+	AbortLease
+	// AbortAuth indicates that etcd rejected the transaction's credentials, or
+	// didn't permit it to access its keys
+	AbortAuth
+)
+
+func (r AbortReason) String() string {
+	switch r {
+	case AbortConflict:
+		return "conflict"
+	case AbortLease:
+		return "lease error"
+	case AbortAuth:
+		return "auth error"
+	default:
+		return fmt.Sprintf("AbortReason(%d)", int(r))
+	}
+}
+
+// ErrTransactionAborted indicates that NewSTM gave up on a transaction,
+// either because of an etcd error (see Err) or because it kept conflicting
+// with concurrent writes.
+type ErrTransactionAborted struct {
+	Reason AbortReason
+	// Attempts is the number of times that the transaction was attempted
+	Attempts int
+	// Err is the error returned by etcd, or nil if Reason is AbortConflict
+	Err error
+}
+
+func (e ErrTransactionAborted) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("etcd transaction aborted (%s) after %d attempts", e.Reason, e.Attempts)
+	}
+	return fmt.Sprintf("etcd transaction aborted (%s) after %d attempts: %v", e.Reason, e.Attempts, e.Err)
+}
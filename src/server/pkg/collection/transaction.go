@@ -19,9 +19,15 @@ package collection
 
 import (
 	v3 "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"golang.org/x/net/context"
 )
 
+// maxSTMConflicts is the number of times that NewSTM attempts a transaction
+// whose reads are invalidated by concurrent writes before giving up with an
+// ErrTransactionAborted
+const maxSTMConflicts = 100
+
 // STM is an interface for software transactional memory.
 type STM interface {
 	// Get returns the value for a key and inserts the key in the txn's read set.
@@ -48,7 +54,9 @@ type STM interface {
 // stmError safely passes STM errors through panic to the STM error channel.
 type stmError struct{ err error }
 
-// NewSTM intiates a new STM operation. It uses a serializable model.
+// NewSTM intiates a new STM operation. It uses a serializable model. If the
+// transaction keeps conflicting with concurrent writes, or etcd rejects it
+// because of a lease or auth error, the error is an ErrTransactionAborted.
 func NewSTM(ctx context.Context, c *v3.Client, apply func(STM) error) (*v3.TxnResponse, error) {
 	return newSTMSerializable(ctx, c, apply)
 }
@@ -79,23 +87,29 @@ func newSTMReadCommitted(ctx context.Context, c *v3.Client, apply func(STM) erro
 type stmResponse struct {
 	resp *v3.TxnResponse
 	err  error
+	// panicked is the value that apply panicked with, if it panicked
+	panicked interface{}
 }
 
 func runSTM(s STM, apply func(STM) error) (*v3.TxnResponse, error) {
 	outc := make(chan stmResponse, 1)
 	go func() {
+		attempts := 0
 		defer func() {
 			if r := recover(); r != nil {
 				e, ok := r.(stmError)
 				if !ok {
-					// client apply panicked
-					panic(r)
+					// client apply panicked. Re-panic in the caller's goroutine,
+					// so that the caller can recover
+					outc <- stmResponse{panicked: r}
+					return
 				}
-				outc <- stmResponse{nil, e.err}
+				outc <- stmResponse{nil, abortError(e.err, attempts), nil}
 			}
 		}()
 		var out stmResponse
 		for {
+			attempts++
 			s.reset()
 			if out.err = apply(s); out.err != nil {
 				break
@@ -103,13 +117,34 @@ func runSTM(s STM, apply func(STM) error) (*v3.TxnResponse, error) {
 			if out.resp = s.commit(); out.resp != nil {
 				break
 			}
+			if attempts == maxSTMConflicts {
+				out.err = ErrTransactionAborted{Reason: AbortConflict, Attempts: attempts}
+				break
+			}
 		}
 		outc <- out
 	}()
 	r := <-outc
+	if r.panicked != nil {
+		panic(r.panicked)
+	}
 	return r.resp, r.err
 }
 
+// abortError wraps 'err', an error returned by etcd during the transaction's
+// 'attempts'th attempt, in an ErrTransactionAborted if it's a lease or auth
+// error. Other errors (e.g. the context being canceled) are returned as-is.
+func abortError(err error, attempts int) error {
+	switch err {
+	case rpctypes.ErrLeaseNotFound:
+		return ErrTransactionAborted{Reason: AbortLease, Attempts: attempts, Err: err}
+	case rpctypes.ErrPermissionDenied, rpctypes.ErrAuthFailed, rpctypes.ErrInvalidAuthToken,
+		rpctypes.ErrUserEmpty:
+		return ErrTransactionAborted{Reason: AbortAuth, Attempts: attempts, Err: err}
+	}
+	return err
+}
+
 // stm implements repeatable-read software transactional memory over etcd
 type stm struct {
 	client *v3.Client
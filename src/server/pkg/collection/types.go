@@ -68,6 +68,16 @@ type ReadWriteCollection interface {
 	PutTTL(key string, val proto.Marshaler, ttl int64) error
 	Create(key string, val proto.Marshaler) error
 	Delete(key string) error
+	// DeleteIfExists is like Delete, except that it doesn't return ErrNotFound
+	// if 'key' is absent. Instead, 'existed' indicates whether there was
+	// anything to delete.
+	DeleteIfExists(key string) (existed bool, err error)
+	// CompareAndDelete deletes 'key' only if its current value is equal to
+	// 'expected' (as determined by proto.Equal), and indicates whether it did.
+	// Like all STM operations, the comparison and the delete are applied
+	// atomically: if the value changes before the STM commits, the STM is
+	// retried.
+	CompareAndDelete(key string, expected proto.Message) (deleted bool, err error)
 	DeleteAll()
 }
 
@@ -84,9 +94,18 @@ type ReadWriteIntCollection interface {
 
 // ReadonlyCollection is a collection interface that only supports read ops.
 type ReadonlyCollection interface {
+	// Get reads the value of 'key' into 'val'. It returns ErrNotFound if
+	// there's no such key, and ErrDecode if its value can't be decoded
 	Get(key string, val proto.Unmarshaler) error
 	GetByIndex(index Index, val interface{}) (Iterator, error)
 	List() (Iterator, error)
+	// ListPaged returns an iterator over the collection in key order, which
+	// reads the collection from etcd 'pageSize' items at a time, rather than
+	// all at once like List. Next fails with the collection's context's error
+	// once that context is canceled. The iterator returns a snapshot of the
+	// collection as of the first page, unless etcd compacts that revision
+	// first, in which case it continues with the latest revision.
+	ListPaged(pageSize int64) (Iterator, error)
 	Count() (int64, error)
 	Watch() (watch.Watcher, error)
 	// WatchWithPrev is like Watch, but the events will include the previous
@@ -103,4 +122,7 @@ type Iterator interface {
 	// ok is true if the serialization was successful.  It's false if the
 	// collection has been exhausted.
 	Next(key *string, val proto.Unmarshaler) (ok bool, retErr error)
+	// Close releases the iterator's resources. Next returns false once the
+	// iterator is closed. Iterators may be closed before they're exhausted.
+	Close() error
 }
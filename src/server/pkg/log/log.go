@@ -16,16 +16,24 @@ type Logger interface {
 	LogAtLevelFromDepth(request interface{}, response interface{}, err error, duration time.Duration, level logrus.Level, depth int)
 }
 
+// FieldInjector returns fields that are added to every line that a Logger
+// emits, e.g. to correlate API logs with some piece of cluster state. It's
+// called once per line, so it must be cheap
+type FieldInjector func() logrus.Fields
+
 type logger struct {
 	*logrus.Entry
+	injectors []FieldInjector
 }
 
-// NewLogger creates a new logger
-func NewLogger(service string) Logger {
+// NewLogger creates a new logger. The fields returned by 'injectors' are
+// added to every line it emits
+func NewLogger(service string, injectors ...FieldInjector) Logger {
 	l := logrus.New()
 	l.Formatter = new(prettyFormatter)
 	return &logger{
-		l.WithFields(logrus.Fields{"service": service}),
+		Entry:     l.WithFields(logrus.Fields{"service": service}),
+		injectors: injectors,
 	}
 }
 
@@ -45,10 +53,14 @@ func (l *logger) LogAtLevelFromDepth(request interface{}, response interface{},
 	split := strings.Split(runtime.FuncForPC(pc[0]).Name(), ".")
 	method := split[len(split)-1]
 
-	fields := logrus.Fields{
-		"method":  method,
-		"request": request,
+	fields := logrus.Fields{}
+	for _, inject := range l.injectors {
+		for k, v := range inject() {
+			fields[k] = v
+		}
 	}
+	fields["method"] = method
+	fields["request"] = request
 	if response != nil {
 		fields["response"] = response
 	}
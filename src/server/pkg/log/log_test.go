@@ -0,0 +1,37 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/sirupsen/logrus"
+)
+
+func TestFieldInjector(t *testing.T) {
+	state := "NONE"
+	l := NewLogger("test.API", func() logrus.Fields {
+		return logrus.Fields{"state": state}
+	}).(*logger)
+	buf := &bytes.Buffer{}
+	l.Logger.Out = buf
+
+	l.Log("request", nil, nil, 0)
+	require.True(t, strings.Contains(buf.String(), `"state":"NONE"`), buf.String())
+
+	// The injector is called for every line, so changes are reflected
+	buf.Reset()
+	state = "ACTIVE"
+	l.Log("request", nil, nil, 0)
+	require.True(t, strings.Contains(buf.String(), `"state":"ACTIVE"`), buf.String())
+
+	// Injected fields can't replace the fields that the logger sets itself
+	l = NewLogger("test.API", func() logrus.Fields {
+		return logrus.Fields{"request": "injected"}
+	}).(*logger)
+	l.Logger.Out = buf
+	buf.Reset()
+	l.Log("request", nil, nil, 0)
+	require.True(t, strings.Contains(buf.String(), `"request":"request"`), buf.String())
+}